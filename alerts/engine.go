@@ -0,0 +1,317 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tigusigalpa/taapi-go"
+	"github.com/tigusigalpa/taapi-go/screener"
+)
+
+// DefaultDelay is how long after a candle closes rules are evaluated, giving
+// taapi.io time to pick up the closed candle
+const DefaultDelay = 5 * time.Second
+
+// RuleError reports a rule that could not be evaluated
+type RuleError struct {
+	Rule string
+	Err  error
+}
+
+func (e *RuleError) Error() string {
+	return fmt.Sprintf("alerts: rule %s: %v", e.Rule, e.Err)
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
+// SinkError reports an alert a sink failed to deliver
+type SinkError struct {
+	Alert Alert
+	Err   error
+}
+
+func (e *SinkError) Error() string {
+	return fmt.Sprintf("alerts: failed to deliver %s alert: %v", e.Alert.Rule, e.Err)
+}
+
+func (e *SinkError) Unwrap() error {
+	return e.Err
+}
+
+// Engine evaluates rules and dispatches the alerts they trigger
+type Engine struct {
+	executor taapi.BulkExecutor
+	rules    []*Rule
+	sinks    []Sink
+	store    Store
+	delay    time.Duration
+	onError  func(error)
+
+	mu    sync.Mutex
+	state *State
+
+	// now and sleep are replaced in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// New creates an engine fetching indicators with the executor, usually a
+// *taapi.Client
+func New(executor taapi.BulkExecutor) *Engine {
+	return &Engine{
+		executor: executor,
+		store:    NewMemoryStore(),
+		delay:    DefaultDelay,
+		onError:  func(error) {},
+		now:      time.Now,
+		sleep:    sleep,
+	}
+}
+
+// AddRule adds a rule
+func (e *Engine) AddRule(rule *Rule) *Engine {
+	e.rules = append(e.rules, rule)
+	return e
+}
+
+// AddSink adds a sink every alert is delivered to
+func (e *Engine) AddSink(sink Sink) *Engine {
+	e.sinks = append(e.sinks, sink)
+	return e
+}
+
+// SetStore sets the store persisting which candles rules fired on
+func (e *Engine) SetStore(store Store) *Engine {
+	e.store = store
+	return e
+}
+
+// Delay sets how long after a candle closes rules are evaluated
+func (e *Engine) Delay(delay time.Duration) *Engine {
+	e.delay = delay
+	return e
+}
+
+// OnError sets the handler receiving the *RuleError and *SinkError failures
+// of Run, which keeps running after them
+func (e *Engine) OnError(handler func(error)) *Engine {
+	e.onError = handler
+	return e
+}
+
+// Evaluate evaluates every rule once and dispatches the alerts of rules that
+// have not fired on the candle they are evaluated on yet. It returns the new
+// alerts and the rule, sink and state failures joined.
+func (e *Engine) Evaluate(ctx context.Context) ([]Alert, error) {
+	if err := e.validate(); err != nil {
+		return nil, err
+	}
+	return e.evaluate(ctx, e.rules)
+}
+
+// Run evaluates the rules once, then again after every candle close (or
+// every Rule.Every) until the context is done. Rule and sink failures are
+// passed to the OnError handler. Run returns the context error, or an error
+// if the rules are invalid or the state cannot be loaded or saved; the other
+// failures of that evaluation reach OnError first.
+func (e *Engine) Run(ctx context.Context) error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+
+	next := make(map[*Rule]time.Time, len(e.rules))
+	due := e.rules
+	for {
+		if _, err := e.evaluate(ctx, due); err != nil {
+			var stateErr *stateError
+			for _, err := range unjoin(err) {
+				if s, ok := err.(*stateError); ok {
+					stateErr = s
+					continue
+				}
+				e.onError(err)
+			}
+			if stateErr != nil {
+				return stateErr.err
+			}
+		}
+
+		now := e.now()
+		for _, rule := range due {
+			next[rule] = e.nextRun(rule, now)
+		}
+		wake := next[e.rules[0]]
+		for _, at := range next {
+			if at.Before(wake) {
+				wake = at
+			}
+		}
+
+		if err := e.sleep(ctx, wake.Sub(now)); err != nil {
+			return err
+		}
+
+		now = e.now()
+		due = nil
+		for _, rule := range e.rules {
+			if !next[rule].After(now) {
+				due = append(due, rule)
+			}
+		}
+	}
+}
+
+// validate checks the rules
+func (e *Engine) validate() error {
+	if len(e.rules) == 0 {
+		return taapi.InvalidArgumentError("alerts: at least one rule is required")
+	}
+	ids := make(map[string]bool)
+	for _, rule := range e.rules {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+		if ids[rule.ID] {
+			return taapi.InvalidArgumentError("alerts: duplicate rule id " + rule.ID)
+		}
+		ids[rule.ID] = true
+	}
+	return nil
+}
+
+// stateError wraps failures to load or save the state, which stop Run
+type stateError struct {
+	err error
+}
+
+func (e *stateError) Error() string { return e.err.Error() }
+
+func (e *stateError) Unwrap() error { return e.err }
+
+// evaluate evaluates the rules, dispatches their alerts and saves the state
+func (e *Engine) evaluate(ctx context.Context, rules []*Rule) ([]Alert, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.state == nil {
+		state, err := e.store.Load()
+		if err != nil {
+			return nil, &stateError{err: err}
+		}
+		e.state = state
+	}
+
+	var alerts []Alert
+	var errs []error
+	for _, rule := range rules {
+		now := e.now()
+		candle := rule.candle(now)
+		if fired, ok := e.state.Fired[rule.ID]; ok && !candle.After(fired) {
+			continue
+		}
+
+		alert, ok, err := e.check(ctx, rule, now, candle)
+		if err != nil {
+			errs = append(errs, &RuleError{Rule: rule.ID, Err: err})
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		// The candle is marked even if delivery fails, so a broken sink
+		// does not turn every poll into a notification
+		e.state.Fired[rule.ID] = candle
+		alerts = append(alerts, alert)
+		for _, sink := range e.sinks {
+			if err := sink.Send(ctx, alert); err != nil {
+				errs = append(errs, &SinkError{Alert: alert, Err: err})
+			}
+		}
+	}
+
+	if len(alerts) > 0 {
+		if err := e.store.Save(e.state); err != nil {
+			errs = append(errs, &stateError{err: err})
+		}
+	}
+	return alerts, errors.Join(errs...)
+}
+
+// check fetches the indicators of a rule and evaluates its condition
+func (e *Engine) check(ctx context.Context, rule *Rule, now, candle time.Time) (Alert, bool, error) {
+	result, err := screener.New(e.executor).
+		Type(rule.Target.Type).
+		Exchange(rule.Target.Exchange).
+		Interval(rule.Target.Interval).
+		Symbols(rule.Target.Symbol).
+		Where(rule.condition()).
+		Run(ctx)
+	if err != nil {
+		return Alert{}, false, err
+	}
+	if err := result.Failures[rule.Target.Symbol]; err != nil {
+		return Alert{}, false, err
+	}
+	if len(result.Matches) == 0 {
+		return Alert{}, false, nil
+	}
+
+	return Alert{
+		Rule:      rule.ID,
+		Type:      rule.Target.Type,
+		Exchange:  rule.Target.Exchange,
+		Symbol:    rule.Target.Symbol,
+		Interval:  rule.Target.Interval,
+		Condition: rule.Condition.String(),
+		Message:   rule.message(),
+		Candle:    candle,
+		Time:      now,
+		Values:    result.Matches[0].Values,
+	}, true, nil
+}
+
+// nextRun returns when a rule is next due after now: delay after the next
+// candle close, or the next multiple of Rule.Every
+func (e *Engine) nextRun(rule *Rule, now time.Time) time.Time {
+	if rule.Every > 0 {
+		return now.Truncate(rule.Every).Add(rule.Every)
+	}
+
+	// Candles are shifted by the delay, so a run just after the close of a
+	// candle is not followed by a second run for the same close
+	base := now.Add(-e.delay)
+	start := rule.Target.Interval.CandleStart(base)
+	var end time.Time
+	if rule.Target.Interval == taapi.Interval1M {
+		end = start.AddDate(0, 1, 0)
+	} else {
+		end = start.Add(rule.Target.Interval.Duration())
+	}
+	return end.Add(e.delay)
+}
+
+// sleep waits for d or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// unjoin splits an error created by errors.Join
+func unjoin(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
@@ -0,0 +1,319 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigusigalpa/taapi-go"
+	"github.com/tigusigalpa/taapi-go/screener"
+	"github.com/tigusigalpa/taapi-go/taapitest"
+)
+
+var btc1h = Target{Exchange: taapi.ExchangeBinance, Symbol: "BTC/USDT", Interval: taapi.Interval1h}
+
+// clock is a settable time source
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) now() time.Time { return c.t }
+
+// collect returns a sink recording alerts
+func collect(alerts *[]Alert) Sink {
+	return SinkFunc(func(_ context.Context, a Alert) error {
+		*alerts = append(*alerts, a)
+		return nil
+	})
+}
+
+func TestEngineEvaluateDedupesPerCandle(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, taapitest.Value(25))
+
+	c := &clock{t: time.Date(2024, 3, 14, 13, 5, 0, 0, time.UTC)}
+	var sent []Alert
+	engine := New(server.Client()).
+		AddRule(&Rule{
+			ID:        "btc-oversold",
+			Target:    btc1h,
+			Condition: Below(screener.Indicator(taapi.RSI().Period(14)), 30),
+			Message:   "BTC is oversold",
+		}).
+		AddSink(collect(&sent))
+	engine.now = c.now
+
+	alerts, err := engine.Evaluate(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, Alert{
+		Rule:      "btc-oversold",
+		Exchange:  taapi.ExchangeBinance,
+		Symbol:    "BTC/USDT",
+		Interval:  taapi.Interval1h,
+		Condition: "rsi(period=14) < 30",
+		Message:   "BTC is oversold",
+		Candle:    time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC),
+		Time:      c.t,
+		Values:    map[string]float64{"rsi(backtrack=1,period=14)": 25},
+	}, alerts[0])
+	assert.Equal(t, alerts, sent)
+	server.AssertParam(t, taapi.IndicatorRSI, "backtrack", "1")
+
+	// Same candle: no request, no alert
+	c.t = c.t.Add(30 * time.Minute)
+	alerts, err = engine.Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+	server.AssertCalled(t, taapi.IndicatorRSI, 1)
+
+	// Next candle fires again
+	c.t = c.t.Add(30 * time.Minute)
+	alerts, err = engine.Evaluate(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, time.Date(2024, 3, 14, 13, 0, 0, 0, time.UTC), alerts[0].Candle)
+	assert.Len(t, sent, 2)
+}
+
+func TestEngineEvaluatePolledRuleOnCurrentCandle(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, taapitest.Value(25))
+
+	c := &clock{t: time.Date(2024, 3, 14, 13, 5, 0, 0, time.UTC)}
+	engine := New(server.Client()).
+		AddRule(&Rule{
+			ID:        "btc-oversold",
+			Target:    btc1h,
+			Condition: Below(screener.Indicator(taapi.RSI().Period(14)), 30),
+			Every:     time.Minute,
+		})
+	engine.now = c.now
+
+	alerts, err := engine.Evaluate(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, time.Date(2024, 3, 14, 13, 0, 0, 0, time.UTC), alerts[0].Candle)
+	assert.Equal(t, map[string]float64{"rsi(period=14)": 25}, alerts[0].Values)
+	server.AssertParam(t, taapi.IndicatorRSI, "backtrack", "")
+}
+
+func TestEngineEvaluateRules(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.HandleFunc(taapi.IndicatorEMA, func(r taapitest.Request) taapitest.Response {
+		fast := r.Param("period") == "20"
+		switch {
+		case r.Param("backtrack") == "2" && fast:
+			return taapitest.Value(99)
+		case r.Param("backtrack") == "1" && fast:
+			return taapitest.Value(101)
+		}
+		return taapitest.Value(100)
+	})
+	server.Handle(taapi.IndicatorENGULFING, taapitest.Value(-100))
+	server.Handle(taapi.IndicatorHAMMER, taapitest.Value(0))
+	server.Handle(taapi.IndicatorRSI, taapitest.Value(50))
+
+	rsiCross, err := Expr("crosses_above(rsi(14), 70)")
+	require.NoError(t, err)
+
+	engine := New(server.Client()).
+		AddRule(&Rule{ID: "golden", Target: btc1h, Condition: CrossesAbove(taapi.EMA().Period(20), taapi.EMA().Period(50))}).
+		AddRule(&Rule{ID: "death", Target: btc1h, Condition: CrossesBelow(taapi.EMA().Period(20), taapi.EMA().Period(50))}).
+		AddRule(&Rule{ID: "bearish", Target: btc1h, Condition: PatternDetected(taapi.PatternBearish, taapi.IndicatorHAMMER, taapi.IndicatorENGULFING)}).
+		AddRule(&Rule{ID: "bullish", Target: btc1h, Condition: PatternDetected(taapi.PatternBullish, taapi.IndicatorENGULFING)}).
+		AddRule(&Rule{ID: "rsi", Target: btc1h, Condition: rsiCross})
+
+	alerts, err := engine.Evaluate(context.Background())
+	require.NoError(t, err)
+	var fired []string
+	for _, a := range alerts {
+		fired = append(fired, a.Rule)
+	}
+	assert.Equal(t, []string{"golden", "bearish"}, fired)
+	assert.Equal(t, "crosses_above(ema(period=20), ema(period=50))", alerts[0].Message)
+	assert.Equal(t, "bearish pattern(hammer, engulfing)", alerts[1].Message)
+}
+
+func TestEngineEvaluateErrors(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, taapitest.Value(25))
+	server.Handle(taapi.IndicatorMACD, taapitest.InvalidSymbol("BTC/USDT"))
+
+	sinkErr := errors.New("sink down")
+	var sent []Alert
+	engine := New(server.Client()).
+		AddRule(&Rule{ID: "macd", Target: btc1h, Condition: Above(screener.Indicator(taapi.MACD()).Output("valueMACD"), 0)}).
+		AddRule(&Rule{ID: "rsi", Target: btc1h, Condition: Below(screener.Indicator(taapi.RSI()), 30)}).
+		AddSink(SinkFunc(func(context.Context, Alert) error { return sinkErr })).
+		AddSink(collect(&sent))
+
+	alerts, err := engine.Evaluate(context.Background())
+	require.Error(t, err)
+	assert.Len(t, alerts, 1)
+	assert.Len(t, sent, 1, "a failing sink does not block the others")
+
+	var ruleErr *RuleError
+	require.ErrorAs(t, err, &ruleErr)
+	assert.Equal(t, "macd", ruleErr.Rule)
+	var deliveryErr *SinkError
+	require.ErrorAs(t, err, &deliveryErr)
+	assert.Equal(t, "rsi", deliveryErr.Alert.Rule)
+	assert.ErrorIs(t, err, sinkErr)
+}
+
+func TestEngineValidation(t *testing.T) {
+	cond := Below(screener.Indicator(taapi.RSI()), 30)
+	tests := []*Engine{
+		New(nil),
+		New(nil).AddRule(&Rule{Target: btc1h, Condition: cond}),
+		New(nil).AddRule(&Rule{ID: "a", Target: btc1h}),
+		New(nil).AddRule(&Rule{ID: "a", Target: Target{Interval: taapi.Interval1h}, Condition: cond}),
+		New(nil).AddRule(&Rule{ID: "a", Target: Target{Symbol: "BTC/USDT"}, Condition: cond}),
+		New(nil).AddRule(&Rule{ID: "a", Target: Target{Symbol: "BTC/USDT", Interval: "7x"}, Condition: cond}),
+		New(nil).AddRule(&Rule{ID: "a", Target: btc1h, Condition: cond}).AddRule(&Rule{ID: "a", Target: btc1h, Condition: cond}),
+	}
+	for _, engine := range tests {
+		_, err := engine.Evaluate(context.Background())
+		assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+		assert.ErrorIs(t, engine.Run(context.Background()), taapi.ErrInvalidParams)
+	}
+
+	_, err := Expr("rsi(14)")
+	assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+
+	engine := New(nil).AddRule(&Rule{ID: "a", Target: btc1h, Condition: CrossesAbove(taapi.MACD(), taapi.EMA())})
+	_, err = engine.Evaluate(context.Background())
+	assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+}
+
+func TestEngineNextRun(t *testing.T) {
+	engine := New(nil)
+	rule := &Rule{Target: btc1h}
+	at := func(h, m, s int) time.Time { return time.Date(2024, 3, 14, h, m, s, 0, time.UTC) }
+
+	assert.Equal(t, at(14, 0, 5), engine.nextRun(rule, at(13, 20, 0)))
+	assert.Equal(t, at(14, 0, 5), engine.nextRun(rule, at(13, 0, 5)), "run just after a close waits for the next one")
+	assert.Equal(t, at(13, 0, 5), engine.nextRun(rule, at(13, 0, 2)), "close not yet evaluated")
+
+	monthly := &Rule{Target: Target{Interval: taapi.Interval1M}}
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 5, 0, time.UTC), engine.nextRun(monthly, at(13, 0, 0)))
+
+	polled := &Rule{Target: btc1h, Every: 15 * time.Minute}
+	assert.Equal(t, at(13, 30, 0), engine.nextRun(polled, at(13, 20, 0)))
+}
+
+func TestEngineRun(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, taapitest.Value(25))
+	server.Handle(taapi.IndicatorEMA, taapitest.InvalidSymbol("BTC/USDT"))
+
+	c := &clock{t: time.Date(2024, 3, 14, 13, 20, 0, 0, time.UTC)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var sent []Alert
+	var failures []error
+	var waits []time.Duration
+	engine := New(server.Client()).
+		AddRule(&Rule{ID: "rsi", Target: btc1h, Condition: Below(screener.Indicator(taapi.RSI()), 30)}).
+		AddRule(&Rule{ID: "ema", Target: Target{Exchange: taapi.ExchangeBinance, Symbol: "BTC/USDT", Interval: taapi.Interval4h},
+			Condition: Above(screener.Indicator(taapi.EMA()), 0)}).
+		AddSink(collect(&sent)).
+		OnError(func(err error) { failures = append(failures, err) })
+	engine.now = c.now
+	engine.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		if len(waits) == 3 {
+			cancel()
+			return ctx.Err()
+		}
+		c.t = c.t.Add(d)
+		return nil
+	}
+
+	err := engine.Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []time.Duration{40*time.Minute + 5*time.Second, time.Hour, time.Hour}, waits)
+
+	// RSI fires on the initial run and the 14:00 and 15:00 closes, each time
+	// for the candle that just closed; EMA only ran initially since the 16:00
+	// close is never reached
+	require.Len(t, sent, 3)
+	assert.Equal(t, time.Date(2024, 3, 14, 14, 0, 0, 0, time.UTC), sent[2].Candle)
+	require.Len(t, failures, 1)
+	var ruleErr *RuleError
+	require.ErrorAs(t, failures[0], &ruleErr)
+	assert.Equal(t, "ema", ruleErr.Rule)
+}
+
+// failingStore is a store whose saves fail
+type failingStore struct {
+	*MemoryStore
+	err error
+}
+
+func (s *failingStore) Save(*State) error { return s.err }
+
+func TestEngineRunStopsWhenStateCannotBeSaved(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, taapitest.Value(25))
+
+	saveErr := errors.New("disk full")
+	sinkErr := errors.New("webhook down")
+	var failures []error
+	engine := New(server.Client()).
+		AddRule(&Rule{ID: "rsi", Target: btc1h, Condition: Below(screener.Indicator(taapi.RSI()), 30)}).
+		AddSink(SinkFunc(func(context.Context, Alert) error { return sinkErr })).
+		SetStore(&failingStore{MemoryStore: NewMemoryStore(), err: saveErr}).
+		OnError(func(err error) { failures = append(failures, err) })
+	engine.now = func() time.Time { return time.Date(2024, 3, 14, 13, 5, 0, 0, time.UTC) }
+	engine.sleep = func(context.Context, time.Duration) error {
+		t.Fatal("Run kept going after the state could not be saved")
+		return nil
+	}
+
+	err := engine.Run(context.Background())
+	assert.ErrorIs(t, err, saveErr)
+
+	// The sink failure of the same evaluation is still reported
+	require.Len(t, failures, 1)
+	var sinkFailure *SinkError
+	require.ErrorAs(t, failures[0], &sinkFailure)
+	assert.ErrorIs(t, sinkFailure, sinkErr)
+}
+
+func TestEngineRestoresState(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, taapitest.Value(25))
+
+	store := NewFileStore(t.TempDir() + "/state.json")
+	now := func() time.Time { return time.Date(2024, 3, 14, 13, 5, 0, 0, time.UTC) }
+	newEngine := func() *Engine {
+		engine := New(server.Client()).
+			AddRule(&Rule{ID: "rsi", Target: btc1h, Condition: Below(screener.Indicator(taapi.RSI()), 30)}).
+			SetStore(store)
+		engine.now = now
+		return engine
+	}
+
+	alerts, err := newEngine().Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, alerts, 1)
+
+	// A restarted engine does not repeat the alert for the same candle
+	alerts, err = newEngine().Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+}
@@ -0,0 +1,245 @@
+// Package alerts evaluates indicator rules on a schedule and dispatches
+// notifications when they trigger:
+//
+//	engine := alerts.New(client).
+//		AddRule(&alerts.Rule{
+//			ID:        "btc-oversold",
+//			Target:    alerts.Target{Exchange: taapi.ExchangeBinance, Symbol: "BTC/USDT", Interval: taapi.Interval1h},
+//			Condition: alerts.Below(screener.Indicator(taapi.RSI().Period(14)), 30),
+//		}).
+//		AddSink(alerts.NewChatWebhookSink(slackURL)).
+//		SetStore(alerts.NewFileStore("alerts.json"))
+//
+//	err := engine.Run(ctx)
+//
+// Rules are evaluated on the last closed candle shortly after every candle
+// closes; rules polled with Rule.Every are evaluated on the current candle
+// instead. A rule fires at most once per candle, and the candles rules fired
+// on are persisted so restarts do not repeat notifications.
+package alerts
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tigusigalpa/taapi-go"
+	"github.com/tigusigalpa/taapi-go/expr"
+	"github.com/tigusigalpa/taapi-go/screener"
+)
+
+// Target is the market a rule watches
+type Target = expr.Target
+
+// Rule is a condition watched on one market
+type Rule struct {
+	// ID identifies the rule in alerts and persisted state
+	ID     string
+	Target Target
+	// Condition triggers the rule, e.g. Below, CrossesAbove, PatternDetected
+	// or a compiled expression
+	Condition screener.Condition
+	// Message describes the alert; the condition by default
+	Message string
+	// Every polls the rule more often than once per candle, e.g. to catch
+	// conditions within the candle. Polled rules are evaluated on the
+	// current, still open candle rather than the last closed one; they
+	// still fire once per candle.
+	Every time.Duration
+}
+
+// validate checks the rule definition
+func (r *Rule) validate() error {
+	switch {
+	case r.ID == "":
+		return taapi.InvalidArgumentError("alerts: rule id is required")
+	case r.Condition == nil:
+		return taapi.InvalidArgumentError(fmt.Sprintf("alerts: rule %s has no condition", r.ID))
+	case isInvalid(r.Condition):
+		return fmt.Errorf("alerts: rule %s: %w", r.ID, r.Condition.(invalid).err)
+	case r.Target.Symbol == "":
+		return taapi.InvalidArgumentError(fmt.Sprintf("alerts: rule %s has no symbol", r.ID))
+	case r.Target.Interval == "":
+		return taapi.InvalidArgumentError(fmt.Sprintf("alerts: rule %s has no interval", r.ID))
+	case r.Target.Interval.Duration() == 0 && r.Target.Interval != taapi.Interval1M && r.Every <= 0:
+		return taapi.InvalidArgumentError(fmt.Sprintf("alerts: rule %s has unknown interval %s; set Every", r.ID, r.Target.Interval))
+	}
+	return nil
+}
+
+// isInvalid reports whether a condition could not be built
+func isInvalid(c screener.Condition) bool {
+	_, ok := c.(invalid)
+	return ok
+}
+
+// candle returns the open time of the candle the rule is evaluated on at
+// now: the current candle for polled rules, the last closed one otherwise
+func (r *Rule) candle(now time.Time) time.Time {
+	start := r.Target.Interval.CandleStart(now)
+	if r.Every > 0 {
+		return start
+	}
+	return r.Target.Interval.CandleStart(start.Add(-time.Nanosecond))
+}
+
+// condition returns the condition as evaluated: on the last closed candle
+// unless the rule is polled
+func (r *Rule) condition() screener.Condition {
+	if r.Every > 0 {
+		return r.Condition
+	}
+	return closedCandle{condition: r.Condition}
+}
+
+// message returns the alert message of the rule
+func (r *Rule) message() string {
+	if r.Message != "" {
+		return r.Message
+	}
+	return r.Condition.String()
+}
+
+// Above triggers while the operand is above the level
+func Above(op screener.Operand, level float64) screener.Condition {
+	return screener.GreaterThan(op, screener.Const(level))
+}
+
+// Below triggers while the operand is below the level
+func Below(op screener.Operand, level float64) screener.Condition {
+	return screener.LessThan(op, screener.Const(level))
+}
+
+// Expr compiles an expression condition such as
+// "crosses_above(rsi(14), 30)"
+func Expr(src string) (screener.Condition, error) {
+	e, err := expr.Compile(src)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsCondition() {
+		return nil, taapi.InvalidArgumentError("alerts: " + src + " is not a condition")
+	}
+	return e.Condition(), nil
+}
+
+// CrossesAbove triggers when the value output of a crosses above b, e.g. a
+// fast EMA crossing above a slow one. It is the expression crosses_above(a, b).
+func CrossesAbove(a, b taapi.ParamSet) screener.Condition {
+	return exprCondition(expr.CrossesAbove(a, b))
+}
+
+// CrossesBelow triggers when the value output of a crosses below b. It is
+// the expression crosses_below(a, b).
+func CrossesBelow(a, b taapi.ParamSet) screener.Condition {
+	return exprCondition(expr.CrossesBelow(a, b))
+}
+
+// exprCondition adapts a built expression, deferring its error to rule
+// validation
+func exprCondition(e *expr.Expression, err error) screener.Condition {
+	if err != nil {
+		return invalid{err: err}
+	}
+	return e.Condition()
+}
+
+// invalid is a condition that could not be built
+type invalid struct {
+	err error
+}
+
+func (c invalid) Requests() []taapi.ParamSet { return nil }
+
+func (c invalid) Eval(*screener.Results) (bool, error) { return false, c.err }
+
+func (c invalid) String() string { return "invalid condition" }
+
+// closedCandle evaluates a condition on the candle before the latest one
+type closedCandle struct {
+	condition screener.Condition
+}
+
+func (c closedCandle) Requests() []taapi.ParamSet {
+	requests := c.condition.Requests()
+	shifted := make([]taapi.ParamSet, len(requests))
+	for i, p := range requests {
+		shifted[i] = backtrack(p, 1)
+	}
+	return shifted
+}
+
+func (c closedCandle) Eval(r *screener.Results) (bool, error) {
+	view := screener.NewResults(r.Symbol)
+	for _, p := range c.condition.Requests() {
+		if resp, ok := r.Response(backtrack(p, 1)); ok {
+			view.Set(p, resp)
+		}
+	}
+	return c.condition.Eval(view)
+}
+
+func (c closedCandle) String() string { return c.condition.String() }
+
+// backtrack returns a copy of the parameter set n candles further back
+func backtrack(p taapi.ParamSet, n int) taapi.ParamSet {
+	params := taapi.NewIndicatorParams(p.Indicator())
+	for k, v := range p.Params() {
+		params.Set(k, v)
+	}
+	if current, ok := p.Params()["backtrack"].(int); ok {
+		n += current
+	}
+	return params.Set("backtrack", n)
+}
+
+// pattern triggers when a candlestick pattern is detected
+type pattern struct {
+	signal   taapi.PatternSignal
+	patterns []taapi.Indicator
+}
+
+// PatternDetected triggers when any of the candlestick patterns is detected
+// with the signal; taapi.PatternNone accepts both directions
+func PatternDetected(signal taapi.PatternSignal, patterns ...taapi.Indicator) screener.Condition {
+	return &pattern{signal: signal, patterns: patterns}
+}
+
+func (c *pattern) Requests() []taapi.ParamSet {
+	requests := make([]taapi.ParamSet, len(c.patterns))
+	for i, p := range c.patterns {
+		requests[i] = taapi.NewIndicatorParams(p)
+	}
+	return requests
+}
+
+func (c *pattern) Eval(r *screener.Results) (bool, error) {
+	for _, p := range c.Requests() {
+		if !p.Indicator().IsPattern() {
+			return false, taapi.InvalidArgumentError(fmt.Sprintf("alerts: %s is not a candlestick pattern", p.Indicator()))
+		}
+		resp, ok := r.Response(p)
+		if !ok {
+			return false, fmt.Errorf("alerts: no result for %s", p.Indicator())
+		}
+		result, err := resp.Pattern()
+		if err != nil {
+			return false, err
+		}
+		if result.Detected() && (c.signal == taapi.PatternNone || result.Signal == c.signal) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *pattern) String() string {
+	names := make([]string, len(c.patterns))
+	for i, p := range c.patterns {
+		names[i] = p.String()
+	}
+	if c.signal == taapi.PatternNone {
+		return fmt.Sprintf("pattern(%s)", strings.Join(names, ", "))
+	}
+	return fmt.Sprintf("%s pattern(%s)", c.signal, strings.Join(names, ", "))
+}
@@ -0,0 +1,160 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// Alert is a triggered rule
+type Alert struct {
+	Rule      string          `json:"rule"`
+	Type      taapi.AssetType `json:"type,omitempty"`
+	Exchange  taapi.Exchange  `json:"exchange,omitempty"`
+	Symbol    string          `json:"symbol"`
+	Interval  taapi.Interval  `json:"interval"`
+	Condition string          `json:"condition"`
+	Message   string          `json:"message"`
+	// Candle is the open time of the candle the rule fired on
+	Candle time.Time `json:"candle"`
+	// Time is when the rule was evaluated
+	Time time.Time `json:"time"`
+	// Values holds the indicator outputs the condition was evaluated on,
+	// keyed by label such as rsi(period=14)
+	Values map[string]float64 `json:"values,omitempty"`
+}
+
+// String returns a one-line description of the alert
+func (a Alert) String() string {
+	market := a.Symbol
+	if a.Exchange != "" {
+		market = string(a.Exchange) + " " + market
+	}
+	return fmt.Sprintf("[%s] %s %s: %s (candle %s)", a.Rule, market, a.Interval, a.Message, a.Candle.Format(time.RFC3339))
+}
+
+// Sink delivers alerts
+type Sink interface {
+	Send(ctx context.Context, alert Alert) error
+}
+
+// SinkFunc adapts a function to Sink
+type SinkFunc func(ctx context.Context, alert Alert) error
+
+// Send calls f
+func (f SinkFunc) Send(ctx context.Context, alert Alert) error {
+	return f(ctx, alert)
+}
+
+// WebhookSink posts alerts as JSON to a URL
+type WebhookSink struct {
+	url        string
+	httpClient *http.Client
+	header     http.Header
+	payload    func(Alert) interface{}
+}
+
+// NewWebhookSink creates a sink posting the alert JSON to the URL
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:        url,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		header:     make(http.Header),
+		payload:    func(a Alert) interface{} { return a },
+	}
+}
+
+// NewChatWebhookSink creates a sink posting {"text": "..."} messages, the
+// incoming webhook format of Slack, Mattermost, Rocket.Chat and others
+func NewChatWebhookSink(url string) *WebhookSink {
+	sink := NewWebhookSink(url)
+	sink.payload = func(a Alert) interface{} {
+		return map[string]string{"text": a.String()}
+	}
+	return sink
+}
+
+// SetHTTPClient sets the HTTP client used for requests
+func (s *WebhookSink) SetHTTPClient(client *http.Client) *WebhookSink {
+	s.httpClient = client
+	return s
+}
+
+// SetHeader sets a request header, e.g. for authentication
+func (s *WebhookSink) SetHeader(key, value string) *WebhookSink {
+	s.header.Set(key, value)
+	return s
+}
+
+// Send posts the alert
+func (s *WebhookSink) Send(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(s.payload(alert))
+	if err != nil {
+		return fmt.Errorf("alerts: failed to encode alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("alerts: invalid webhook request: %w", err)
+	}
+	for key, values := range s.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("alerts: webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("alerts: webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return nil
+}
+
+// writerSink writes one line per alert
+type writerSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink creates a sink writing one line per alert to w
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{w: w}
+}
+
+// Stdout returns a sink printing alerts to standard output
+func Stdout() Sink {
+	return NewWriterSink(os.Stdout)
+}
+
+func (s *writerSink) Send(_ context.Context, alert Alert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := fmt.Fprintln(s.w, alert.String())
+	return err
+}
+
+// ChannelSink delivers alerts to a channel, blocking until the alert is
+// received or the context is done
+func ChannelSink(ch chan<- Alert) Sink {
+	return SinkFunc(func(ctx context.Context, alert Alert) error {
+		select {
+		case ch <- alert:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
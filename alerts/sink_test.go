@@ -0,0 +1,103 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+var testAlert = Alert{
+	Rule:      "btc-oversold",
+	Exchange:  taapi.ExchangeBinance,
+	Symbol:    "BTC/USDT",
+	Interval:  taapi.Interval1h,
+	Condition: "rsi(period=14) < 30",
+	Message:   "rsi(period=14) < 30",
+	Candle:    time.Date(2024, 3, 14, 13, 0, 0, 0, time.UTC),
+	Time:      time.Date(2024, 3, 14, 13, 0, 5, 0, time.UTC),
+	Values:    map[string]float64{"rsi(period=14)": 25},
+}
+
+func TestAlertString(t *testing.T) {
+	assert.Equal(t, "[btc-oversold] binance BTC/USDT 1h: rsi(period=14) < 30 (candle 2024-03-14T13:00:00Z)", testAlert.String())
+}
+
+// webhook records the bodies and headers posted to it
+func webhook(t *testing.T, status int) (*httptest.Server, *[]map[string]interface{}, *http.Header) {
+	var bodies []map[string]interface{}
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		header = r.Header.Clone()
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("nope"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &bodies, &header
+}
+
+func TestWebhookSink(t *testing.T) {
+	server, bodies, header := webhook(t, http.StatusNoContent)
+
+	sink := NewWebhookSink(server.URL).SetHeader("Authorization", "Bearer token")
+	require.NoError(t, sink.Send(context.Background(), testAlert))
+
+	require.Len(t, *bodies, 1)
+	body := (*bodies)[0]
+	assert.Equal(t, "btc-oversold", body["rule"])
+	assert.Equal(t, "BTC/USDT", body["symbol"])
+	assert.Equal(t, "2024-03-14T13:00:00Z", body["candle"])
+	assert.Equal(t, map[string]interface{}{"rsi(period=14)": 25.0}, body["values"])
+	assert.Equal(t, "Bearer token", header.Get("Authorization"))
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+}
+
+func TestChatWebhookSink(t *testing.T) {
+	server, bodies, _ := webhook(t, http.StatusOK)
+
+	require.NoError(t, NewChatWebhookSink(server.URL).Send(context.Background(), testAlert))
+	assert.Equal(t, []map[string]interface{}{{"text": testAlert.String()}}, *bodies)
+}
+
+func TestWebhookSinkErrors(t *testing.T) {
+	server, _, _ := webhook(t, http.StatusInternalServerError)
+	err := NewWebhookSink(server.URL).Send(context.Background(), testAlert)
+	assert.EqualError(t, err, "alerts: webhook returned status 500: nope")
+
+	err = NewWebhookSink("http://127.0.0.1:1").SetHTTPClient(&http.Client{Timeout: time.Second}).
+		Send(context.Background(), testAlert)
+	assert.ErrorContains(t, err, "alerts: webhook request failed")
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+	require.NoError(t, sink.Send(context.Background(), testAlert))
+	require.NoError(t, sink.Send(context.Background(), testAlert))
+	assert.Equal(t, testAlert.String()+"\n"+testAlert.String()+"\n", buf.String())
+}
+
+func TestChannelSink(t *testing.T) {
+	ch := make(chan Alert, 1)
+	sink := ChannelSink(ch)
+	require.NoError(t, sink.Send(context.Background(), testAlert))
+	assert.Equal(t, testAlert, <-ch)
+
+	// A full channel blocks until the context is done
+	ch <- testAlert
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, sink.Send(ctx, testAlert), context.Canceled)
+}
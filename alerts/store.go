@@ -0,0 +1,119 @@
+package alerts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// State records the candle every rule last fired on
+type State struct {
+	Fired map[string]time.Time `json:"fired"`
+}
+
+// NewState creates an empty state
+func NewState() *State {
+	return &State{Fired: make(map[string]time.Time)}
+}
+
+// Store persists the state of an engine
+type Store interface {
+	Load() (*State, error)
+	Save(state *State) error
+}
+
+// MemoryStore keeps the state in memory; it is the default store
+type MemoryStore struct {
+	mu    sync.Mutex
+	state *State
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{state: NewState()}
+}
+
+// Load returns a copy of the stored state
+func (s *MemoryStore) Load() (*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.clone(), nil
+}
+
+// Save stores a copy of the state
+func (s *MemoryStore) Save(state *State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state.clone()
+	return nil
+}
+
+// FileStore persists the state as JSON
+type FileStore struct {
+	path string
+}
+
+// NewFileStore creates a store saving to path. A missing file loads as an
+// empty state.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load reads the state from the file
+func (s *FileStore) Load() (*State, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewState(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("alerts: failed to read state: %w", err)
+	}
+
+	state := NewState()
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("alerts: invalid state file %s: %w", s.path, err)
+	}
+	if state.Fired == nil {
+		state.Fired = make(map[string]time.Time)
+	}
+	return state, nil
+}
+
+// Save writes the state to a temporary file and renames it over the store
+// file, so a crash never leaves a truncated state
+func (s *FileStore) Save(state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("alerts: failed to encode state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("alerts: failed to write state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("alerts: failed to write state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("alerts: failed to write state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("alerts: failed to write state: %w", err)
+	}
+	return nil
+}
+
+// clone returns a deep copy of the state
+func (s *State) clone() *State {
+	c := NewState()
+	for rule, candle := range s.Fired {
+		c.Fired[rule] = candle
+	}
+	return c
+}
@@ -0,0 +1,61 @@
+package alerts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	store := NewFileStore(path)
+
+	state, err := store.Load()
+	require.NoError(t, err)
+	assert.Empty(t, state.Fired)
+
+	candle := time.Date(2024, 3, 14, 13, 0, 0, 0, time.UTC)
+	state.Fired["btc-oversold"] = candle
+	require.NoError(t, store.Save(state))
+
+	loaded, err := NewFileStore(path).Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"btc-oversold": candle}, loaded.Fired)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary files are left behind")
+}
+
+func TestFileStoreInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+
+	_, err := NewFileStore(path).Load()
+	assert.ErrorContains(t, err, "invalid state file")
+
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0o644))
+	state, err := NewFileStore(path).Load()
+	require.NoError(t, err)
+	assert.NotNil(t, state.Fired)
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	state, err := store.Load()
+	require.NoError(t, err)
+
+	state.Fired["a"] = time.Unix(0, 0)
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.Empty(t, loaded.Fired, "loaded states are copies")
+
+	require.NoError(t, store.Save(state))
+	loaded, err = store.Load()
+	require.NoError(t, err)
+	assert.Len(t, loaded.Fired, 1)
+}
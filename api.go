@@ -0,0 +1,127 @@
+package taapi
+
+import "context"
+
+// IndicatorFetcher fetches single indicator values. Client implements it;
+// depend on it instead of *Client to substitute a mock in tests.
+type IndicatorFetcher interface {
+	FetchIndicator(ctx context.Context, direct DirectRequest) (*IndicatorResponse, error)
+}
+
+// BulkExecutor executes bulk requests
+type BulkExecutor interface {
+	ExecuteBulk(ctx context.Context, constructs ...BulkConstruct) (*BulkResponse, error)
+}
+
+// SeriesFetcher fetches backtracked indicator series, one response per
+// candle
+type SeriesFetcher interface {
+	FetchSeries(ctx context.Context, direct DirectRequest) ([]*IndicatorResponse, error)
+}
+
+// CandleFetcher fetches OHLCV candles
+type CandleFetcher interface {
+	FetchCandles(ctx context.Context, direct DirectRequest) ([]*Candle, error)
+}
+
+// ManualExecutor calculates indicators on custom candles
+type ManualExecutor interface {
+	ExecuteManual(ctx context.Context, manual ManualRequest) (*IndicatorResponse, error)
+}
+
+// API combines all request interfaces implemented by Client. The taapimock
+// package is generated from these declarations; run go generate there after
+// changing them.
+type API interface {
+	IndicatorFetcher
+	SeriesFetcher
+	CandleFetcher
+	BulkExecutor
+	ManualExecutor
+}
+
+var _ API = (*Client)(nil)
+
+// DirectRequest describes a direct GET request
+type DirectRequest struct {
+	// Type is the asset class; it defaults to crypto
+	Type      AssetType
+	Exchange  Exchange
+	Symbol    string
+	Interval  Interval
+	Indicator Indicator
+	Params    map[string]interface{}
+}
+
+// BulkConstruct describes one construct of a bulk request
+type BulkConstruct struct {
+	// Type is the asset class; it defaults to crypto
+	Type       AssetType
+	Exchange   Exchange
+	Symbol     string
+	Interval   Interval
+	Indicators []ParamSet
+}
+
+// ManualRequest describes a manual POST request
+type ManualRequest struct {
+	Indicator Indicator
+	Candles   []*Candle
+	Params    map[string]interface{}
+}
+
+// FetchIndicator executes a direct request
+func (c *Client) FetchIndicator(ctx context.Context, req DirectRequest) (*IndicatorResponse, error) {
+	return c.Direct().
+		Type(req.Type).
+		Exchange(req.Exchange).
+		Symbol(req.Symbol).
+		Interval(req.Interval).
+		Indicator(req.Indicator).
+		WithParams(req.Params).
+		GetContext(ctx)
+}
+
+// FetchSeries executes a direct request with backtracks, e.g. with
+// Params{"backtracks": 100}
+func (c *Client) FetchSeries(ctx context.Context, req DirectRequest) ([]*IndicatorResponse, error) {
+	return c.Direct().
+		Type(req.Type).
+		Exchange(req.Exchange).
+		Symbol(req.Symbol).
+		Interval(req.Interval).
+		Indicator(req.Indicator).
+		WithParams(req.Params).
+		GetSeriesContext(ctx)
+}
+
+// FetchCandles fetches the candles of a market, ordered from oldest to
+// newest. The indicator of the request is ignored.
+func (c *Client) FetchCandles(ctx context.Context, req DirectRequest) ([]*Candle, error) {
+	builder := c.Candles(req.Exchange, req.Symbol, req.Interval).Type(req.Type)
+	for k, v := range req.Params {
+		builder.WithParam(k, v)
+	}
+	return builder.GetContext(ctx)
+}
+
+// ExecuteBulk executes a bulk request with the given constructs
+func (c *Client) ExecuteBulk(ctx context.Context, constructs ...BulkConstruct) (*BulkResponse, error) {
+	bulk := c.Bulk()
+	for _, construct := range constructs {
+		builder := c.Construct(construct.Exchange, construct.Symbol, construct.Interval).Type(construct.Type)
+		for _, p := range construct.Indicators {
+			builder.Add(p)
+		}
+		bulk.AddConstruct(builder)
+	}
+	return bulk.ExecuteContext(ctx)
+}
+
+// ExecuteManual executes a manual request
+func (c *Client) ExecuteManual(ctx context.Context, req ManualRequest) (*IndicatorResponse, error) {
+	return c.Manual(req.Indicator).
+		WithCandleStructs(req.Candles).
+		WithParams(req.Params).
+		ExecuteContext(ctx)
+}
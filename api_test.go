@@ -0,0 +1,125 @@
+package taapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientImplementsAPI(t *testing.T) {
+	var payloads []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rsi":
+			assert.Equal(t, "BTC/USDT", r.URL.Query().Get("symbol"))
+			assert.Equal(t, "21", r.URL.Query().Get("period"))
+			w.Write([]byte(`{"value": 42}`))
+		case "/bulk", "/manual":
+			var payload map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			payloads = append(payloads, payload)
+			if r.URL.Path == "/bulk" {
+				w.Write([]byte(`[{"id": "macd", "indicator": "macd", "result": {"valueMACD": 1}}]`))
+			} else {
+				w.Write([]byte(`{"value": 7}`))
+			}
+		}
+	}))
+	defer server.Close()
+
+	var api API = NewClient("secret").SetBaseURL(server.URL)
+	ctx := context.Background()
+
+	resp, err := api.FetchIndicator(ctx, DirectRequest{
+		Exchange:  ExchangeBinance,
+		Symbol:    "BTCUSDT",
+		Interval:  Interval1h,
+		Indicator: IndicatorRSI,
+		Params:    map[string]interface{}{"period": 21},
+	})
+	require.NoError(t, err)
+	value, _ := resp.GetFloat("value")
+	assert.Equal(t, 42.0, value)
+
+	bulk, err := api.ExecuteBulk(ctx, BulkConstruct{
+		Exchange:   ExchangeBinance,
+		Symbol:     "BTC/USDT",
+		Interval:   Interval1h,
+		Indicators: []ParamSet{MACD().FastPeriod(12).ID("macd")},
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, bulk.FindByID("macd"))
+
+	resp, err = api.ExecuteManual(ctx, ManualRequest{
+		Indicator: IndicatorSMA,
+		Candles:   []*Candle{NewCandle(time.Unix(1700000000, 0), 1, 2, 0.5, 1.5, 10)},
+	})
+	require.NoError(t, err)
+	value, _ = resp.GetFloat("value")
+	assert.Equal(t, 7.0, value)
+
+	require.Len(t, payloads, 2)
+	constructs := payloads[0]["construct"].([]interface{})
+	indicators := constructs[0].(map[string]interface{})["indicators"].([]interface{})
+	assert.Equal(t, "macd", indicators[0].(map[string]interface{})["indicator"])
+	assert.Equal(t, "sma", payloads[1]["indicator"])
+}
+
+func TestClientFetchSeriesAndCandles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2", r.URL.Query().Get("backtracks"))
+		switch r.URL.Path {
+		case "/ema":
+			assert.Equal(t, "20", r.URL.Query().Get("period"))
+			w.Write([]byte(`[{"value": 11, "backtrack": 0}, {"value": 10, "backtrack": 1}]`))
+		case "/candle":
+			w.Write([]byte(`[{"timestamp": 1700003600, "open": 2, "high": 3, "low": 1, "close": 2.5, "volume": 5},
+				{"timestamp": 1700000000, "open": 1, "high": 2, "low": 0.5, "close": 1.5, "volume": 10}]`))
+		}
+	}))
+	defer server.Close()
+
+	var api API = NewClient("secret").SetBaseURL(server.URL)
+	ctx := context.Background()
+	direct := DirectRequest{Exchange: ExchangeBinance, Symbol: "BTC/USDT", Interval: Interval1h}
+
+	series := direct
+	series.Indicator = IndicatorEMA
+	series.Params = map[string]interface{}{"period": 20, "backtracks": 2}
+	responses, err := api.FetchSeries(ctx, series)
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+	value, _ := responses[1].GetFloat("value")
+	assert.Equal(t, 10.0, value)
+
+	direct.Params = map[string]interface{}{"backtracks": 2}
+	candles, err := api.FetchCandles(ctx, direct)
+	require.NoError(t, err)
+	require.Len(t, candles, 2)
+	assert.Equal(t, int64(1700000000), candles[0].Timestamp)
+}
+
+func TestClientAPIValidation(t *testing.T) {
+	client := NewClient("secret")
+
+	_, err := client.FetchIndicator(context.Background(), DirectRequest{Symbol: "BTC/USDT"})
+	assert.ErrorIs(t, err, ErrInvalidParams)
+
+	_, err = client.FetchSeries(context.Background(), DirectRequest{Symbol: "BTC/USDT", Interval: Interval1h})
+	assert.ErrorIs(t, err, ErrInvalidParams)
+
+	_, err = client.FetchCandles(context.Background(), DirectRequest{Symbol: "BTC/USDT"})
+	assert.ErrorIs(t, err, ErrInvalidParams)
+
+	_, err = client.ExecuteBulk(context.Background())
+	assert.ErrorIs(t, err, ErrInvalidParams)
+
+	_, err = client.ExecuteManual(context.Background(), ManualRequest{Indicator: IndicatorSMA})
+	assert.ErrorIs(t, err, ErrInvalidParams)
+}
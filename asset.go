@@ -0,0 +1,188 @@
+package taapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// AssetType represents the asset class of a symbol. The zero value is
+// treated as AssetCrypto.
+type AssetType string
+
+const (
+	AssetCrypto AssetType = "crypto"
+	AssetStocks AssetType = "stocks"
+	AssetForex  AssetType = "forex"
+)
+
+// String returns the string representation of the asset type
+func (a AssetType) String() string {
+	return string(a)
+}
+
+// IsValid checks if the asset type is supported
+func (a AssetType) IsValid() bool {
+	switch a {
+	case "", AssetCrypto, AssetStocks, AssetForex:
+		return true
+	}
+	return false
+}
+
+// RequiresExchange reports whether symbols of the asset class are quoted per
+// exchange. Only crypto requests carry an exchange.
+func (a AssetType) RequiresExchange() bool {
+	return a == "" || a == AssetCrypto
+}
+
+// assetIntervals lists the intervals of asset classes that do not depend on
+// an exchange. Crypto intervals come from the market catalog.
+var assetIntervals = map[AssetType][]Interval{
+	AssetStocks: {
+		Interval1m, Interval5m, Interval15m, Interval30m,
+		Interval1h, Interval2h, Interval4h,
+		Interval1d, Interval1w, Interval1M,
+	},
+	AssetForex: {
+		Interval1m, Interval5m, Interval15m, Interval30m,
+		Interval1h, Interval2h, Interval4h,
+		Interval1d, Interval1w, Interval1M,
+	},
+}
+
+// Intervals returns the intervals supported by the asset class. Crypto
+// intervals depend on the exchange and are nil here; see MarketCatalog.
+func (a AssetType) Intervals() []Interval {
+	return append([]Interval(nil), assetIntervals[a]...)
+}
+
+// SupportsInterval reports whether the asset class supports the interval.
+// Crypto intervals are checked against the exchange by the market catalog.
+func (a AssetType) SupportsInterval(interval Interval) bool {
+	if a.RequiresExchange() {
+		return true
+	}
+	for _, i := range assetIntervals[a] {
+		if i == interval {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	stockSymbolPattern = regexp.MustCompile(`^[A-Z0-9]{1,10}([.-][A-Z0-9]{1,4})?$`)
+	forexSymbolPattern = regexp.MustCompile(`^([A-Z]{3})[/_\-]?([A-Z]{3})$`)
+)
+
+// FormatSymbol normalizes a symbol to the format taapi.io expects for the
+// asset class and validates it. Crypto symbols are parsed with ParseSymbol
+// and returned in unified notation, stocks are tickers such as AAPL or BRK.B
+// and forex pairs are written EUR/USD.
+func (a AssetType) FormatSymbol(symbol string) (string, error) {
+	return a.FormatSymbolFor("", symbol)
+}
+
+// FormatSymbolFor is FormatSymbol for an exchange: crypto symbols are
+// written in the exchange's SymbolFormat, as builders send them. Without an
+// exchange they are returned in unified notation.
+func (a AssetType) FormatSymbolFor(exchange Exchange, symbol string) (string, error) {
+	s := strings.ToUpper(strings.TrimSpace(symbol))
+	if s == "" {
+		return "", InvalidArgumentError("symbol is required")
+	}
+
+	invalid := func() (string, error) {
+		return "", &Error{
+			Message: fmt.Sprintf("symbol %q is not a valid %s symbol", symbol, a.kind()),
+			Kind:    ErrInvalidSymbol,
+		}
+	}
+
+	switch a {
+	case AssetStocks:
+		if !stockSymbolPattern.MatchString(s) {
+			return invalid()
+		}
+		return s, nil
+	case AssetForex:
+		m := forexSymbolPattern.FindStringSubmatch(s)
+		if m == nil {
+			return invalid()
+		}
+		return m[1] + "/" + m[2], nil
+	default:
+		// Asset codes are kept as written, so exchange-specific codes such as
+		// XBT reach the exchange unchanged
+		sym, err := parseSymbol(s, false)
+		if err != nil {
+			// A concatenated symbol with an unknown quote, e.g. BTCPLN, is
+			// left for the market catalog or taapi.io to judge
+			if assetCodePattern.MatchString(s) {
+				return s, nil
+			}
+			return invalid()
+		}
+		if exchange == "" {
+			return sym.String(), nil
+		}
+		return sym.Format(exchange)
+	}
+}
+
+// kind returns the asset class name, treating the zero value as crypto
+func (a AssetType) kind() AssetType {
+	if a == "" {
+		return AssetCrypto
+	}
+	return a
+}
+
+// validateTarget checks the asset class, symbol and interval of a request
+// and returns the symbol formatted for taapi.io. Crypto symbols are formatted
+// for the exchange and checked against the client's market catalog.
+func (c *Client) validateTarget(asset AssetType, exchange, symbol, interval string) (string, error) {
+	if !asset.IsValid() {
+		return "", InvalidArgumentError(fmt.Sprintf("asset type %s is not supported", asset))
+	}
+
+	if asset.RequiresExchange() {
+		formatted, err := asset.FormatSymbolFor(Exchange(exchange), symbol)
+		if err != nil {
+			return "", err
+		}
+		if err := c.validateMarket(exchange, formatted, interval); err != nil {
+			return "", err
+		}
+		return formatted, nil
+	}
+
+	formatted, err := asset.FormatSymbol(symbol)
+	if err != nil {
+		return "", err
+	}
+	if exchange != "" {
+		return "", InvalidArgumentError(fmt.Sprintf("%s symbols do not take an exchange", asset))
+	}
+	if !asset.SupportsInterval(Interval(interval)) {
+		return "", InvalidArgumentError(fmt.Sprintf("interval %s is not supported for %s", interval, asset))
+	}
+	return formatted, nil
+}
+
+// targetParams returns the exchange, symbol, interval and type parameters of
+// a request, omitting the exchange and type when they do not apply
+func targetParams(asset AssetType, exchange, symbol, interval string) map[string]interface{} {
+	params := map[string]interface{}{
+		"symbol":   symbol,
+		"interval": interval,
+	}
+	if exchange != "" {
+		params["exchange"] = exchange
+	}
+	if asset != "" {
+		params["type"] = asset.String()
+	}
+	return params
+}
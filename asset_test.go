@@ -0,0 +1,155 @@
+package taapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetTypeFormatSymbol(t *testing.T) {
+	tests := []struct {
+		asset   AssetType
+		symbol  string
+		want    string
+		wantErr bool
+	}{
+		{AssetCrypto, "btc/usdt", "BTC/USDT", false},
+		{"", "ETH/BTC", "ETH/BTC", false},
+		{AssetCrypto, "btcusdt", "BTC/USDT", false},
+		{AssetCrypto, "FOOBAR", "FOOBAR", false},
+		{AssetCrypto, "btcpln", "BTCPLN", false},
+		{AssetCrypto, "xbtusd", "XBT/USD", false},
+		{AssetCrypto, "BTC/USDT/EUR", "", true},
+		{AssetCrypto, "BTC$USDT", "", true},
+		{AssetCrypto, "/USDT", "", true},
+		{AssetCrypto, "BTC-USDT-SWAP", "BTC/USDT:USDT", false},
+		{AssetStocks, "aapl", "AAPL", false},
+		{AssetStocks, "BRK.B", "BRK.B", false},
+		{AssetStocks, "AAPL/USD", "", true},
+		{AssetForex, "eurusd", "EUR/USD", false},
+		{AssetForex, "EUR_USD", "EUR/USD", false},
+		{AssetForex, "EUR/USD", "EUR/USD", false},
+		{AssetForex, "EURO/USD", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.asset.FormatSymbol(tt.symbol)
+		if tt.wantErr {
+			assert.True(t, errors.Is(err, ErrInvalidSymbol), "%s %s", tt.asset, tt.symbol)
+			continue
+		}
+		require.NoError(t, err, "%s %s", tt.asset, tt.symbol)
+		assert.Equal(t, tt.want, got)
+	}
+}
+
+func TestAssetTypeFormatSymbolFor(t *testing.T) {
+	got, err := AssetCrypto.FormatSymbolFor(ExchangeBinanceUSDM, "BTC-USDT-SWAP")
+	require.NoError(t, err)
+	assert.Equal(t, "BTC/USDT", got)
+
+	_, err = AssetCrypto.FormatSymbolFor(ExchangeBinance, "BTC-USDT-SWAP")
+	assert.ErrorIs(t, err, ErrInvalidSymbol)
+
+	got, err = AssetForex.FormatSymbolFor("", "eurusd")
+	require.NoError(t, err)
+	assert.Equal(t, "EUR/USD", got)
+}
+
+func TestAssetTypeIntervals(t *testing.T) {
+	assert.True(t, AssetStocks.SupportsInterval(Interval1d))
+	assert.False(t, AssetStocks.SupportsInterval(Interval3m))
+	assert.False(t, AssetForex.SupportsInterval(Interval12h))
+	assert.True(t, AssetCrypto.SupportsInterval(Interval3m))
+	assert.Nil(t, AssetCrypto.Intervals())
+	assert.Contains(t, AssetForex.Intervals(), Interval1h)
+
+	assert.True(t, AssetCrypto.RequiresExchange())
+	assert.True(t, AssetType("").RequiresExchange())
+	assert.False(t, AssetStocks.RequiresExchange())
+	assert.False(t, AssetType("bonds").IsValid())
+}
+
+func TestDirectBuilderStocks(t *testing.T) {
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"value": 55.5}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL)
+	_, err := client.Direct().
+		Type(AssetStocks).
+		Symbol("aapl").
+		Interval(Interval1d).
+		Indicator(IndicatorRSI).
+		Get()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stocks"}, query["type"])
+	assert.Equal(t, []string{"AAPL"}, query["symbol"])
+	assert.NotContains(t, query, "exchange")
+}
+
+func TestBuildersValidateAssetType(t *testing.T) {
+	client := NewClient("test_secret")
+
+	err := client.Direct().
+		Type(AssetForex).
+		Symbol("EURUSD").
+		Interval(Interval3m).
+		Indicator(IndicatorRSI).
+		validate()
+	assert.True(t, errors.Is(err, ErrInvalidParams))
+
+	err = client.Direct().
+		Type(AssetStocks).
+		Exchange(ExchangeBinance).
+		Symbol("AAPL").
+		Interval(Interval1d).
+		Indicator(IndicatorRSI).
+		validate()
+	assert.True(t, errors.Is(err, ErrInvalidParams))
+
+	err = client.Direct().
+		Exchange(ExchangeBinance).
+		Symbol("BTC/USDT/EUR").
+		Interval(Interval1h).
+		Indicator(IndicatorRSI).
+		validate()
+	assert.True(t, errors.Is(err, ErrInvalidSymbol))
+}
+
+func TestConstructBuilderType(t *testing.T) {
+	client := NewClient("test_secret")
+
+	construct, err := client.Construct("", "eur/usd", Interval1h).
+		Type(AssetForex).
+		AddIndicator(IndicatorRSI, nil).
+		ToMap()
+
+	require.NoError(t, err)
+	assert.Equal(t, "forex", construct["type"])
+	assert.Equal(t, "EUR/USD", construct["symbol"])
+	assert.NotContains(t, construct, "exchange")
+}
+
+func TestCandlesBuilderType(t *testing.T) {
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"timestamp":1609459200,"open":1,"high":1,"low":1,"close":1,"volume":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL)
+	_, err := client.Candles("", "MSFT", Interval1h).Type(AssetStocks).Get()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stocks"}, query["type"])
+}
@@ -0,0 +1,151 @@
+package backtest
+
+import (
+	"math"
+	"time"
+)
+
+// Side is the side of a fill or trade
+type Side int
+
+const (
+	// Long buys first and profits from rising prices
+	Long Side = iota
+	// Short sells first and profits from falling prices
+	Short
+)
+
+// String returns the string representation of the side
+func (s Side) String() string {
+	if s == Short {
+		return "short"
+	}
+	return "long"
+}
+
+// Fill is an executed order
+type Fill struct {
+	Time time.Time
+	// Side is Long for buys and Short for sells
+	Side     Side
+	Quantity float64
+	// Price includes slippage
+	Price float64
+	Fee   float64
+}
+
+// Trade is a position from opening to going flat
+type Trade struct {
+	Side       Side
+	EntryTime  time.Time
+	EntryPrice float64
+	ExitTime   time.Time
+	// ExitPrice is the average price of the exits
+	ExitPrice float64
+	// Quantity is the total quantity entered
+	Quantity float64
+	// PnL is the realized profit net of fees
+	PnL  float64
+	Fees float64
+}
+
+// Return is the PnL relative to the entered value
+func (t Trade) Return() float64 {
+	cost := t.EntryPrice * t.Quantity
+	if cost == 0 {
+		return 0
+	}
+	return t.PnL / cost
+}
+
+// openTrade accumulates a trade until the position is flat
+type openTrade struct {
+	Trade
+	gross     float64
+	exitValue float64
+	exitQty   float64
+}
+
+// account tracks cash, position and trades
+type account struct {
+	cash     float64
+	position float64
+	avgPrice float64
+	fees     float64
+	current  *openTrade
+	trades   []Trade
+	fills    []Fill
+}
+
+// equity returns the account value at a price
+func (a *account) equity(price float64) float64 {
+	return a.cash + a.position*price
+}
+
+// fill applies an executed order; quantity is positive for buys and
+// negative for sells
+func (a *account) fill(t time.Time, quantity, price, fee float64) {
+	side := Long
+	if quantity < 0 {
+		side = Short
+	}
+	a.fills = append(a.fills, Fill{Time: t, Side: side, Quantity: math.Abs(quantity), Price: price, Fee: fee})
+	a.cash -= quantity*price + fee
+	a.fees += fee
+
+	remaining := math.Abs(quantity)
+	if a.position != 0 && sign(a.position) != sign(quantity) {
+		closed := math.Min(remaining, math.Abs(a.position))
+		share := fee * closed / remaining
+		tr := a.current
+		tr.gross += closed * (price - a.avgPrice) * sign(a.position)
+		tr.exitValue += closed * price
+		tr.exitQty += closed
+		tr.Fees += share
+		a.position += sign(quantity) * closed
+		fee -= share
+		remaining -= closed
+
+		if a.position == 0 {
+			tr.ExitTime = t
+			tr.ExitPrice = tr.exitValue / tr.exitQty
+			tr.PnL = tr.gross - tr.Fees
+			a.trades = append(a.trades, tr.Trade)
+			a.current = nil
+			a.avgPrice = 0
+		}
+	}
+	if remaining == 0 {
+		return
+	}
+
+	if a.position == 0 {
+		a.current = &openTrade{Trade: Trade{Side: side, EntryTime: t}}
+	}
+	held := math.Abs(a.position)
+	a.avgPrice = (a.avgPrice*held + price*remaining) / (held + remaining)
+	a.position += sign(quantity) * remaining
+	a.current.EntryPrice = a.avgPrice
+	a.current.Quantity += remaining
+	a.current.Fees += fee
+}
+
+// openPosition returns the open trade marked to a price
+func (a *account) openPosition(t time.Time, price float64) *Trade {
+	if a.current == nil {
+		return nil
+	}
+	tr := a.current.Trade
+	gross := a.current.gross + math.Abs(a.position)*(price-a.avgPrice)*sign(a.position)
+	tr.ExitTime = t
+	tr.ExitPrice = (a.current.exitValue + math.Abs(a.position)*price) / (a.current.exitQty + math.Abs(a.position))
+	tr.PnL = gross - tr.Fees
+	return &tr
+}
+
+func sign(x float64) float64 {
+	if x < 0 {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,72 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(n int) time.Time {
+	return time.Date(2024, 1, 1, n, 0, 0, 0, time.UTC)
+}
+
+func TestAccountLongTrade(t *testing.T) {
+	a := &account{cash: 1000}
+	a.fill(at(0), 2, 100, 1)
+	a.fill(at(1), 2, 110, 1) // average entry 105
+	assert.InDelta(t, 578, a.cash, 1e-9)
+	assert.Equal(t, 4.0, a.position)
+
+	a.fill(at(2), -1, 120, 0.5)
+	assert.Empty(t, a.trades)
+	a.fill(at(3), -3, 100, 1.5)
+
+	require.Len(t, a.trades, 1)
+	tr := a.trades[0]
+	assert.Equal(t, Long, tr.Side)
+	assert.Equal(t, at(0), tr.EntryTime)
+	assert.Equal(t, at(3), tr.ExitTime)
+	assert.InDelta(t, 105, tr.EntryPrice, 1e-9)
+	assert.InDelta(t, 105, tr.ExitPrice, 1e-9) // (120 + 3*100) / 4
+	assert.Equal(t, 4.0, tr.Quantity)
+	assert.InDelta(t, 4, tr.Fees, 1e-9)
+	// 1*(120-105) + 3*(100-105) - 4
+	assert.InDelta(t, -4, tr.PnL, 1e-9)
+	assert.InDelta(t, -4.0/420, tr.Return(), 1e-9)
+
+	assert.Equal(t, 0.0, a.position)
+	assert.InDelta(t, 996, a.cash, 1e-9)
+	assert.InDelta(t, 4, a.fees, 1e-9)
+	assert.Len(t, a.fills, 4)
+	assert.Nil(t, a.openPosition(at(4), 100))
+}
+
+func TestAccountFlipAndShort(t *testing.T) {
+	a := &account{cash: 1000}
+	a.fill(at(0), 1, 100, 0)
+	a.fill(at(1), -3, 90, 3) // closes the long, opens a short of 2
+
+	require.Len(t, a.trades, 1)
+	assert.InDelta(t, -11, a.trades[0].PnL, 1e-9) // -10 gross, 1 fee
+	assert.Equal(t, -2.0, a.position)
+
+	open := a.openPosition(at(2), 80)
+	require.NotNil(t, open)
+	assert.Equal(t, Short, open.Side)
+	assert.Equal(t, 90.0, open.EntryPrice)
+	assert.Equal(t, 2.0, open.Quantity)
+	assert.InDelta(t, 18, open.PnL, 1e-9) // 2*(90-80) - 2 fee
+	assert.InDelta(t, 1000-100+270-3+2*-80, a.equity(80), 1e-9)
+
+	a.fill(at(3), 2, 80, 0)
+	require.Len(t, a.trades, 2)
+	assert.Equal(t, Short, a.trades[1].Side)
+	assert.InDelta(t, 18, a.trades[1].PnL, 1e-9)
+}
+
+func TestSideString(t *testing.T) {
+	assert.Equal(t, "long", Long.String())
+	assert.Equal(t, "short", Short.String())
+}
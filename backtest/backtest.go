@@ -0,0 +1,235 @@
+// Package backtest replays a candle series bar by bar through a strategy
+// and reports its performance:
+//
+//	bt := backtest.New(backtest.NewManualCalculator(client)).
+//		InitialCash(10000).
+//		Fees(0.001).
+//		Slippage(0.0005)
+//
+//	result, err := bt.Run(ctx, candles, backtest.StrategyFunc(func(bar *backtest.Bar) error {
+//		rsi, err := bar.Indicator(taapi.RSI().Period(14))
+//		if err != nil {
+//			return err
+//		}
+//		switch {
+//		case rsi < 30 && bar.Position() == 0:
+//			bar.BuyValue(bar.Cash())
+//		case rsi > 70 && bar.Position() > 0:
+//			bar.ClosePosition()
+//		}
+//		return nil
+//	}))
+//
+// Strategies only see candles up to the current bar, indicators are
+// computed on windows ending at the current bar, and orders fill at the
+// open of the next bar, so results carry no look-ahead bias.
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// DefaultLookback is the default number of candles indicators are computed on
+const DefaultLookback = 100
+
+// Backtest configures and runs backtests
+type Backtest struct {
+	calc        Calculator
+	initialCash float64
+	feeRate     float64
+	slippage    float64
+	lookback    int
+	warmup      int
+	allowShort  bool
+	interval    taapi.Interval
+}
+
+// New creates a backtest computing indicators with the calculator, usually
+// NewManualCalculator(client). The calculator may be nil for strategies
+// without indicators.
+func New(calc Calculator) *Backtest {
+	return &Backtest{
+		calc:        calc,
+		initialCash: 10000,
+		lookback:    DefaultLookback,
+	}
+}
+
+// InitialCash sets the starting cash; 10000 by default
+func (bt *Backtest) InitialCash(cash float64) *Backtest {
+	bt.initialCash = cash
+	return bt
+}
+
+// Fees sets the fee rate charged on the value of every fill, e.g. 0.001
+// for 0.1%
+func (bt *Backtest) Fees(rate float64) *Backtest {
+	bt.feeRate = rate
+	return bt
+}
+
+// Slippage sets the fraction the fill price moves against every order
+func (bt *Backtest) Slippage(fraction float64) *Backtest {
+	bt.slippage = fraction
+	return bt
+}
+
+// Lookback sets the number of candles, ending at the current bar,
+// indicators are computed on
+func (bt *Backtest) Lookback(candles int) *Backtest {
+	bt.lookback = candles
+	return bt
+}
+
+// Warmup skips the strategy on the first bars, e.g. until indicators have
+// enough history
+func (bt *Backtest) Warmup(bars int) *Backtest {
+	bt.warmup = bars
+	return bt
+}
+
+// AllowShort allows sells beyond the held quantity
+func (bt *Backtest) AllowShort(allow bool) *Backtest {
+	bt.allowShort = allow
+	return bt
+}
+
+// Interval sets the candle interval used to annualize the Sharpe ratio;
+// detected from the candle spacing by default
+func (bt *Backtest) Interval(interval taapi.Interval) *Backtest {
+	bt.interval = interval
+	return bt
+}
+
+// run is the state of one backtest run
+type run struct {
+	*Backtest
+	account *account
+	orders  []order
+}
+
+// Run replays the candles, which must be in ascending time order
+func (bt *Backtest) Run(ctx context.Context, candles []*taapi.Candle, strategy Strategy) (*Result, error) {
+	if err := bt.validate(candles, strategy); err != nil {
+		return nil, err
+	}
+
+	r := &run{Backtest: bt, account: &account{cash: bt.initialCash}}
+	equity := make([]EquityPoint, 0, len(candles))
+	for i, candle := range candles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Orders of the previous bar fill at this open
+		for _, o := range r.orders {
+			r.execute(candle, o)
+		}
+		r.orders = nil
+
+		if i >= bt.warmup {
+			bar := &Bar{Index: i, Candle: candle, ctx: ctx, run: r, history: candles[: i+1 : i+1]}
+			if err := strategy.OnBar(bar); err != nil {
+				return nil, fmt.Errorf("backtest: bar %d (%s): %w", i, candle.Time().Format(time.RFC3339), err)
+			}
+		}
+		equity = append(equity, EquityPoint{Time: candle.Time(), Equity: r.account.equity(candle.Close)})
+	}
+
+	last := candles[len(candles)-1]
+	result := &Result{
+		InitialCash: bt.initialCash,
+		FinalEquity: r.account.equity(last.Close),
+		Fees:        r.account.fees,
+		Trades:      r.account.trades,
+		Open:        r.account.openPosition(last.Time(), last.Close),
+		Fills:       r.account.fills,
+		Equity:      equity,
+	}
+	result.compute(bt.periodsPerYear(candles))
+	return result, nil
+}
+
+// validate checks the configuration and the candles
+func (bt *Backtest) validate(candles []*taapi.Candle, strategy Strategy) error {
+	switch {
+	case strategy == nil:
+		return taapi.InvalidArgumentError("backtest: a strategy is required")
+	case len(candles) == 0:
+		return taapi.InvalidArgumentError("backtest: candles are required")
+	case bt.initialCash <= 0:
+		return taapi.InvalidArgumentError("backtest: initial cash must be positive")
+	case bt.feeRate < 0 || bt.slippage < 0:
+		return taapi.InvalidArgumentError("backtest: fees and slippage must not be negative")
+	case bt.lookback < 1:
+		return taapi.InvalidArgumentError("backtest: lookback must be positive")
+	}
+	for i := 1; i < len(candles); i++ {
+		if candles[i].Timestamp <= candles[i-1].Timestamp {
+			return taapi.InvalidArgumentError(fmt.Sprintf("backtest: candle %d is not after the previous candle", i))
+		}
+	}
+	return nil
+}
+
+// execute fills an order at the open of the candle
+func (r *run) execute(candle *taapi.Candle, o order) {
+	a := r.account
+	quantity := o.quantity
+	switch {
+	case o.close:
+		quantity = -a.position
+	case o.value != 0:
+		quantity = o.value / (candle.Open * (1 + r.slippage))
+	}
+	if quantity == 0 || math.IsNaN(quantity) || math.IsInf(quantity, 0) {
+		return
+	}
+
+	price := candle.Open * (1 + r.slippage)
+	if quantity < 0 {
+		price = candle.Open * (1 - r.slippage)
+	}
+
+	if quantity > 0 {
+		// Buys are capped at the cash, fees included
+		if affordable := a.cash / (price * (1 + r.feeRate)); quantity > affordable {
+			quantity = math.Max(affordable, 0)
+		}
+	} else if !r.allowShort && -quantity > a.position {
+		quantity = -math.Max(a.position, 0)
+	}
+	if quantity == 0 {
+		return
+	}
+
+	a.fill(candle.Time(), quantity, price, math.Abs(quantity)*price*r.feeRate)
+}
+
+// periodsPerYear returns the number of bars in a year
+func (bt *Backtest) periodsPerYear(candles []*taapi.Candle) float64 {
+	const year = 365 * 24 * time.Hour
+	if bt.interval == taapi.Interval1M {
+		return 12
+	}
+	if d := bt.interval.Duration(); d > 0 {
+		return float64(year) / float64(d)
+	}
+	if len(candles) < 2 {
+		return 0
+	}
+
+	// Median spacing, robust to gaps
+	spacing := make([]int64, len(candles)-1)
+	for i := 1; i < len(candles); i++ {
+		spacing[i-1] = candles[i].Time().Unix() - candles[i-1].Time().Unix()
+	}
+	sort.Slice(spacing, func(i, j int) bool { return spacing[i] < spacing[j] })
+	return float64(year/time.Second) / float64(spacing[len(spacing)/2])
+}
@@ -0,0 +1,251 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// hourly creates hourly candles opening at the previous close
+func hourly(closes ...float64) []*taapi.Candle {
+	candles := make([]*taapi.Candle, len(closes))
+	open := closes[0]
+	for i, c := range closes {
+		candles[i] = taapi.NewCandle(at(i), open, max(open, c), min(open, c), c, 100)
+		open = c
+	}
+	return candles
+}
+
+// sma computes simple moving averages locally
+var sma = CalculatorFunc(func(_ context.Context, p taapi.ParamSet, candles []*taapi.Candle) (map[string]float64, error) {
+	period, _ := p.Params()["period"].(int)
+	if len(candles) < period {
+		return nil, errors.New("not enough candles")
+	}
+	var sum float64
+	for _, c := range candles[len(candles)-period:] {
+		sum += c.Close
+	}
+	return map[string]float64{"value": sum / float64(period)}, nil
+})
+
+func TestRunFillsAtNextOpen(t *testing.T) {
+	candles := hourly(100, 110, 120, 90)
+	strategy := StrategyFunc(func(bar *Bar) error {
+		switch bar.Index {
+		case 0:
+			bar.Buy(10)
+		case 1:
+			bar.ClosePosition()
+		}
+		return nil
+	})
+
+	result, err := New(nil).InitialCash(10000).Fees(0.001).Slippage(0.01).Run(context.Background(), candles, strategy)
+	require.NoError(t, err)
+
+	// Bought at the open of bar 1 (100), sold at the open of bar 2 (110)
+	require.Len(t, result.Fills, 2)
+	buy, sell := result.Fills[0], result.Fills[1]
+	assert.Equal(t, Fill{Time: at(1), Side: Long, Quantity: 10, Price: 101, Fee: 1.01}, buy)
+	assert.Equal(t, at(2), sell.Time)
+	assert.InDelta(t, 108.9, sell.Price, 1e-9)
+	assert.InDelta(t, 1.089, sell.Fee, 1e-9)
+
+	require.Len(t, result.Trades, 1)
+	pnl := 10*(108.9-101) - 1.01 - 1.089
+	assert.InDelta(t, pnl, result.Trades[0].PnL, 1e-9)
+	assert.InDelta(t, 10000+pnl, result.FinalEquity, 1e-9)
+	assert.InDelta(t, pnl, result.NetProfit, 1e-9)
+	assert.InDelta(t, pnl/10000, result.Return, 1e-9)
+	assert.InDelta(t, 1.01+1.089, result.Fees, 1e-9)
+	assert.Nil(t, result.Open)
+	assert.Equal(t, 1.0, result.WinRate())
+	require.Len(t, result.Equity, 4)
+	assert.InDelta(t, 10000, result.Equity[0].Equity, 1e-9)
+	assert.InDelta(t, 10000-1010-1.01+1100, result.Equity[1].Equity, 1e-9)
+}
+
+func TestRunHasNoLookAhead(t *testing.T) {
+	candles := hourly(1, 2, 3, 4, 5, 6, 7, 8)
+	calc := CalculatorFunc(func(ctx context.Context, p taapi.ParamSet, window []*taapi.Candle) (map[string]float64, error) {
+		return sma(ctx, p, window)
+	})
+
+	var seen []int
+	strategy := StrategyFunc(func(bar *Bar) error {
+		seen = append(seen, bar.Index)
+		history := bar.History()
+		require.Len(t, history, bar.Index+1)
+		assert.Same(t, bar.Candle, history[len(history)-1])
+		assert.Equal(t, len(history), cap(history), "history cannot be extended into the future")
+
+		v, err := bar.Indicator(taapi.SMA().Period(3))
+		require.NoError(t, err)
+		assert.Equal(t, bar.Candle.Close-1, v, "computed on candles up to the current one")
+		return nil
+	})
+
+	_, err := New(calc).Lookback(4).Warmup(2).Run(context.Background(), candles, strategy)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 4, 5, 6, 7}, seen)
+}
+
+func TestRunLookbackWindow(t *testing.T) {
+	var sizes []int
+	calc := CalculatorFunc(func(_ context.Context, _ taapi.ParamSet, window []*taapi.Candle) (map[string]float64, error) {
+		sizes = append(sizes, len(window))
+		return map[string]float64{"value": 0}, nil
+	})
+	strategy := StrategyFunc(func(bar *Bar) error {
+		_, err := bar.Indicator(taapi.RSI())
+		return err
+	})
+
+	_, err := New(calc).Lookback(3).Run(context.Background(), hourly(1, 2, 3, 4, 5), strategy)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 3, 3}, sizes)
+}
+
+func TestRunCrossoverStrategy(t *testing.T) {
+	candles := hourly(10, 10, 10, 12, 14, 16, 15, 13, 8, 8, 9)
+	strategy := StrategyFunc(func(bar *Bar) error {
+		fast, err := bar.Indicator(taapi.SMA().Period(2))
+		if err != nil {
+			return err
+		}
+		slow, err := bar.Indicator(taapi.SMA().Period(3))
+		if err != nil {
+			return err
+		}
+		switch {
+		case fast > slow && bar.Position() == 0:
+			bar.BuyValue(bar.Cash())
+		case fast < slow && bar.Position() > 0:
+			bar.ClosePosition()
+		}
+		return nil
+	})
+
+	calc := Cached(sma)
+	result, err := New(calc).InitialCash(1000).Warmup(2).Run(context.Background(), candles, strategy)
+	require.NoError(t, err)
+
+	// Enters at the open of bar 4 (12), exits at the open of bar 8 (13)
+	require.Len(t, result.Trades, 1)
+	tr := result.Trades[0]
+	assert.Equal(t, at(4), tr.EntryTime)
+	assert.Equal(t, 12.0, tr.EntryPrice)
+	assert.Equal(t, at(8), tr.ExitTime)
+	assert.Equal(t, 13.0, tr.ExitPrice)
+	assert.InDelta(t, 1000.0/12, tr.PnL, 1e-9)
+	assert.Greater(t, result.Sharpe, 0.0)
+	assert.Greater(t, result.MaxDrawdown, 0.0)
+
+	_, misses := calc.Stats()
+	assert.Equal(t, 18, misses)
+	_, err = New(calc).InitialCash(1000).Warmup(2).Run(context.Background(), candles, strategy)
+	require.NoError(t, err)
+	hits, misses := calc.Stats()
+	assert.Equal(t, 18, hits, "a second run reuses every window")
+	assert.Equal(t, 18, misses)
+}
+
+func TestRunOrderLimits(t *testing.T) {
+	candles := hourly(100, 100, 100, 100, 100)
+	strategy := StrategyFunc(func(bar *Bar) error {
+		switch bar.Index {
+		case 0:
+			bar.Buy(50) // only 10 affordable
+		case 1:
+			bar.Sell(25) // only 10 held
+		case 2:
+			bar.Sell(5) // nothing held
+			bar.Buy(0)
+		}
+		return nil
+	})
+
+	result, err := New(nil).InitialCash(1000).Run(context.Background(), candles, strategy)
+	require.NoError(t, err)
+	require.Len(t, result.Fills, 2)
+	assert.Equal(t, 10.0, result.Fills[0].Quantity)
+	assert.Equal(t, 10.0, result.Fills[1].Quantity)
+
+	// With short selling, the sell opens a short that is still open
+	result, err = New(nil).InitialCash(1000).AllowShort(true).Run(context.Background(), candles, strategy)
+	require.NoError(t, err)
+	require.Len(t, result.Fills, 3)
+	assert.Equal(t, 25.0, result.Fills[1].Quantity)
+	require.NotNil(t, result.Open)
+	assert.Equal(t, Short, result.Open.Side)
+	assert.Equal(t, 20.0, result.Open.Quantity)
+}
+
+func TestRunErrors(t *testing.T) {
+	candles := hourly(1, 2, 3)
+	noop := StrategyFunc(func(*Bar) error { return nil })
+	ctx := context.Background()
+
+	tests := []struct {
+		bt       *Backtest
+		candles  []*taapi.Candle
+		strategy Strategy
+	}{
+		{New(nil), candles, nil},
+		{New(nil), nil, noop},
+		{New(nil).InitialCash(0), candles, noop},
+		{New(nil).Fees(-0.1), candles, noop},
+		{New(nil).Lookback(0), candles, noop},
+		{New(nil), []*taapi.Candle{candles[1], candles[0]}, noop},
+	}
+	for _, tt := range tests {
+		_, err := tt.bt.Run(ctx, tt.candles, tt.strategy)
+		assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+	}
+
+	failing := errors.New("boom")
+	_, err := New(nil).Run(ctx, candles, StrategyFunc(func(bar *Bar) error {
+		if bar.Index == 1 {
+			return failing
+		}
+		return nil
+	}))
+	assert.ErrorIs(t, err, failing)
+	assert.ErrorContains(t, err, "backtest: bar 1 (2024-01-01T01:00:00Z)")
+
+	_, err = New(nil).Run(ctx, candles, StrategyFunc(func(bar *Bar) error {
+		_, err := bar.Indicator(taapi.RSI())
+		return err
+	}))
+	assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+
+	_, err = New(sma).Run(ctx, candles, StrategyFunc(func(bar *Bar) error {
+		_, err := bar.Output(taapi.SMA().Period(1), "upper")
+		return err
+	}))
+	assert.ErrorContains(t, err, "sma has no output upper")
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = New(nil).Run(canceled, candles, noop)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPeriodsPerYear(t *testing.T) {
+	candles := hourly(1, 2, 3, 4)
+	assert.Equal(t, 365*24.0, New(nil).periodsPerYear(candles))
+	assert.Equal(t, 365.0, New(nil).Interval(taapi.Interval1d).periodsPerYear(candles))
+	assert.Equal(t, 12.0, New(nil).Interval(taapi.Interval1M).periodsPerYear(candles))
+
+	// A gap does not change the detected spacing
+	gapped := append(hourly(1, 2, 3), taapi.NewCandle(at(10), 1, 1, 1, 1, 1))
+	assert.Equal(t, 365*24.0, New(nil).periodsPerYear(gapped))
+	assert.Equal(t, 0.0, New(nil).periodsPerYear(candles[:1]))
+}
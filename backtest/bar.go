@@ -0,0 +1,131 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// Strategy decides orders bar by bar
+type Strategy interface {
+	// OnBar is called once the candle of the bar has closed. Orders it
+	// places fill at the open of the next bar.
+	OnBar(bar *Bar) error
+}
+
+// StrategyFunc adapts a function to Strategy
+type StrategyFunc func(bar *Bar) error
+
+// OnBar calls f
+func (f StrategyFunc) OnBar(bar *Bar) error {
+	return f(bar)
+}
+
+// order is a market order waiting for the next open
+type order struct {
+	// quantity is positive for buys and negative for sells; value buys or
+	// sells the quantity worth value at the fill price instead
+	quantity float64
+	value    float64
+	close    bool
+}
+
+// Bar is the view of the market and the account a strategy gets. It only
+// exposes candles up to and including the current one.
+type Bar struct {
+	// Index is the position of the candle in the backtested series
+	Index int
+	// Candle is the current, closed candle
+	Candle *taapi.Candle
+
+	ctx     context.Context
+	run     *run
+	history []*taapi.Candle
+}
+
+// Context returns the context of the run
+func (b *Bar) Context() context.Context {
+	return b.ctx
+}
+
+// Time returns the open time of the current candle
+func (b *Bar) Time() time.Time {
+	return b.Candle.Time()
+}
+
+// History returns the candles up to and including the current one, oldest
+// first
+func (b *Bar) History() []*taapi.Candle {
+	return b.history
+}
+
+// Outputs computes every output of an indicator on the lookback window
+// ending at the current candle
+func (b *Bar) Outputs(p taapi.ParamSet) (map[string]float64, error) {
+	if b.run.calc == nil {
+		return nil, taapi.InvalidArgumentError("backtest: no calculator configured")
+	}
+	start := len(b.history) - b.run.lookback
+	if start < 0 {
+		start = 0
+	}
+	return b.run.calc.Calculate(b.ctx, p, b.history[start:])
+}
+
+// Output computes an output of an indicator
+func (b *Bar) Output(p taapi.ParamSet, output string) (float64, error) {
+	outputs, err := b.Outputs(p)
+	if err != nil {
+		return 0, err
+	}
+	v, ok := outputs[output]
+	if !ok {
+		return 0, fmt.Errorf("backtest: %s has no output %s", p.Indicator(), output)
+	}
+	return v, nil
+}
+
+// Indicator computes the value output of an indicator
+func (b *Bar) Indicator(p taapi.ParamSet) (float64, error) {
+	return b.Output(p, "value")
+}
+
+// Position returns the held quantity; negative when short
+func (b *Bar) Position() float64 {
+	return b.run.account.position
+}
+
+// Cash returns the available cash
+func (b *Bar) Cash() float64 {
+	return b.run.account.cash
+}
+
+// Equity returns the cash plus the position valued at the current close
+func (b *Bar) Equity() float64 {
+	return b.run.account.equity(b.Candle.Close)
+}
+
+// Buy places a market order for a quantity. Buys are reduced to what the
+// cash covers.
+func (b *Bar) Buy(quantity float64) {
+	b.run.orders = append(b.run.orders, order{quantity: quantity})
+}
+
+// Sell places a market sell order for a quantity. Without short selling,
+// sells are reduced to the held quantity.
+func (b *Bar) Sell(quantity float64) {
+	b.run.orders = append(b.run.orders, order{quantity: -quantity})
+}
+
+// BuyValue buys the quantity worth value at the fill price, e.g.
+// BuyValue(bar.Cash()) to go all in
+func (b *Bar) BuyValue(value float64) {
+	b.run.orders = append(b.run.orders, order{value: value})
+}
+
+// ClosePosition places an order flattening the position at the next open
+func (b *Bar) ClosePosition() {
+	b.run.orders = append(b.run.orders, order{close: true})
+}
@@ -0,0 +1,153 @@
+package backtest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// Calculator computes the outputs of an indicator on a candle window whose
+// last candle is the current bar
+type Calculator interface {
+	Calculate(ctx context.Context, p taapi.ParamSet, candles []*taapi.Candle) (map[string]float64, error)
+}
+
+// CalculatorFunc adapts a function to Calculator, e.g. to compute
+// indicators locally
+type CalculatorFunc func(ctx context.Context, p taapi.ParamSet, candles []*taapi.Candle) (map[string]float64, error)
+
+// Calculate calls f
+func (f CalculatorFunc) Calculate(ctx context.Context, p taapi.ParamSet, candles []*taapi.Candle) (map[string]float64, error) {
+	return f(ctx, p, candles)
+}
+
+// manualCalculator computes indicators with manual requests
+type manualCalculator struct {
+	executor taapi.ManualExecutor
+}
+
+// NewManualCalculator creates a cached calculator posting the windows to
+// taapi.io as manual requests, usually through a *taapi.Client
+func NewManualCalculator(executor taapi.ManualExecutor) *CachedCalculator {
+	return Cached(&manualCalculator{executor: executor})
+}
+
+func (c *manualCalculator) Calculate(ctx context.Context, p taapi.ParamSet, candles []*taapi.Candle) (map[string]float64, error) {
+	resp, err := c.executor.ExecuteManual(ctx, taapi.ManualRequest{
+		Indicator: p.Indicator(),
+		Candles:   candles,
+		Params:    p.Params(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make(map[string]float64)
+	for k, v := range resp.Data {
+		if f, ok := v.(float64); ok {
+			outputs[k] = f
+		}
+	}
+	return outputs, nil
+}
+
+// CachedCalculator remembers the outputs of every indicator and candle
+// window it computed, so repeated windows, e.g. across runs of a parameter
+// sweep, are computed once
+type CachedCalculator struct {
+	calc Calculator
+
+	mu     sync.Mutex
+	cache  map[[sha256.Size]byte]map[string]float64
+	hits   int
+	misses int
+}
+
+// Cached wraps a calculator with a cache
+func Cached(calc Calculator) *CachedCalculator {
+	return &CachedCalculator{
+		calc:  calc,
+		cache: make(map[[sha256.Size]byte]map[string]float64),
+	}
+}
+
+// Calculate returns the cached outputs or computes them. Errors are not
+// cached.
+func (c *CachedCalculator) Calculate(ctx context.Context, p taapi.ParamSet, candles []*taapi.Candle) (map[string]float64, error) {
+	key, err := cacheKey(p, candles)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	outputs, ok := c.cache[key]
+	if ok {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	c.mu.Unlock()
+	if ok {
+		return copyOutputs(outputs), nil
+	}
+
+	outputs, err = c.calc.Calculate(ctx, p, candles)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.cache[key] = copyOutputs(outputs)
+	c.mu.Unlock()
+	return outputs, nil
+}
+
+// Stats returns the number of cache hits and misses
+func (c *CachedCalculator) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// Reset empties the cache
+func (c *CachedCalculator) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache = make(map[[sha256.Size]byte]map[string]float64)
+	c.hits, c.misses = 0, 0
+}
+
+// cacheKey hashes the indicator, its parameters and the candle window
+func cacheKey(p taapi.ParamSet, candles []*taapi.Candle) ([sha256.Size]byte, error) {
+	params, err := json.Marshal(p.Params())
+	if err != nil {
+		return [sha256.Size]byte{}, taapi.InvalidArgumentError(fmt.Sprintf("backtest: invalid %s parameters: %v", p.Indicator(), err))
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00", p.Indicator(), params)
+	var buf [8]byte
+	for _, c := range candles {
+		for _, v := range []float64{float64(c.Timestamp), c.Open, c.High, c.Low, c.Close, c.Volume} {
+			binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+			h.Write(buf[:])
+		}
+	}
+
+	var key [sha256.Size]byte
+	copy(key[:], h.Sum(nil))
+	return key, nil
+}
+
+func copyOutputs(outputs map[string]float64) map[string]float64 {
+	c := make(map[string]float64, len(outputs))
+	for k, v := range outputs {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,81 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigusigalpa/taapi-go"
+	"github.com/tigusigalpa/taapi-go/taapimock"
+)
+
+func TestManualCalculator(t *testing.T) {
+	mock := taapimock.New()
+	mock.OnManual(taapi.IndicatorMACD).
+		ReturnData(map[string]interface{}{"valueMACD": 1.5, "valueMACDSignal": 1.0, "note": "ignored"}).
+		Once()
+
+	calc := NewManualCalculator(mock)
+	candles := hourly(10, 11, 12)
+	for i := 0; i < 2; i++ {
+		outputs, err := calc.Calculate(context.Background(), taapi.MACD().FastPeriod(3), candles)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{"valueMACD": 1.5, "valueMACDSignal": 1.0}, outputs)
+	}
+	mock.AssertExpectations(t)
+
+	calls := mock.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, candles, calls[0].Manual.Candles)
+	assert.Equal(t, 3, calls[0].Manual.Params["optInFastPeriod"])
+
+	hits, misses := calc.Stats()
+	assert.Equal(t, 1, hits)
+	assert.Equal(t, 1, misses)
+}
+
+func TestCachedCalculator(t *testing.T) {
+	calls := 0
+	failing := errors.New("boom")
+	calc := Cached(CalculatorFunc(func(_ context.Context, p taapi.ParamSet, candles []*taapi.Candle) (map[string]float64, error) {
+		calls++
+		if candles[len(candles)-1].Close < 0 {
+			return nil, failing
+		}
+		return map[string]float64{"value": candles[len(candles)-1].Close}, nil
+	}))
+	ctx := context.Background()
+
+	_, err := calc.Calculate(ctx, taapi.SMA().Period(2), hourly(1, 2))
+	require.NoError(t, err)
+	_, err = calc.Calculate(ctx, taapi.SMA().Period(2), hourly(1, 2))
+	require.NoError(t, err)
+	_, err = calc.Calculate(ctx, taapi.SMA().Period(3), hourly(1, 2))
+	require.NoError(t, err)
+	_, err = calc.Calculate(ctx, taapi.SMA().Period(2), hourly(1, 3))
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls, "parameters and candles are part of the key")
+
+	// Cached outputs cannot be modified through the returned map
+	outputs, err := calc.Calculate(ctx, taapi.SMA().Period(2), hourly(1, 2))
+	require.NoError(t, err)
+	outputs["value"] = 99
+	outputs, err = calc.Calculate(ctx, taapi.SMA().Period(2), hourly(1, 2))
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, outputs["value"])
+
+	// Errors are not cached
+	for i := 0; i < 2; i++ {
+		_, err = calc.Calculate(ctx, taapi.SMA().Period(2), hourly(1, -1))
+		assert.ErrorIs(t, err, failing)
+	}
+	assert.Equal(t, 5, calls)
+
+	calc.Reset()
+	hits, misses := calc.Stats()
+	assert.Zero(t, hits)
+	assert.Zero(t, misses)
+}
@@ -0,0 +1,106 @@
+package backtest
+
+import (
+	"math"
+	"time"
+)
+
+// EquityPoint is the account value at the close of a bar
+type EquityPoint struct {
+	Time   time.Time
+	Equity float64
+}
+
+// Result is the performance of a backtest
+type Result struct {
+	InitialCash float64
+	// FinalEquity values the open position at the last close
+	FinalEquity float64
+	NetProfit   float64
+	// Return is the net profit relative to the initial cash
+	Return float64
+	// MaxDrawdown is the largest fall of the equity from a previous peak, as
+	// a fraction of the peak
+	MaxDrawdown float64
+	// Sharpe is the annualized Sharpe ratio of the bar returns, with a zero
+	// risk-free rate
+	Sharpe float64
+	Fees   float64
+	// Trades are the closed trades
+	Trades []Trade
+	// Open is the position still open at the end, marked to the last close
+	Open   *Trade
+	Fills  []Fill
+	Equity []EquityPoint
+}
+
+// WinRate returns the fraction of closed trades with a positive PnL
+func (r *Result) WinRate() float64 {
+	if len(r.Trades) == 0 {
+		return 0
+	}
+	wins := 0
+	for _, t := range r.Trades {
+		if t.PnL > 0 {
+			wins++
+		}
+	}
+	return float64(wins) / float64(len(r.Trades))
+}
+
+// compute derives the summary statistics from the equity curve
+func (r *Result) compute(periodsPerYear float64) {
+	r.NetProfit = r.FinalEquity - r.InitialCash
+	r.Return = r.NetProfit / r.InitialCash
+	r.MaxDrawdown = maxDrawdown(r.Equity)
+	r.Sharpe = sharpe(r.Equity, r.InitialCash, periodsPerYear)
+}
+
+// maxDrawdown returns the largest peak-to-trough fall of the equity
+func maxDrawdown(equity []EquityPoint) float64 {
+	var peak, worst float64
+	for _, p := range equity {
+		if p.Equity > peak {
+			peak = p.Equity
+		}
+		if peak > 0 {
+			if dd := (peak - p.Equity) / peak; dd > worst {
+				worst = dd
+			}
+		}
+	}
+	return worst
+}
+
+// sharpe returns the annualized mean over the standard deviation of the bar
+// returns, starting from the initial cash
+func sharpe(equity []EquityPoint, initial, periodsPerYear float64) float64 {
+	if len(equity) < 2 || periodsPerYear <= 0 {
+		return 0
+	}
+
+	returns := make([]float64, len(equity))
+	prev := initial
+	for i, p := range equity {
+		if prev != 0 {
+			returns[i] = p.Equity/prev - 1
+		}
+		prev = p.Equity
+	}
+
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	std := math.Sqrt(variance / float64(len(returns)-1))
+	if std == 0 {
+		return 0
+	}
+	return mean / std * math.Sqrt(periodsPerYear)
+}
@@ -0,0 +1,48 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func curve(values ...float64) []EquityPoint {
+	equity := make([]EquityPoint, len(values))
+	for i, v := range values {
+		equity[i] = EquityPoint{Time: at(i), Equity: v}
+	}
+	return equity
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	assert.Equal(t, 0.0, maxDrawdown(nil))
+	assert.Equal(t, 0.0, maxDrawdown(curve(100, 110, 120)))
+	assert.InDelta(t, 0.25, maxDrawdown(curve(100, 120, 90, 110, 100)), 1e-9)
+	assert.InDelta(t, 0.5, maxDrawdown(curve(100, 80, 200, 100, 150)), 1e-9)
+}
+
+func TestSharpe(t *testing.T) {
+	assert.Equal(t, 0.0, sharpe(curve(100), 100, 365))
+	assert.Equal(t, 0.0, sharpe(curve(100, 100, 100), 100, 365), "no volatility")
+	assert.Equal(t, 0.0, sharpe(curve(101, 102), 100, 0))
+
+	// Returns of 10% and -5%
+	equity := curve(110, 104.5)
+	mean := (0.1 - 0.05) / 2
+	std := math.Sqrt((math.Pow(0.1-mean, 2) + math.Pow(-0.05-mean, 2)) / 1)
+	assert.InDelta(t, mean/std*math.Sqrt(12), sharpe(equity, 100, 12), 1e-9)
+}
+
+func TestResultStats(t *testing.T) {
+	r := &Result{InitialCash: 1000, FinalEquity: 1100, Equity: curve(1000, 1200, 1100)}
+	r.compute(365)
+	assert.Equal(t, 100.0, r.NetProfit)
+	assert.InDelta(t, 0.1, r.Return, 1e-9)
+	assert.InDelta(t, 100.0/1200, r.MaxDrawdown, 1e-9)
+	assert.Greater(t, r.Sharpe, 0.0)
+
+	assert.Equal(t, 0.0, r.WinRate())
+	r.Trades = []Trade{{PnL: 10}, {PnL: -5}, {PnL: 0}, {PnL: 3}}
+	assert.Equal(t, 0.5, r.WinRate())
+}
@@ -1,15 +1,26 @@
 package taapi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // DirectBuilder builds direct GET requests
 type DirectBuilder struct {
 	client    *Client
+	asset     AssetType
 	exchange  string
 	symbol    string
 	interval  string
 	indicator string
 	params    map[string]interface{}
+	err       error
+}
+
+// Type sets the asset class; stocks and forex requests take no exchange
+func (b *DirectBuilder) Type(asset AssetType) *DirectBuilder {
+	b.asset = asset
+	return b
 }
 
 // Exchange sets the exchange
@@ -56,6 +67,20 @@ func (b *DirectBuilder) WithParam(key string, value interface{}) *DirectBuilder
 	return b
 }
 
+// Use sets the indicator and merges the parameters of a parameter set. A
+// parameter set for a different indicator than the one already set is
+// reported by Get.
+func (b *DirectBuilder) Use(p ParamSet) *DirectBuilder {
+	if err := checkParamSet(b.indicator, p); err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
+	}
+	b.indicator = p.Indicator().String()
+	return b.WithParams(p.Params())
+}
+
 // Backtrack sets the backtrack parameter
 func (b *DirectBuilder) Backtrack(backtrack int) *DirectBuilder {
 	return b.WithParam("backtrack", backtrack)
@@ -68,48 +93,96 @@ func (b *DirectBuilder) Backtracks(backtracks int) *DirectBuilder {
 
 // Get executes the request
 func (b *DirectBuilder) Get() (*IndicatorResponse, error) {
-	if err := b.validate(); err != nil {
+	return b.GetContext(context.Background())
+}
+
+// GetContext executes the request with the given context
+func (b *DirectBuilder) GetContext(ctx context.Context) (*IndicatorResponse, error) {
+	params, err := b.build()
+	if err != nil {
 		return nil, err
 	}
 
-	params := make(map[string]interface{})
-	params["exchange"] = b.exchange
-	params["symbol"] = b.symbol
-	params["interval"] = b.interval
+	return b.client.doGet(ctx, "/"+b.indicator, params)
+}
 
-	for k, v := range b.params {
-		params[k] = v
+// GetSeries executes a request with backtracks set and returns one response
+// per candle, newest first as taapi.io returns them
+func (b *DirectBuilder) GetSeries() ([]*IndicatorResponse, error) {
+	return b.GetSeriesContext(context.Background())
+}
+
+// GetSeriesContext executes a backtracks request with the given context
+func (b *DirectBuilder) GetSeriesContext(ctx context.Context) ([]*IndicatorResponse, error) {
+	params, err := b.build()
+	if err != nil {
+		return nil, err
 	}
 
-	return b.client.doGet("/"+b.indicator, params)
+	return b.client.doGetSeries(ctx, "/"+b.indicator, params)
 }
 
 func (b *DirectBuilder) validate() error {
-	if b.exchange == "" {
-		return InvalidArgumentError("exchange is required")
+	_, err := b.build()
+	return err
+}
+
+// build validates the request and returns its query parameters
+func (b *DirectBuilder) build() (map[string]interface{}, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.exchange == "" && b.asset.RequiresExchange() {
+		return nil, InvalidArgumentError("exchange is required")
 	}
 	if b.symbol == "" {
-		return InvalidArgumentError("symbol is required")
+		return nil, InvalidArgumentError("symbol is required")
 	}
 	if b.interval == "" {
-		return InvalidArgumentError("interval is required")
+		return nil, InvalidArgumentError("interval is required")
 	}
 	if b.indicator == "" {
-		return InvalidArgumentError("indicator is required")
+		return nil, InvalidArgumentError("indicator is required")
 	}
-	return nil
+	symbol, err := b.client.validateTarget(b.asset, b.exchange, b.symbol, b.interval)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateIndicatorParams(b.indicator, b.params, false); err != nil {
+		return nil, err
+	}
+
+	params := targetParams(b.asset, b.exchange, symbol, b.interval)
+	for k, v := range b.params {
+		params[k] = v
+	}
+	return params, nil
 }
 
 // ConstructBuilder builds a construct for bulk requests
 type ConstructBuilder struct {
+	client     *Client
+	asset      AssetType
 	exchange   string
 	symbol     string
 	interval   string
 	indicators []map[string]interface{}
+	err        error
 }
 
-// AddIndicator adds an indicator to the construct
+// Type sets the asset class; stocks and forex constructs take no exchange
+func (b *ConstructBuilder) Type(asset AssetType) *ConstructBuilder {
+	b.asset = asset
+	return b
+}
+
+// AddIndicator adds an indicator to the construct. Parameters of cataloged
+// indicators are validated; the first error is returned by ToMap.
 func (b *ConstructBuilder) AddIndicator(indicator Indicator, params map[string]interface{}) *ConstructBuilder {
+	if err := validateIndicatorParams(indicator.String(), params, false); err != nil && b.err == nil {
+		b.err = err
+	}
+
 	indicatorData := map[string]interface{}{
 		"indicator": indicator.String(),
 	}
@@ -122,38 +195,84 @@ func (b *ConstructBuilder) AddIndicator(indicator Indicator, params map[string]i
 	return b
 }
 
+// Add adds an indicator with the parameters of a parameter set
+func (b *ConstructBuilder) Add(p ParamSet) *ConstructBuilder {
+	return b.AddIndicator(p.Indicator(), p.Params())
+}
+
 // ToMap converts the construct to a map
 func (b *ConstructBuilder) ToMap() (map[string]interface{}, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
 	if len(b.indicators) == 0 {
 		return nil, InvalidArgumentError("at least one indicator is required")
 	}
+	symbol, err := b.client.validateTarget(b.asset, b.exchange, b.symbol, b.interval)
+	if err != nil {
+		return nil, err
+	}
 
-	return map[string]interface{}{
-		"exchange":   b.exchange,
-		"symbol":     b.symbol,
-		"interval":   b.interval,
-		"indicators": b.indicators,
-	}, nil
+	construct := targetParams(b.asset, b.exchange, symbol, b.interval)
+	construct["indicators"] = b.indicators
+	return construct, nil
 }
 
 // BulkBuilder builds bulk POST requests
 type BulkBuilder struct {
 	client     *Client
 	constructs []map[string]interface{}
+	err        error
 }
 
-// AddConstruct adds a construct to the bulk request
+// AddConstruct adds a construct to the bulk request. An invalid construct is
+// skipped and its error is returned by Execute.
 func (b *BulkBuilder) AddConstruct(construct *ConstructBuilder) *BulkBuilder {
 	constructMap, err := construct.ToMap()
 	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
 		return b
 	}
 	b.constructs = append(b.constructs, constructMap)
 	return b
 }
 
+// Constructs returns copies of the constructs added so far, as sent in the
+// request body, and the error of the first invalid construct
+func (b *BulkBuilder) Constructs() ([]map[string]interface{}, error) {
+	constructs := make([]map[string]interface{}, len(b.constructs))
+	for i, construct := range b.constructs {
+		c := make(map[string]interface{}, len(construct))
+		for k, v := range construct {
+			c[k] = v
+		}
+		if indicators, ok := construct["indicators"].([]map[string]interface{}); ok {
+			copied := make([]map[string]interface{}, len(indicators))
+			for j, indicator := range indicators {
+				copied[j] = make(map[string]interface{}, len(indicator))
+				for k, v := range indicator {
+					copied[j][k] = v
+				}
+			}
+			c["indicators"] = copied
+		}
+		constructs[i] = c
+	}
+	return constructs, b.err
+}
+
 // Execute executes the bulk request
 func (b *BulkBuilder) Execute() (*BulkResponse, error) {
+	return b.ExecuteContext(context.Background())
+}
+
+// ExecuteContext executes the bulk request with the given context
+func (b *BulkBuilder) ExecuteContext(ctx context.Context) (*BulkResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
 	if len(b.constructs) == 0 {
 		return nil, InvalidArgumentError("at least one construct is required")
 	}
@@ -162,25 +281,102 @@ func (b *BulkBuilder) Execute() (*BulkResponse, error) {
 		"construct": b.constructs,
 	}
 
-	result, err := b.client.doPost("/bulk", payload)
+	result, err := b.client.doPost(ctx, "/bulk", payload)
 	if err != nil {
 		return nil, err
 	}
 
 	bulkResp, ok := result.(*BulkResponse)
 	if !ok {
-		return nil, fmt.Errorf("unexpected response type")
+		return nil, DecodeError("unexpected response type", nil)
 	}
 
 	return bulkResp, nil
 }
 
+// CandlesBuilder builds GET requests for OHLCV candle data
+type CandlesBuilder struct {
+	client   *Client
+	asset    AssetType
+	exchange string
+	symbol   string
+	interval string
+	params   map[string]interface{}
+}
+
+// Type sets the asset class; stocks and forex requests take no exchange
+func (b *CandlesBuilder) Type(asset AssetType) *CandlesBuilder {
+	b.asset = asset
+	return b
+}
+
+// Backtracks sets the number of candles to fetch, counting back from the latest
+func (b *CandlesBuilder) Backtracks(backtracks int) *CandlesBuilder {
+	return b.WithParam("backtracks", backtracks)
+}
+
+// Backtrack fetches a single candle the given number of candles back
+func (b *CandlesBuilder) Backtrack(backtrack int) *CandlesBuilder {
+	return b.WithParam("backtrack", backtrack)
+}
+
+// WithParam adds a single parameter
+func (b *CandlesBuilder) WithParam(key string, value interface{}) *CandlesBuilder {
+	b.params[key] = value
+	return b
+}
+
+// Get fetches the candles, ordered from oldest to newest
+func (b *CandlesBuilder) Get() ([]*Candle, error) {
+	return b.GetContext(context.Background())
+}
+
+// GetContext fetches the candles with the given context
+func (b *CandlesBuilder) GetContext(ctx context.Context) ([]*Candle, error) {
+	candles, _, err := b.GetWithMetaContext(ctx)
+	return candles, err
+}
+
+// GetWithMeta fetches the candles along with the response metadata
+func (b *CandlesBuilder) GetWithMeta() ([]*Candle, *ResponseMeta, error) {
+	return b.GetWithMetaContext(context.Background())
+}
+
+// GetWithMetaContext fetches the candles and response metadata with the given
+// context
+func (b *CandlesBuilder) GetWithMetaContext(ctx context.Context) ([]*Candle, *ResponseMeta, error) {
+	if b.exchange == "" && b.asset.RequiresExchange() {
+		return nil, nil, InvalidArgumentError("exchange is required")
+	}
+	if b.symbol == "" {
+		return nil, nil, InvalidArgumentError("symbol is required")
+	}
+	if b.interval == "" {
+		return nil, nil, InvalidArgumentError("interval is required")
+	}
+	if n, ok := b.params["backtracks"].(int); ok && n < 1 {
+		return nil, nil, InvalidArgumentError("backtracks must be positive")
+	}
+	symbol, err := b.client.validateTarget(b.asset, b.exchange, b.symbol, b.interval)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := targetParams(b.asset, b.exchange, symbol, b.interval)
+	for k, v := range b.params {
+		params[k] = v
+	}
+
+	return b.client.doGetCandles(ctx, "/"+IndicatorCANDLE.String(), params)
+}
+
 // ManualBuilder builds manual POST requests with custom candle data
 type ManualBuilder struct {
 	client    *Client
 	indicator string
 	candles   [][]interface{}
 	params    map[string]interface{}
+	err       error
 }
 
 // WithCandles sets the candle data
@@ -198,6 +394,24 @@ func (b *ManualBuilder) WithCandleStructs(candles []*Candle) *ManualBuilder {
 	return b
 }
 
+// WithCandleSeries sets the candle data from a series. The series is
+// validated first; any issue is returned by Execute.
+func (b *ManualBuilder) WithCandleSeries(series *CandleSeries) *ManualBuilder {
+	if series == nil {
+		if b.err == nil {
+			b.err = InvalidArgumentError("candle series is required")
+		}
+		return b
+	}
+	if err := series.Validate(); err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
+	}
+	return b.WithCandleStructs(series.Candles())
+}
+
 // WithParams adds multiple parameters
 func (b *ManualBuilder) WithParams(params map[string]interface{}) *ManualBuilder {
 	for k, v := range params {
@@ -212,11 +426,45 @@ func (b *ManualBuilder) WithParam(key string, value interface{}) *ManualBuilder
 	return b
 }
 
+// Use sets the indicator and merges the parameters of a parameter set. A
+// parameter set for a different indicator than the one already set is
+// reported by Execute.
+func (b *ManualBuilder) Use(p ParamSet) *ManualBuilder {
+	if err := checkParamSet(b.indicator, p); err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
+	}
+	b.indicator = p.Indicator().String()
+	return b.WithParams(p.Params())
+}
+
+// checkParamSet rejects a parameter set for another indicator than the one
+// already set on a builder
+func checkParamSet(indicator string, p ParamSet) error {
+	if indicator != "" && indicator != p.Indicator().String() {
+		return InvalidArgumentError(fmt.Sprintf("parameters for %s cannot be used with indicator %s", p.Indicator(), indicator))
+	}
+	return nil
+}
+
 // Execute executes the manual request
 func (b *ManualBuilder) Execute() (*IndicatorResponse, error) {
+	return b.ExecuteContext(context.Background())
+}
+
+// ExecuteContext executes the manual request with the given context
+func (b *ManualBuilder) ExecuteContext(ctx context.Context) (*IndicatorResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
 	if len(b.candles) == 0 {
 		return nil, InvalidArgumentError("candles are required")
 	}
+	if err := validateIndicatorParams(b.indicator, b.params, true); err != nil {
+		return nil, err
+	}
 
 	payload := map[string]interface{}{
 		"indicator": b.indicator,
@@ -227,14 +475,14 @@ func (b *ManualBuilder) Execute() (*IndicatorResponse, error) {
 		payload[k] = v
 	}
 
-	result, err := b.client.doPost("/manual", payload)
+	result, err := b.client.doPost(ctx, "/manual", payload)
 	if err != nil {
 		return nil, err
 	}
 
 	indicatorResp, ok := result.(*IndicatorResponse)
 	if !ok {
-		return nil, fmt.Errorf("unexpected response type")
+		return nil, DecodeError("unexpected response type", nil)
 	}
 
 	return indicatorResp, nil
@@ -1,6 +1,8 @@
 package taapi
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -119,6 +121,30 @@ func TestBulkBuilderAddConstruct(t *testing.T) {
 	assert.Equal(t, 2, len(bulk.constructs))
 }
 
+func TestBulkBuilderConstructs(t *testing.T) {
+	client := NewClient("test_secret")
+
+	bulk := client.Bulk().AddConstruct(
+		client.Construct(ExchangeBinance, "BTC/USDT", Interval1h).
+			AddIndicator(IndicatorEMA, map[string]interface{}{"period": 50}),
+	)
+
+	constructs, err := bulk.Constructs()
+	require.NoError(t, err)
+	require.Len(t, constructs, 1)
+	assert.Equal(t, "BTC/USDT", constructs[0]["symbol"])
+	indicators := constructs[0]["indicators"].([]map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"indicator": "ema", "period": 50}, indicators[0])
+
+	indicators[0]["period"] = 20
+	constructs, _ = bulk.Constructs()
+	assert.Equal(t, 50, constructs[0]["indicators"].([]map[string]interface{})[0]["period"])
+
+	bulk.AddConstruct(client.Construct(ExchangeBinance, "BTC/USDT", Interval1h))
+	_, err = bulk.Constructs()
+	assert.ErrorIs(t, err, ErrInvalidParams)
+}
+
 func TestManualBuilderWithCandles(t *testing.T) {
 	client := NewClient("test_secret")
 	
@@ -155,3 +181,68 @@ func TestManualBuilderWithParams(t *testing.T) {
 	assert.Equal(t, 50, builder.params["period"])
 	assert.Equal(t, 5, builder.params["backtrack"])
 }
+
+func TestDirectBuilderGetSeries(t *testing.T) {
+	responses := []string{
+		`[{"value":30,"backtrack":0},{"value":40,"backtrack":1},{"value":50,"backtrack":2}]`,
+		`{"value":30}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(responses[0]))
+		responses = responses[1:]
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL)
+	builder := client.Exchange(ExchangeBinance).Symbol("BTC/USDT").Interval(Interval1h).Indicator(IndicatorRSI)
+
+	series, err := builder.Backtracks(3).GetSeries()
+	require.NoError(t, err)
+	require.Len(t, series, 3)
+	value, _ := series[2].GetFloat("value")
+	assert.Equal(t, 50.0, value)
+	assert.NotNil(t, series[0].Meta)
+
+	series, err = builder.GetSeries()
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+}
+
+func TestCandlesBuilderGet(t *testing.T) {
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/candle", r.URL.Path)
+		query = r.URL.Query()
+		w.Write([]byte(`[
+			{"timestamp":1609462800,"open":2,"high":2,"low":2,"close":2,"volume":1},
+			{"timestamp":1609459200,"open":1,"high":1,"low":1,"close":1,"volume":1}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL)
+	builder := client.Candles(ExchangeBinance, "BTC/USDT", Interval1h).Backtracks(2)
+	candles, err := builder.Get()
+
+	require.NoError(t, err)
+	require.Len(t, candles, 2)
+	assert.Equal(t, int64(1609459200), candles[0].Timestamp)
+	assert.Equal(t, []string{"2"}, query["backtracks"])
+	assert.Equal(t, []string{"BTC/USDT"}, query["symbol"])
+
+	candles, meta, err := builder.GetWithMeta()
+	require.NoError(t, err)
+	assert.Len(t, candles, 2)
+	require.NotNil(t, meta)
+	assert.Equal(t, http.StatusOK, meta.StatusCode)
+}
+
+func TestCandlesBuilderValidation(t *testing.T) {
+	client := NewClient("test_secret")
+
+	_, err := client.Candles(ExchangeBinance, "", Interval1h).Get()
+	assert.ErrorIs(t, err, ErrInvalidParams)
+
+	_, err = client.Candles(ExchangeBinance, "BTC/USDT", Interval1h).Backtracks(0).Get()
+	assert.ErrorIs(t, err, ErrInvalidParams)
+}
@@ -0,0 +1,141 @@
+package bulkspec
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// varReference matches a value that is a single variable reference, such as
+// $majors or ${majors}
+var varReference = regexp.MustCompile(`^\$(?:\{(\w+)\}|(\w+))$`)
+
+// Expand resolves variables and symbol lists and returns one construct per
+// symbol, with Symbols cleared
+func (s *Spec) Expand() ([]Construct, error) {
+	var expanded []Construct
+	for i, c := range s.Constructs {
+		symbols, err := s.symbols(c)
+		if err != nil {
+			return nil, fmt.Errorf("bulkspec: construct %d: %w", i+1, err)
+		}
+		for _, symbol := range symbols {
+			construct, err := s.expandConstruct(c, symbol)
+			if err != nil {
+				return nil, fmt.Errorf("bulkspec: construct %d (%s): %w", i+1, symbol, err)
+			}
+			expanded = append(expanded, construct)
+		}
+	}
+	return expanded, nil
+}
+
+// symbols returns the symbols of a construct with list variables spliced in
+func (s *Spec) symbols(c Construct) ([]string, error) {
+	switch {
+	case c.Symbol != "" && len(c.Symbols) > 0:
+		return nil, taapi.InvalidArgumentError("symbol and symbols are mutually exclusive")
+	case c.Symbol != "":
+		return []string{c.Symbol}, nil
+	case len(c.Symbols) == 0:
+		return nil, taapi.InvalidArgumentError("symbol is required")
+	}
+
+	var symbols []string
+	for _, entry := range c.Symbols {
+		if m := varReference.FindStringSubmatch(entry); m != nil {
+			name := m[1] + m[2]
+			if list, ok := s.Vars[name].([]interface{}); ok {
+				for _, v := range list {
+					symbols = append(symbols, formatVar(v))
+				}
+				continue
+			}
+		}
+		symbols = append(symbols, entry)
+	}
+	return symbols, nil
+}
+
+// expandConstruct resolves the variables of a construct for one symbol
+func (s *Spec) expandConstruct(c Construct, symbol string) (Construct, error) {
+	var err error
+	lookup := func(name string) string {
+		if v, ok := s.Vars[name]; ok {
+			if _, isList := v.([]interface{}); isList {
+				err = taapi.InvalidArgumentError(fmt.Sprintf("list variable %s used as a value", name))
+			}
+			return formatVar(v)
+		}
+		err = taapi.InvalidArgumentError(fmt.Sprintf("undefined variable %s", name))
+		return ""
+	}
+	expand := func(value string) string {
+		return os.Expand(value, lookup)
+	}
+
+	out := Construct{
+		Symbol: expand(symbol),
+	}
+	out.Type = expand(c.Type)
+	out.Exchange = expand(c.Exchange)
+	out.Interval = expand(c.Interval)
+
+	// The built-in variables refer to the expanded construct
+	builtins := map[string]string{
+		"symbol":   out.Symbol,
+		"exchange": out.Exchange,
+		"interval": out.Interval,
+		"type":     out.Type,
+	}
+	expandIndicator := func(value string) string {
+		return os.Expand(value, func(name string) string {
+			if v, ok := builtins[name]; ok {
+				return v
+			}
+			return lookup(name)
+		})
+	}
+
+	for _, indicator := range c.Indicators {
+		resolved := Indicator{
+			Indicator: expandIndicator(indicator.Indicator),
+			ID:        expandIndicator(indicator.ID),
+		}
+		if indicator.Params != nil {
+			resolved.Params = make(map[string]interface{}, len(indicator.Params))
+			for k, v := range indicator.Params {
+				if str, ok := v.(string); ok {
+					v = expandIndicator(str)
+					// A parameter that is a single variable keeps the variable's type
+					if m := varReference.FindStringSubmatch(str); m != nil {
+						if raw, ok := s.Vars[m[1]+m[2]]; ok {
+							v = raw
+						}
+					}
+				}
+				resolved.Params[k] = v
+			}
+		}
+		out.Indicators = append(out.Indicators, resolved)
+	}
+
+	return out, err
+}
+
+// formatVar formats a scalar variable value
+func formatVar(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,317 @@
+// Package bulkspec loads bulk requests from declarative YAML or JSON files
+// and serializes them back, so screens can live in configuration:
+//
+//	vars:
+//	  majors: [BTC/USDT, ETH/USDT, SOL/USDT]
+//	constructs:
+//	  - exchange: binance
+//	    symbols: $majors
+//	    interval: 1h
+//	    indicators:
+//	      - indicator: rsi
+//	        id: ${symbol}_rsi
+//	        period: 14
+//
+// A construct with a symbol list expands to one construct per symbol. String
+// fields may reference variables with $name or ${name}; the built-in
+// variables symbol, exchange, interval and type refer to the construct.
+// Indicator entries hold the indicator name, an optional id and the
+// indicator parameters.
+package bulkspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// Spec is a declarative bulk request
+type Spec struct {
+	// Vars are variables referenced by the constructs. Values are strings,
+	// numbers or lists of them; lists can be used as symbol lists.
+	Vars       map[string]interface{} `yaml:"vars,omitempty" json:"vars,omitempty"`
+	Constructs []Construct            `yaml:"constructs" json:"constructs"`
+}
+
+// Construct is a construct of the spec. Either Symbol or Symbols is set.
+type Construct struct {
+	Type       string      `yaml:"type,omitempty" json:"type,omitempty"`
+	Exchange   string      `yaml:"exchange,omitempty" json:"exchange,omitempty"`
+	Symbol     string      `yaml:"symbol,omitempty" json:"symbol,omitempty"`
+	Symbols    SymbolList  `yaml:"symbols,omitempty" json:"symbols,omitempty"`
+	Interval   string      `yaml:"interval" json:"interval"`
+	Indicators []Indicator `yaml:"indicators" json:"indicators"`
+}
+
+// Indicator is an indicator of a construct. It is written as a flat object
+// of indicator, id and the parameters.
+type Indicator struct {
+	Indicator string
+	ID        string
+	Params    map[string]interface{}
+}
+
+// SymbolList is a list of symbols, written as a list or as a single string
+// such as a variable reference
+type SymbolList []string
+
+// Parse parses a YAML or JSON spec
+func Parse(data []byte) (*Spec, error) {
+	var spec Spec
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil && err != io.EOF {
+		return nil, taapi.InvalidArgumentError(fmt.Sprintf("bulkspec: %v", err))
+	}
+	return &spec, nil
+}
+
+// Read parses a spec from a reader
+func Read(r io.Reader) (*Spec, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Load parses a spec file
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// WriteYAML writes the spec as YAML
+func (s *Spec) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(s); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// WriteJSON writes the spec as indented JSON
+func (s *Spec) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// Build validates the spec and returns a bulk builder with its constructs
+func (s *Spec) Build(client *taapi.Client) (*taapi.BulkBuilder, error) {
+	constructs, err := s.Expand()
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(constructs); err != nil {
+		return nil, err
+	}
+
+	bulk := client.Bulk()
+	for i, c := range constructs {
+		builder := client.Construct(taapi.Exchange(c.Exchange), c.Symbol, taapi.Interval(c.Interval)).
+			Type(taapi.AssetType(c.Type))
+		for _, indicator := range c.Indicators {
+			builder.AddIndicator(taapi.Indicator(indicator.Indicator), indicator.params())
+		}
+		if _, err := builder.ToMap(); err != nil {
+			return nil, constructError(i, c, err)
+		}
+		bulk.AddConstruct(builder)
+	}
+	return bulk, nil
+}
+
+// Validate expands the spec and checks every construct against the
+// indicator catalog
+func (s *Spec) Validate() error {
+	constructs, err := s.Expand()
+	if err != nil {
+		return err
+	}
+	return validate(constructs)
+}
+
+// validate checks expanded constructs
+func validate(constructs []Construct) error {
+	if len(constructs) == 0 {
+		return taapi.InvalidArgumentError("bulkspec: at least one construct is required")
+	}
+
+	ids := make(map[string]bool)
+	for i, c := range constructs {
+		if len(c.Indicators) == 0 {
+			return constructError(i, c, taapi.InvalidArgumentError("at least one indicator is required"))
+		}
+		for _, indicator := range c.Indicators {
+			info, ok := taapi.LookupIndicator(taapi.Indicator(indicator.Indicator))
+			if !ok {
+				return constructError(i, c, taapi.InvalidArgumentError(
+					fmt.Sprintf("unknown indicator %q", indicator.Indicator)))
+			}
+			if err := info.ValidateParams(indicator.params()); err != nil {
+				return constructError(i, c, err)
+			}
+			if indicator.ID == "" {
+				continue
+			}
+			if ids[indicator.ID] {
+				return constructError(i, c, taapi.InvalidArgumentError(
+					fmt.Sprintf("duplicate id %q", indicator.ID)))
+			}
+			ids[indicator.ID] = true
+		}
+	}
+	return nil
+}
+
+// constructError adds the position and symbol of a construct to an error
+func constructError(i int, c Construct, err error) error {
+	return fmt.Errorf("bulkspec: construct %d (%s): %w", i+1, c.Symbol, err)
+}
+
+// FromBuilder converts the constructs of a bulk builder to a spec
+func FromBuilder(bulk *taapi.BulkBuilder) (*Spec, error) {
+	constructs, err := bulk.Constructs()
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &Spec{Constructs: make([]Construct, len(constructs))}
+	for i, m := range constructs {
+		c := Construct{
+			Type:     stringValue(m["type"]),
+			Exchange: stringValue(m["exchange"]),
+			Symbol:   stringValue(m["symbol"]),
+			Interval: stringValue(m["interval"]),
+		}
+		indicators, _ := m["indicators"].([]map[string]interface{})
+		for _, fields := range indicators {
+			c.Indicators = append(c.Indicators, indicatorFromMap(fields))
+		}
+		spec.Constructs[i] = c
+	}
+	return spec, nil
+}
+
+// stringValue returns v if it is a string
+func stringValue(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+// params returns the request parameters of the indicator, including its id
+func (i Indicator) params() map[string]interface{} {
+	params := make(map[string]interface{}, len(i.Params)+1)
+	for k, v := range i.Params {
+		params[k] = v
+	}
+	if i.ID != "" {
+		params["id"] = i.ID
+	}
+	return params
+}
+
+// fields returns the flat representation of the indicator
+func (i Indicator) fields() map[string]interface{} {
+	fields := i.params()
+	fields["indicator"] = i.Indicator
+	return fields
+}
+
+// indicatorFromMap splits a flat indicator object into its parts
+func indicatorFromMap(fields map[string]interface{}) Indicator {
+	indicator := Indicator{
+		Indicator: stringValue(fields["indicator"]),
+		ID:        stringValue(fields["id"]),
+	}
+	for k, v := range fields {
+		if k == "indicator" || k == "id" {
+			continue
+		}
+		if indicator.Params == nil {
+			indicator.Params = make(map[string]interface{})
+		}
+		indicator.Params[k] = v
+	}
+	return indicator
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (i *Indicator) UnmarshalYAML(node *yaml.Node) error {
+	var fields map[string]interface{}
+	if err := node.Decode(&fields); err != nil {
+		return err
+	}
+	if stringValue(fields["indicator"]) == "" {
+		return fmt.Errorf("line %d: indicator name is required", node.Line)
+	}
+	*i = indicatorFromMap(fields)
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler
+func (i Indicator) MarshalYAML() (interface{}, error) {
+	return i.fields(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (i *Indicator) UnmarshalJSON(data []byte) error {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if stringValue(fields["indicator"]) == "" {
+		return fmt.Errorf("indicator name is required")
+	}
+	*i = indicatorFromMap(fields)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (i Indicator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.fields())
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (l *SymbolList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = SymbolList{node.Value}
+		return nil
+	}
+	var symbols []string
+	if err := node.Decode(&symbols); err != nil {
+		return err
+	}
+	*l = symbols
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (l *SymbolList) UnmarshalJSON(data []byte) error {
+	var symbol string
+	if err := json.Unmarshal(data, &symbol); err == nil {
+		*l = SymbolList{symbol}
+		return nil
+	}
+	var symbols []string
+	if err := json.Unmarshal(data, &symbols); err != nil {
+		return err
+	}
+	*l = symbols
+	return nil
+}
@@ -0,0 +1,147 @@
+package bulkspec
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigusigalpa/taapi-go"
+	"github.com/tigusigalpa/taapi-go/taapitest"
+)
+
+const screenYAML = `
+vars:
+  majors: [BTC/USDT, ETH/USDT]
+  period: 21
+constructs:
+  - exchange: binance
+    symbols: $majors
+    interval: 1h
+    indicators:
+      - indicator: rsi
+        id: ${symbol}_rsi
+        period: ${period}
+      - indicator: ema
+        id: ${symbol}_ema200
+        period: 200
+  - exchange: binance
+    symbol: SOL/USDT
+    interval: 4h
+    indicators:
+      - indicator: macd
+`
+
+func TestParseAndExpand(t *testing.T) {
+	spec, err := Parse([]byte(screenYAML))
+	require.NoError(t, err)
+	require.Len(t, spec.Constructs, 2)
+	assert.Equal(t, SymbolList{"$majors"}, spec.Constructs[0].Symbols)
+	assert.Equal(t, Indicator{Indicator: "ema", ID: "${symbol}_ema200", Params: map[string]interface{}{"period": 200}},
+		spec.Constructs[0].Indicators[1])
+
+	constructs, err := spec.Expand()
+	require.NoError(t, err)
+	require.Len(t, constructs, 3)
+	assert.Equal(t, "ETH/USDT", constructs[1].Symbol)
+	assert.Nil(t, constructs[1].Symbols)
+	assert.Equal(t, "ETH/USDT_rsi", constructs[1].Indicators[0].ID)
+	assert.Equal(t, 21, constructs[1].Indicators[0].Params["period"])
+	assert.Equal(t, "4h", constructs[2].Interval)
+
+	require.NoError(t, spec.Validate())
+}
+
+func TestParseJSON(t *testing.T) {
+	spec, err := Parse([]byte(`{"constructs": [{"exchange": "binance", "symbols": ["BTC/USDT", "ETH/USDT"],
+		"interval": "1h", "indicators": [{"indicator": "rsi", "period": 14}]}]}`))
+	require.NoError(t, err)
+	constructs, err := spec.Expand()
+	require.NoError(t, err)
+	assert.Len(t, constructs, 2)
+}
+
+func TestValidateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"unknown field", "constructs:\n  - exchange: binance\n    symbl: BTC/USDT\n"},
+		{"missing indicator name", "constructs:\n  - symbol: BTC/USDT\n    interval: 1h\n    indicators:\n      - period: 14\n"},
+		{"unknown indicator", "constructs:\n  - symbol: BTC/USDT\n    interval: 1h\n    indicators:\n      - indicator: nope\n"},
+		{"invalid parameter", "constructs:\n  - symbol: BTC/USDT\n    interval: 1h\n    indicators:\n      - indicator: rsi\n        period: fast\n"},
+		{"undefined variable", "constructs:\n  - symbol: $coins\n    interval: 1h\n    indicators:\n      - indicator: rsi\n"},
+		{"duplicate id", "constructs:\n  - symbols: [BTC/USDT, ETH/USDT]\n    interval: 1h\n    indicators:\n      - indicator: rsi\n        id: rsi\n"},
+		{"no indicators", "constructs:\n  - symbol: BTC/USDT\n    interval: 1h\n"},
+		{"no constructs", "vars: {}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := Parse([]byte(tt.spec))
+			if err == nil {
+				err = spec.Validate()
+			}
+			assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+		})
+	}
+}
+
+func TestBuildAndExecute(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, taapitest.Value(30))
+	server.Handle(taapi.IndicatorEMA, taapitest.Value(100))
+	server.Handle(taapi.IndicatorMACD, taapitest.Values(map[string]float64{"valueMACD": 1}))
+
+	spec, err := Parse([]byte(screenYAML))
+	require.NoError(t, err)
+
+	client := server.Client()
+	bulk, err := spec.Build(client)
+	require.NoError(t, err)
+
+	resp, err := bulk.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, 5, resp.Count())
+	assert.NotNil(t, resp.FindByID("ETH/USDT_rsi"))
+	server.AssertParam(t, taapi.IndicatorRSI, "period", "21")
+
+	spec.Constructs[1].Exchange = "nowhere"
+	_, err = spec.Build(client)
+	assert.ErrorIs(t, err, taapi.ErrUnsupportedExchange)
+}
+
+func TestRoundTrip(t *testing.T) {
+	client := taapi.NewClient("secret")
+	bulk := client.Bulk().AddConstruct(
+		client.Construct(taapi.ExchangeBinance, "BTCUSDT", taapi.Interval1h).
+			Add(taapi.RSI().Period(14).ID("rsi")).
+			AddIndicator(taapi.IndicatorEMA, map[string]interface{}{"period": 200}),
+	)
+
+	spec, err := FromBuilder(bulk)
+	require.NoError(t, err)
+	require.Len(t, spec.Constructs, 1)
+	assert.Equal(t, "BTC/USDT", spec.Constructs[0].Symbol)
+
+	var yamlOut, jsonOut bytes.Buffer
+	require.NoError(t, spec.WriteYAML(&yamlOut))
+	require.NoError(t, spec.WriteJSON(&jsonOut))
+	assert.Contains(t, yamlOut.String(), "indicator: rsi")
+
+	for _, data := range [][]byte{yamlOut.Bytes(), jsonOut.Bytes()} {
+		parsed, err := Parse(data)
+		require.NoError(t, err)
+		assert.Equal(t, spec, parsed)
+	}
+
+	path := filepath.Join(t.TempDir(), "screen.yaml")
+	require.NoError(t, os.WriteFile(path, yamlOut.Bytes(), 0o644))
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, spec, loaded)
+}
@@ -0,0 +1,257 @@
+package taapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Candle represents OHLCV candle data. Timestamp is the Unix time in seconds
+// of the candle open; decoding normalizes millisecond timestamps to seconds.
+type Candle struct {
+	Timestamp int64   `json:"timestamp"`
+	Open      float64 `json:"open"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Close     float64 `json:"close"`
+	Volume    float64 `json:"volume"`
+}
+
+// NewCandle creates a candle opening at t
+func NewCandle(t time.Time, open, high, low, close, volume float64) *Candle {
+	return &Candle{
+		Timestamp: t.Unix(),
+		Open:      open,
+		High:      high,
+		Low:       low,
+		Close:     close,
+		Volume:    volume,
+	}
+}
+
+// Time returns the candle open time. Timestamps that are not in seconds are
+// detected by magnitude and converted.
+func (c *Candle) Time() time.Time {
+	return time.Unix(TimeUnitAuto.ToSeconds(c.Timestamp), 0).UTC()
+}
+
+// ToArray converts a candle to an array format [timestamp, open, high, low, close, volume]
+// with the timestamp in Unix seconds
+func (c *Candle) ToArray() []interface{} {
+	return []interface{}{c.Time().Unix(), c.Open, c.High, c.Low, c.Close, c.Volume}
+}
+
+// UnmarshalJSON decodes a candle from an object with timestamp, open, high,
+// low, close and volume fields, or from an array
+// [timestamp, open, high, low, close, volume]. Values may be numbers or
+// numeric strings; the timestamp unit is detected and normalized to seconds.
+// Open, high, low and close are required, volume defaults to zero.
+func (c *Candle) UnmarshalJSON(data []byte) error {
+	return c.decodeJSON(data, TimeUnitAuto)
+}
+
+// candleFields are the JSON object keys of a candle in array order
+var candleFields = [6]string{"timestamp", "open", "high", "low", "close", "volume"}
+
+// decodeJSON decodes a candle in either JSON form with the given timestamp unit
+func (c *Candle) decodeJSON(data []byte, unit TimeUnit) error {
+	data = bytes.TrimSpace(data)
+
+	var values [6]json.Number
+	if len(data) > 0 && data[0] == '[' {
+		var raw []json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		if len(raw) < 5 {
+			return fmt.Errorf("candle array has %d elements, want at least 5", len(raw))
+		}
+		for i := 0; i < len(raw) && i < len(values); i++ {
+			n, err := jsonNumber(raw[i])
+			if err != nil {
+				return fmt.Errorf("candle element %d: %w", i, err)
+			}
+			values[i] = n
+		}
+	} else {
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		for i, field := range candleFields {
+			names := []string{field}
+			if i == 0 {
+				names = append(names, "time")
+			}
+			for _, name := range names {
+				field, ok := raw[name]
+				if !ok {
+					continue
+				}
+				n, err := jsonNumber(field)
+				if err != nil {
+					return fmt.Errorf("candle field %q: %w", name, err)
+				}
+				values[i] = n
+				break
+			}
+		}
+	}
+
+	if values[0] == "" {
+		return fmt.Errorf("candle is missing %s", candleFields[0])
+	}
+	ts, err := parseTimestampNumber(values[0])
+	if err != nil {
+		return fmt.Errorf("candle timestamp: %w", err)
+	}
+
+	var prices [5]float64
+	for i := range prices {
+		if values[i+1] == "" {
+			// Volume is optional, a missing price would read as zero
+			if i < 4 {
+				return fmt.Errorf("candle is missing %s", candleFields[i+1])
+			}
+			continue
+		}
+		if prices[i], err = values[i+1].Float64(); err != nil {
+			return err
+		}
+	}
+
+	*c = Candle{
+		Timestamp: unit.ToSeconds(ts),
+		Open:      prices[0],
+		High:      prices[1],
+		Low:       prices[2],
+		Close:     prices[3],
+		Volume:    prices[4],
+	}
+	return nil
+}
+
+// jsonNumber reads a JSON number, numeric string or null
+func jsonNumber(raw json.RawMessage) (json.Number, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return "", err
+		}
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return "", fmt.Errorf("%q is not a number", s)
+		}
+		return json.Number(s), nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return "", err
+	}
+	return n, nil
+}
+
+// parseTimestampNumber parses an integer timestamp, tolerating float notation
+func parseTimestampNumber(n json.Number) (int64, error) {
+	if ts, err := n.Int64(); err == nil {
+		return ts, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return 0, err
+	}
+	return int64(f), nil
+}
+
+// TimeUnit is the unit of an integer Unix timestamp
+type TimeUnit int
+
+const (
+	// TimeUnitAuto detects the unit from the magnitude of the timestamp
+	TimeUnitAuto TimeUnit = iota
+	TimeUnitSeconds
+	TimeUnitMilliseconds
+	TimeUnitMicroseconds
+	TimeUnitNanoseconds
+)
+
+// DetectTimeUnit guesses the unit of a Unix timestamp from its magnitude.
+// It is reliable for dates between 1973 and 5138.
+func DetectTimeUnit(ts int64) TimeUnit {
+	if ts < 0 {
+		ts = -ts
+	}
+	switch {
+	case ts < 1e11:
+		return TimeUnitSeconds
+	case ts < 1e14:
+		return TimeUnitMilliseconds
+	case ts < 1e17:
+		return TimeUnitMicroseconds
+	default:
+		return TimeUnitNanoseconds
+	}
+}
+
+// ToSeconds converts a timestamp in this unit to Unix seconds
+func (u TimeUnit) ToSeconds(ts int64) int64 {
+	if u == TimeUnitAuto {
+		u = DetectTimeUnit(ts)
+	}
+	switch u {
+	case TimeUnitMilliseconds:
+		return ts / 1e3
+	case TimeUnitMicroseconds:
+		return ts / 1e6
+	case TimeUnitNanoseconds:
+		return ts / 1e9
+	default:
+		return ts
+	}
+}
+
+// FromSeconds converts Unix seconds to a timestamp in this unit. Auto is
+// treated as seconds.
+func (u TimeUnit) FromSeconds(ts int64) int64 {
+	switch u {
+	case TimeUnitMilliseconds:
+		return ts * 1e3
+	case TimeUnitMicroseconds:
+		return ts * 1e6
+	case TimeUnitNanoseconds:
+		return ts * 1e9
+	default:
+		return ts
+	}
+}
+
+// decodeCandles decodes a candle object or an array of candle objects and
+// returns the candles sorted from oldest to newest
+func decodeCandles(data []byte) ([]*Candle, error) {
+	data = bytes.TrimSpace(data)
+
+	var candles []*Candle
+	if len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &candles); err != nil {
+			return nil, err
+		}
+	} else {
+		var candle Candle
+		if err := json.Unmarshal(data, &candle); err != nil {
+			return nil, err
+		}
+		candles = []*Candle{&candle}
+	}
+
+	sort.SliceStable(candles, func(i, j int) bool {
+		return candles[i].Timestamp < candles[j].Timestamp
+	})
+
+	return candles, nil
+}
@@ -0,0 +1,131 @@
+package taapi
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// The columnar candle format stores a series column by column:
+//
+//	magic   "TPCC" (4 bytes)
+//	version uint8
+//	count   uvarint
+//	ts      varint first timestamp, then varint deltas (count values)
+//	open, high, low, close, volume
+//	        count little-endian float64 values each
+//
+// Delta-encoded timestamps keep regular series to one or two bytes per row.
+const (
+	columnarMagic   = "TPCC"
+	columnarVersion = 1
+	// columnarMaxCount rejects headers with implausible counts
+	columnarMaxCount = 1 << 28
+	// columnarInitialCap bounds the allocation made before any row is read
+	columnarInitialCap = 4096
+)
+
+// WriteCandlesColumnar writes candles in the compact binary columnar format
+func WriteCandlesColumnar(w io.Writer, candles []*Candle) error {
+	buf := bufio.NewWriter(w)
+	scratch := make([]byte, binary.MaxVarintLen64)
+
+	write := func(p []byte) error {
+		_, err := buf.Write(p)
+		return err
+	}
+
+	if err := write(append([]byte(columnarMagic), columnarVersion)); err != nil {
+		return fmt.Errorf("taapi: write columnar candles: %w", err)
+	}
+	if err := write(scratch[:binary.PutUvarint(scratch, uint64(len(candles)))]); err != nil {
+		return fmt.Errorf("taapi: write columnar candles: %w", err)
+	}
+
+	var previous int64
+	for _, candle := range candles {
+		n := binary.PutVarint(scratch, candle.Timestamp-previous)
+		if err := write(scratch[:n]); err != nil {
+			return fmt.Errorf("taapi: write columnar candles: %w", err)
+		}
+		previous = candle.Timestamp
+	}
+
+	columns := []func(*Candle) float64{
+		func(c *Candle) float64 { return c.Open },
+		func(c *Candle) float64 { return c.High },
+		func(c *Candle) float64 { return c.Low },
+		func(c *Candle) float64 { return c.Close },
+		func(c *Candle) float64 { return c.Volume },
+	}
+	for _, column := range columns {
+		for _, candle := range candles {
+			binary.LittleEndian.PutUint64(scratch, math.Float64bits(column(candle)))
+			if err := write(scratch[:8]); err != nil {
+				return fmt.Errorf("taapi: write columnar candles: %w", err)
+			}
+		}
+	}
+
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("taapi: write columnar candles: %w", err)
+	}
+	return nil
+}
+
+// ReadCandlesColumnar reads candles written by WriteCandlesColumnar
+func ReadCandlesColumnar(r io.Reader) ([]*Candle, error) {
+	buf := bufio.NewReader(r)
+
+	header := make([]byte, len(columnarMagic)+1)
+	if _, err := io.ReadFull(buf, header); err != nil {
+		return nil, DecodeError("failed to read columnar header", err)
+	}
+	if string(header[:len(columnarMagic)]) != columnarMagic {
+		return nil, DecodeError("not a columnar candle stream", nil)
+	}
+	if header[len(columnarMagic)] != columnarVersion {
+		return nil, DecodeError(fmt.Sprintf("unsupported columnar version %d", header[len(columnarMagic)]), nil)
+	}
+
+	count, err := binary.ReadUvarint(buf)
+	if err != nil {
+		return nil, DecodeError("failed to read candle count", err)
+	}
+	if count > columnarMaxCount {
+		return nil, DecodeError(fmt.Sprintf("candle count %d exceeds limit", count), nil)
+	}
+
+	// The count is untrusted, so the slice grows as timestamps are read
+	candles := make([]*Candle, 0, min(count, columnarInitialCap))
+	var previous int64
+	for i := uint64(0); i < count; i++ {
+		delta, err := binary.ReadVarint(buf)
+		if err != nil {
+			return nil, DecodeError("failed to read timestamps", err)
+		}
+		previous += delta
+		candles = append(candles, &Candle{Timestamp: previous})
+	}
+
+	columns := []func(*Candle, float64){
+		func(c *Candle, v float64) { c.Open = v },
+		func(c *Candle, v float64) { c.High = v },
+		func(c *Candle, v float64) { c.Low = v },
+		func(c *Candle, v float64) { c.Close = v },
+		func(c *Candle, v float64) { c.Volume = v },
+	}
+	value := make([]byte, 8)
+	for _, column := range columns {
+		for _, candle := range candles {
+			if _, err := io.ReadFull(buf, value); err != nil {
+				return nil, DecodeError("failed to read price columns", err)
+			}
+			column(candle, math.Float64frombits(binary.LittleEndian.Uint64(value)))
+		}
+	}
+
+	return candles, nil
+}
@@ -0,0 +1,59 @@
+package taapi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCandlesColumnarRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCandlesColumnar(&buf, sampleCandles()))
+
+	candles, err := ReadCandlesColumnar(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, sampleCandles(), candles)
+}
+
+func TestCandlesColumnarCompact(t *testing.T) {
+	candles := make([]*Candle, 1000)
+	for i := range candles {
+		candles[i] = &Candle{Timestamp: 1609459200 + int64(i)*60, Close: float64(i)}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCandlesColumnar(&buf, candles))
+	// 5 float columns plus ~2 bytes per timestamp delta
+	assert.Less(t, buf.Len(), 1000*5*8+1000*2+16)
+
+	decoded, err := ReadCandlesColumnar(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, candles, decoded)
+}
+
+func TestReadCandlesColumnarInvalid(t *testing.T) {
+	_, err := ReadCandlesColumnar(bytes.NewReader([]byte("NOPE\x01")))
+	assert.ErrorIs(t, err, ErrDecode)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCandlesColumnar(&buf, sampleCandles()))
+	_, err = ReadCandlesColumnar(bytes.NewReader(buf.Bytes()[:buf.Len()-4]))
+	assert.ErrorIs(t, err, ErrDecode)
+}
+
+func TestReadCandlesColumnarLargeCount(t *testing.T) {
+	// A header claiming 2^28-1 candles followed by no data
+	header := append([]byte(columnarMagic+"\x01"), binary.AppendUvarint(nil, columnarMaxCount-1)...)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	_, err := ReadCandlesColumnar(bytes.NewReader(header))
+	runtime.ReadMemStats(&after)
+
+	assert.ErrorIs(t, err, ErrDecode)
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(1<<20), "allocation is bounded by the input")
+}
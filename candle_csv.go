@@ -0,0 +1,311 @@
+package taapi
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVColumns maps candle fields to CSV columns. With a header row each entry
+// is a column name (matched case-insensitively); without one it is a
+// zero-based column index such as "0". An empty Volume means the file has no
+// volume column.
+type CSVColumns struct {
+	Timestamp string
+	Open      string
+	High      string
+	Low       string
+	Close     string
+	Volume    string
+}
+
+// DefaultCSVColumns is the column mapping used when none is configured
+var DefaultCSVColumns = CSVColumns{
+	Timestamp: "timestamp",
+	Open:      "open",
+	High:      "high",
+	Low:       "low",
+	Close:     "close",
+	Volume:    "volume",
+}
+
+// csvColumnAliases are accepted header names for each field when the
+// default mapping is used
+var csvColumnAliases = [6][]string{
+	{"timestamp", "time", "date", "datetime", "ts", "t", "open_time", "opentime"},
+	{"open", "o"},
+	{"high", "h"},
+	{"low", "l"},
+	{"close", "c"},
+	{"volume", "vol", "v"},
+}
+
+// CSVOptions configures reading and writing candles as CSV
+type CSVOptions struct {
+	// Comma is the field delimiter, ',' by default
+	Comma rune
+	// Header reports whether the first row holds column names
+	Header bool
+	// Columns maps candle fields to columns, DefaultCSVColumns when empty
+	Columns CSVColumns
+	// TimeUnit is the unit of integer timestamps. When reading, Auto detects
+	// it per row; when writing, Auto writes seconds.
+	TimeUnit TimeUnit
+	// TimeLayout parses and formats timestamps as text (e.g. time.RFC3339)
+	// instead of integers. Times are written in UTC.
+	TimeLayout string
+}
+
+func (o *CSVOptions) comma() rune {
+	if o.Comma == 0 {
+		return ','
+	}
+	return o.Comma
+}
+
+func (o *CSVOptions) columns() CSVColumns {
+	if o.Columns == (CSVColumns{}) {
+		return DefaultCSVColumns
+	}
+	return o.Columns
+}
+
+// ReadCandlesCSV reads candles from CSV. A nil opts reads a headerless file
+// with columns timestamp, open, high, low, close, volume.
+func ReadCandlesCSV(r io.Reader, opts *CSVOptions) ([]*Candle, error) {
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = opts.comma()
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var indexes [6]int
+	line := 0
+
+	if opts.Header {
+		header, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, nil
+			}
+			return nil, DecodeError("failed to read CSV header", err)
+		}
+		line++
+		if indexes, err = resolveCSVHeader(header, opts); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if indexes, err = resolveCSVIndexes(opts); err != nil {
+			return nil, err
+		}
+	}
+
+	var candles []*Candle
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, DecodeError(fmt.Sprintf("csv line %d", line), err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		candle, err := parseCSVRecord(record, indexes, opts)
+		if err != nil {
+			return nil, DecodeError(fmt.Sprintf("csv line %d", line), err)
+		}
+		candles = append(candles, candle)
+	}
+
+	return candles, nil
+}
+
+// resolveCSVHeader finds the column index of every candle field in a header row
+func resolveCSVHeader(header []string, opts *CSVOptions) ([6]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := opts.columns()
+	names := [6]string{columns.Timestamp, columns.Open, columns.High, columns.Low, columns.Close, columns.Volume}
+	useAliases := opts.Columns == (CSVColumns{})
+
+	var indexes [6]int
+	for field, name := range names {
+		indexes[field] = -1
+		candidates := []string{name}
+		if useAliases {
+			candidates = csvColumnAliases[field]
+		}
+		for _, candidate := range candidates {
+			if i, ok := positions[strings.ToLower(candidate)]; ok && candidate != "" {
+				indexes[field] = i
+				break
+			}
+		}
+		if indexes[field] < 0 && field != 5 {
+			return indexes, InvalidArgumentError(fmt.Sprintf("CSV header has no %q column", name))
+		}
+	}
+	return indexes, nil
+}
+
+// resolveCSVIndexes parses numeric column positions for headerless files
+func resolveCSVIndexes(opts *CSVOptions) ([6]int, error) {
+	if opts.Columns == (CSVColumns{}) {
+		return [6]int{0, 1, 2, 3, 4, 5}, nil
+	}
+
+	columns := opts.Columns
+	names := [6]string{columns.Timestamp, columns.Open, columns.High, columns.Low, columns.Close, columns.Volume}
+
+	var indexes [6]int
+	for field, name := range names {
+		if name == "" && field == 5 {
+			indexes[field] = -1
+			continue
+		}
+		i, err := strconv.Atoi(name)
+		if err != nil || i < 0 {
+			return indexes, InvalidArgumentError(fmt.Sprintf("CSV column %q must be a column index when there is no header", name))
+		}
+		indexes[field] = i
+	}
+	return indexes, nil
+}
+
+// parseCSVRecord converts a CSV record to a candle
+func parseCSVRecord(record []string, indexes [6]int, opts *CSVOptions) (*Candle, error) {
+	field := func(i int) (string, error) {
+		if i >= len(record) {
+			return "", fmt.Errorf("missing column %d", i)
+		}
+		return strings.TrimSpace(record[i]), nil
+	}
+
+	raw, err := field(indexes[0])
+	if err != nil {
+		return nil, err
+	}
+	ts, err := parseCSVTimestamp(raw, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var values [5]float64
+	for i := 0; i < 5; i++ {
+		if indexes[i+1] < 0 {
+			continue
+		}
+		raw, err := field(indexes[i+1])
+		if err != nil {
+			return nil, err
+		}
+		if values[i], err = strconv.ParseFloat(raw, 64); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Candle{
+		Timestamp: ts,
+		Open:      values[0],
+		High:      values[1],
+		Low:       values[2],
+		Close:     values[3],
+		Volume:    values[4],
+	}, nil
+}
+
+// parseCSVTimestamp parses a timestamp cell into Unix seconds
+func parseCSVTimestamp(raw string, opts *CSVOptions) (int64, error) {
+	if opts.TimeLayout != "" {
+		t, err := time.Parse(opts.TimeLayout, raw)
+		if err != nil {
+			return 0, err
+		}
+		return t.Unix(), nil
+	}
+
+	if ts, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return opts.TimeUnit.ToSeconds(ts), nil
+	}
+	// Some exports write integer timestamps in float notation
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return opts.TimeUnit.ToSeconds(int64(f)), nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp %q", raw)
+	}
+	return t.Unix(), nil
+}
+
+// WriteCandlesCSV writes candles as CSV in timestamp, open, high, low,
+// close, volume order. A header row is written when opts.Header is set, and
+// volume is omitted when the columns map no Volume.
+func WriteCandlesCSV(w io.Writer, candles []*Candle, opts *CSVOptions) error {
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = opts.comma()
+	columns := opts.columns()
+
+	if opts.Header {
+		header := []string{columns.Timestamp, columns.Open, columns.High, columns.Low, columns.Close}
+		if columns.Volume != "" {
+			header = append(header, columns.Volume)
+		}
+		if err := writer.Write(header); err != nil {
+			return fmt.Errorf("taapi: write CSV: %w", err)
+		}
+	}
+
+	withVolume := columns.Volume != ""
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	for _, candle := range candles {
+		var ts string
+		if opts.TimeLayout != "" {
+			ts = time.Unix(candle.Timestamp, 0).UTC().Format(opts.TimeLayout)
+		} else {
+			ts = strconv.FormatInt(opts.TimeUnit.FromSeconds(candle.Timestamp), 10)
+		}
+
+		record := []string{
+			ts,
+			formatFloat(candle.Open),
+			formatFloat(candle.High),
+			formatFloat(candle.Low),
+			formatFloat(candle.Close),
+		}
+		if withVolume {
+			record = append(record, formatFloat(candle.Volume))
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("taapi: write CSV: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("taapi: write CSV: %w", err)
+	}
+	return nil
+}
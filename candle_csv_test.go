@@ -0,0 +1,107 @@
+package taapi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleCandles() []*Candle {
+	return []*Candle{
+		{Timestamp: 1609459200, Open: 28923.63, High: 28923.63, Low: 28923.63, Close: 28923.63, Volume: 0.0},
+		{Timestamp: 1609462800, Open: 29083.37, High: 29188.78, Low: 28963.64, Close: 29103.37, Volume: 1107.056268},
+		{Timestamp: 1609466400, Open: 29103.38, High: 29152.98, Low: 28980.01, Close: 29050.00, Volume: 978.581086},
+	}
+}
+
+func TestReadCandlesCSVHeaderless(t *testing.T) {
+	data := "1609459200000,1,2,0.5,1.5,10\n1609462800000,1.5,3,1,2,20\n"
+
+	candles, err := ReadCandlesCSV(strings.NewReader(data), nil)
+	require.NoError(t, err)
+	require.Len(t, candles, 2)
+	assert.Equal(t, int64(1609459200), candles[0].Timestamp)
+	assert.Equal(t, 2.0, candles[1].Close)
+	assert.Equal(t, 20.0, candles[1].Volume)
+}
+
+func TestReadCandlesCSVColumnMapping(t *testing.T) {
+	data := "Date;Close;Open;High;Low\n2021-01-01T00:00:00Z;1.5;1;2;0.5\n"
+
+	candles, err := ReadCandlesCSV(strings.NewReader(data), &CSVOptions{
+		Comma:      ';',
+		Header:     true,
+		TimeLayout: time.RFC3339,
+		Columns: CSVColumns{
+			Timestamp: "date",
+			Open:      "open",
+			High:      "high",
+			Low:       "low",
+			Close:     "close",
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Equal(t, int64(1609459200), candles[0].Timestamp)
+	assert.Equal(t, 1.0, candles[0].Open)
+	assert.Equal(t, 1.5, candles[0].Close)
+	assert.Equal(t, 0.0, candles[0].Volume)
+}
+
+func TestReadCandlesCSVHeaderAliases(t *testing.T) {
+	data := "open_time,o,h,l,c,vol\n1609459200,1,2,0.5,1.5,10\n"
+
+	candles, err := ReadCandlesCSV(strings.NewReader(data), &CSVOptions{Header: true, TimeUnit: TimeUnitSeconds})
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Equal(t, 10.0, candles[0].Volume)
+}
+
+func TestReadCandlesCSVIndexes(t *testing.T) {
+	data := "x,1609459200,1.5,1,2,0.5\n"
+
+	candles, err := ReadCandlesCSV(strings.NewReader(data), &CSVOptions{
+		Columns: CSVColumns{Timestamp: "1", Open: "3", High: "4", Low: "5", Close: "2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, candles[0].Open)
+	assert.Equal(t, 1.5, candles[0].Close)
+}
+
+func TestReadCandlesCSVErrors(t *testing.T) {
+	_, err := ReadCandlesCSV(strings.NewReader("1609459200,abc,1,1,1,1\n"), nil)
+	assert.ErrorIs(t, err, ErrDecode)
+	assert.Contains(t, err.Error(), "csv line 1")
+
+	_, err = ReadCandlesCSV(strings.NewReader("a,b\n"), &CSVOptions{Header: true})
+	assert.ErrorIs(t, err, ErrInvalidParams)
+}
+
+func TestWriteCandlesCSVRoundTrip(t *testing.T) {
+	opts := &CSVOptions{Header: true, TimeUnit: TimeUnitMilliseconds}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCandlesCSV(&buf, sampleCandles(), opts))
+	assert.True(t, strings.HasPrefix(buf.String(), "timestamp,open,high,low,close,volume\n1609459200000,"))
+
+	candles, err := ReadCandlesCSV(&buf, opts)
+	require.NoError(t, err)
+	assert.Equal(t, sampleCandles(), candles)
+}
+
+func TestWriteCandlesCSVWithoutVolume(t *testing.T) {
+	opts := &CSVOptions{Columns: CSVColumns{Timestamp: "0", Open: "1", High: "2", Low: "3", Close: "4"}}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCandlesCSV(&buf, sampleCandles()[:1], opts))
+	assert.Equal(t, 5, strings.Count(buf.String(), ",")+1)
+
+	candles, err := ReadCandlesCSV(&buf, opts)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Zero(t, candles[0].Volume)
+}
@@ -0,0 +1,52 @@
+package taapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ReadCandlesJSONL reads candles from JSON Lines, one candle object or
+// array per line. Timestamps are converted from unit to Unix seconds.
+func ReadCandlesJSONL(r io.Reader, unit TimeUnit) ([]*Candle, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var candles []*Candle
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var candle Candle
+		if err := candle.decodeJSON(data, unit); err != nil {
+			return nil, DecodeError(fmt.Sprintf("jsonl line %d", line), err)
+		}
+		candles = append(candles, &candle)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, DecodeError("failed to read JSON Lines", err)
+	}
+
+	return candles, nil
+}
+
+// WriteCandlesJSONL writes candles as JSON Lines, one candle object per line
+func WriteCandlesJSONL(w io.Writer, candles []*Candle) error {
+	buf := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buf)
+	for _, candle := range candles {
+		if err := encoder.Encode(candle); err != nil {
+			return fmt.Errorf("taapi: write JSON Lines: %w", err)
+		}
+	}
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("taapi: write JSON Lines: %w", err)
+	}
+	return nil
+}
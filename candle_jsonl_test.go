@@ -0,0 +1,38 @@
+package taapi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCandlesJSONLRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCandlesJSONL(&buf, sampleCandles()))
+	assert.Equal(t, 3, strings.Count(buf.String(), "\n"))
+
+	candles, err := ReadCandlesJSONL(&buf, TimeUnitSeconds)
+	require.NoError(t, err)
+	assert.Equal(t, sampleCandles(), candles)
+}
+
+func TestReadCandlesJSONLUnits(t *testing.T) {
+	data := `{"timestamp":1609459200000,"open":1,"high":2,"low":0.5,"close":1.5,"volume":10}
+
+{"timestamp":1609462800000,"open":1.5,"high":3,"low":1,"close":2,"volume":20}
+`
+	candles, err := ReadCandlesJSONL(strings.NewReader(data), TimeUnitAuto)
+	require.NoError(t, err)
+	require.Len(t, candles, 2)
+	assert.Equal(t, int64(1609462800), candles[1].Timestamp)
+
+	candles, err = ReadCandlesJSONL(strings.NewReader("[1609459200000,1,2,0.5,1.5,10]\n"), TimeUnitMilliseconds)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1609459200), candles[0].Timestamp)
+
+	_, err = ReadCandlesJSONL(strings.NewReader("{oops}\n"), TimeUnitAuto)
+	assert.ErrorIs(t, err, ErrDecode)
+}
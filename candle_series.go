@@ -0,0 +1,271 @@
+package taapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CandleSeries is an ordered sequence of candles of a single interval
+type CandleSeries struct {
+	interval Interval
+	candles  []*Candle
+}
+
+// NewCandleSeries creates a series from candles of the given interval. The
+// candles are used as given; call Validate before relying on them.
+func NewCandleSeries(interval Interval, candles []*Candle) *CandleSeries {
+	return &CandleSeries{
+		interval: interval,
+		candles:  candles,
+	}
+}
+
+// Interval returns the interval of the series
+func (s *CandleSeries) Interval() Interval {
+	return s.interval
+}
+
+// Candles returns the candles of the series
+func (s *CandleSeries) Candles() []*Candle {
+	return s.candles
+}
+
+// Len returns the number of candles
+func (s *CandleSeries) Len() int {
+	return len(s.candles)
+}
+
+// CandleIssueKind classifies a problem found by CandleSeries.Validate
+type CandleIssueKind int
+
+const (
+	IssueNilCandle CandleIssueKind = iota
+	IssueOutOfOrder
+	IssueDuplicate
+	IssueMisaligned
+	IssueInvalidOHLC
+	IssueGap
+)
+
+// String returns a short name for the issue kind
+func (k CandleIssueKind) String() string {
+	switch k {
+	case IssueNilCandle:
+		return "nil candle"
+	case IssueOutOfOrder:
+		return "out of order"
+	case IssueDuplicate:
+		return "duplicate"
+	case IssueMisaligned:
+		return "misaligned"
+	case IssueInvalidOHLC:
+		return "invalid OHLC"
+	case IssueGap:
+		return "gap"
+	default:
+		return "unknown"
+	}
+}
+
+// CandleIssue is a single problem at a position in a series
+type CandleIssue struct {
+	Index   int
+	Kind    CandleIssueKind
+	Message string
+}
+
+// CandleSeriesError reports every issue found in a series
+type CandleSeriesError struct {
+	Issues []CandleIssue
+}
+
+// Error implements the error interface
+func (e *CandleSeriesError) Error() string {
+	const shown = 3
+	parts := make([]string, 0, shown)
+	for i, issue := range e.Issues {
+		if i == shown {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("candle %d: %s", issue.Index, issue.Message))
+	}
+	message := strings.Join(parts, "; ")
+	if len(e.Issues) > shown {
+		message += fmt.Sprintf(" (and %d more)", len(e.Issues)-shown)
+	}
+	return fmt.Sprintf("taapi error: invalid candle series: %s", message)
+}
+
+// Is reports whether the target is ErrInvalidCandles
+func (e *CandleSeriesError) Is(target error) bool {
+	return target == ErrInvalidCandles
+}
+
+// Validate checks that timestamps strictly increase, are aligned to the
+// series interval and that every candle has consistent OHLC values. It
+// returns a *CandleSeriesError listing all issues, or nil.
+func (s *CandleSeries) Validate() error {
+	var issues []CandleIssue
+	add := func(index int, kind CandleIssueKind, format string, args ...interface{}) {
+		issues = append(issues, CandleIssue{Index: index, Kind: kind, Message: fmt.Sprintf(format, args...)})
+	}
+
+	step := int64(s.interval.Duration().Seconds())
+	var previous *Candle
+
+	for i, candle := range s.candles {
+		if candle == nil {
+			add(i, IssueNilCandle, "candle is nil")
+			continue
+		}
+
+		if previous != nil {
+			switch {
+			case candle.Timestamp == previous.Timestamp:
+				add(i, IssueDuplicate, "duplicate timestamp %d", candle.Timestamp)
+			case candle.Timestamp < previous.Timestamp:
+				add(i, IssueOutOfOrder, "timestamp %d is before %d", candle.Timestamp, previous.Timestamp)
+			}
+		}
+
+		if step > 0 && !s.interval.isAligned(candle.Timestamp) {
+			add(i, IssueMisaligned, "timestamp %d is not aligned to %s", candle.Timestamp, s.interval)
+		}
+
+		if msg := ohlcProblem(candle); msg != "" {
+			add(i, IssueInvalidOHLC, "%s", msg)
+		}
+
+		previous = candle
+	}
+
+	if len(issues) > 0 {
+		return &CandleSeriesError{Issues: issues}
+	}
+	return nil
+}
+
+// ohlcProblem describes why a candle's prices are inconsistent, or returns ""
+func ohlcProblem(c *Candle) string {
+	switch {
+	case c.High < c.Low:
+		return fmt.Sprintf("high %v is below low %v", c.High, c.Low)
+	case c.Open > c.High || c.Open < c.Low:
+		return fmt.Sprintf("open %v is outside [%v, %v]", c.Open, c.Low, c.High)
+	case c.Close > c.High || c.Close < c.Low:
+		return fmt.Sprintf("close %v is outside [%v, %v]", c.Close, c.Low, c.High)
+	case c.Volume < 0:
+		return fmt.Sprintf("volume %v is negative", c.Volume)
+	}
+	return ""
+}
+
+// isAligned checks that a Unix timestamp in seconds starts a candle of the
+// interval. Weekly candles start on Monday 00:00 UTC.
+func (i Interval) isAligned(ts int64) bool {
+	step := int64(i.Duration().Seconds())
+	if step <= 0 {
+		return true
+	}
+	if i == Interval1w {
+		// The Unix epoch was a Thursday; Mondays are 4 days later
+		ts -= 4 * 24 * 60 * 60
+	}
+	return ts%step == 0
+}
+
+// CandleGap is a run of missing candles between two candles of a series
+type CandleGap struct {
+	// Index is the position of the candle following the gap
+	Index int
+	// From and To are the timestamps of the first and last missing candle
+	From int64
+	To   int64
+	// Missing is the number of missing candles
+	Missing int
+}
+
+// Gaps returns every run of missing candles. It requires an interval with a
+// fixed duration and assumes ordered candles.
+func (s *CandleSeries) Gaps() []CandleGap {
+	step := int64(s.interval.Duration().Seconds())
+	if step <= 0 {
+		return nil
+	}
+
+	var gaps []CandleGap
+	for i := 1; i < len(s.candles); i++ {
+		prev, cur := s.candles[i-1], s.candles[i]
+		if prev == nil || cur == nil {
+			continue
+		}
+		if diff := cur.Timestamp - prev.Timestamp; diff > step {
+			missing := int((diff - 1) / step)
+			gaps = append(gaps, CandleGap{
+				Index:   i,
+				From:    prev.Timestamp + step,
+				To:      prev.Timestamp + int64(missing)*step,
+				Missing: missing,
+			})
+		}
+	}
+	return gaps
+}
+
+// GapFillStrategy selects how FillGaps handles missing candles
+type GapFillStrategy int
+
+const (
+	// GapFillError returns an error when the series has gaps
+	GapFillError GapFillStrategy = iota
+	// GapFillSkip leaves gaps in place
+	GapFillSkip
+	// GapFillForward inserts flat candles at the previous close with zero volume
+	GapFillForward
+)
+
+// FillGaps returns a new series with gaps handled by the given strategy
+func (s *CandleSeries) FillGaps(strategy GapFillStrategy) (*CandleSeries, error) {
+	gaps := s.Gaps()
+
+	switch strategy {
+	case GapFillError:
+		if len(gaps) > 0 {
+			issues := make([]CandleIssue, len(gaps))
+			for i, gap := range gaps {
+				issues[i] = CandleIssue{
+					Index:   gap.Index,
+					Kind:    IssueGap,
+					Message: fmt.Sprintf("%d missing candles from %d to %d", gap.Missing, gap.From, gap.To),
+				}
+			}
+			return nil, &CandleSeriesError{Issues: issues}
+		}
+		return NewCandleSeries(s.interval, append([]*Candle(nil), s.candles...)), nil
+	case GapFillSkip:
+		return NewCandleSeries(s.interval, append([]*Candle(nil), s.candles...)), nil
+	case GapFillForward:
+		step := int64(s.interval.Duration().Seconds())
+		filled := make([]*Candle, 0, len(s.candles))
+		next := 0
+		for i, candle := range s.candles {
+			if next < len(gaps) && gaps[next].Index == i {
+				prevClose := s.candles[i-1].Close
+				for ts := gaps[next].From; ts <= gaps[next].To; ts += step {
+					filled = append(filled, &Candle{
+						Timestamp: ts,
+						Open:      prevClose,
+						High:      prevClose,
+						Low:       prevClose,
+						Close:     prevClose,
+					})
+				}
+				next++
+			}
+			filled = append(filled, candle)
+		}
+		return NewCandleSeries(s.interval, filled), nil
+	}
+
+	return nil, InvalidArgumentError(fmt.Sprintf("unknown gap fill strategy %d", strategy))
+}
@@ -0,0 +1,101 @@
+package taapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func flatCandle(ts int64, price float64) *Candle {
+	return &Candle{Timestamp: ts, Open: price, High: price, Low: price, Close: price, Volume: 1}
+}
+
+func TestCandleSeriesValidateOK(t *testing.T) {
+	series := NewCandleSeries(Interval1h, sampleCandles())
+	assert.NoError(t, series.Validate())
+	assert.Equal(t, 3, series.Len())
+}
+
+func TestCandleSeriesValidateIssues(t *testing.T) {
+	candles := []*Candle{
+		flatCandle(1609459200, 1),
+		flatCandle(1609459200, 1),
+		flatCandle(1609455600, 1),
+		flatCandle(1609470001, 1),
+		{Timestamp: 1609473600, Open: 1, High: 1, Low: 2, Close: 1},
+		nil,
+	}
+
+	err := NewCandleSeries(Interval1h, candles).Validate()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidCandles))
+
+	var seriesErr *CandleSeriesError
+	require.True(t, errors.As(err, &seriesErr))
+
+	kinds := make([]CandleIssueKind, len(seriesErr.Issues))
+	for i, issue := range seriesErr.Issues {
+		kinds[i] = issue.Kind
+	}
+	assert.Equal(t, []CandleIssueKind{IssueDuplicate, IssueOutOfOrder, IssueMisaligned, IssueInvalidOHLC, IssueNilCandle}, kinds)
+	assert.Contains(t, err.Error(), "and 2 more")
+}
+
+func TestCandleSeriesWeeklyAlignment(t *testing.T) {
+	// 2021-01-04 was a Monday
+	assert.True(t, Interval1w.isAligned(1609718400))
+	assert.False(t, Interval1w.isAligned(1609459200))
+}
+
+func TestCandleSeriesGaps(t *testing.T) {
+	series := NewCandleSeries(Interval1h, []*Candle{
+		flatCandle(1609459200, 1),
+		flatCandle(1609462800, 2),
+		flatCandle(1609473600, 3),
+	})
+
+	gaps := series.Gaps()
+	require.Len(t, gaps, 1)
+	assert.Equal(t, CandleGap{Index: 2, From: 1609466400, To: 1609470000, Missing: 2}, gaps[0])
+}
+
+func TestCandleSeriesFillGaps(t *testing.T) {
+	series := NewCandleSeries(Interval1h, []*Candle{
+		flatCandle(1609459200, 1),
+		flatCandle(1609462800, 2),
+		flatCandle(1609473600, 3),
+	})
+
+	_, err := series.FillGaps(GapFillError)
+	assert.ErrorIs(t, err, ErrInvalidCandles)
+
+	skipped, err := series.FillGaps(GapFillSkip)
+	require.NoError(t, err)
+	assert.Equal(t, 3, skipped.Len())
+
+	filled, err := series.FillGaps(GapFillForward)
+	require.NoError(t, err)
+	require.Equal(t, 5, filled.Len())
+	assert.Empty(t, filled.Gaps())
+	assert.NoError(t, filled.Validate())
+	assert.Equal(t, int64(1609466400), filled.Candles()[2].Timestamp)
+	assert.Equal(t, 2.0, filled.Candles()[3].Close)
+	assert.Equal(t, 0.0, filled.Candles()[3].Volume)
+}
+
+func TestManualBuilderWithCandleSeries(t *testing.T) {
+	client := NewClient("test_secret")
+
+	invalid := NewCandleSeries(Interval1h, []*Candle{flatCandle(1609462800, 1), flatCandle(1609459200, 1)})
+	_, err := client.Manual(IndicatorEMA).WithCandleSeries(invalid).Execute()
+	assert.ErrorIs(t, err, ErrInvalidCandles)
+
+	_, err = client.Manual(IndicatorEMA).WithCandleSeries(nil).Execute()
+	assert.ErrorIs(t, err, ErrInvalidParams)
+
+	builder := client.Manual(IndicatorEMA).WithCandleSeries(NewCandleSeries(Interval1h, sampleCandles()))
+	assert.NoError(t, builder.err)
+	assert.Len(t, builder.candles, 3)
+}
@@ -0,0 +1,127 @@
+package taapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCandleToArray(t *testing.T) {
+	candle := &Candle{
+		Timestamp: 1609459200,
+		Open:      28923.63,
+		High:      28923.63,
+		Low:       28923.63,
+		Close:     28923.63,
+		Volume:    0.0,
+	}
+	
+	array := candle.ToArray()
+	assert.Equal(t, 6, len(array))
+	assert.Equal(t, int64(1609459200), array[0])
+	assert.Equal(t, 28923.63, array[1])
+}
+
+func TestDecodeCandles(t *testing.T) {
+	single := `{"timestampHuman":"2021-01-01 00:00:00 (Friday) UTC","timestamp":1609459200,"open":1,"high":2,"low":0.5,"close":1.5,"volume":10}`
+	candles, err := decodeCandles([]byte(single))
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Equal(t, int64(1609459200), candles[0].Timestamp)
+	assert.Equal(t, 1.5, candles[0].Close)
+
+	array := `[
+		{"timestamp":1609466400,"open":3,"high":3,"low":3,"close":3,"volume":1,"backtrack":0},
+		{"timestamp":1609462800,"open":2,"high":2,"low":2,"close":2,"volume":1,"backtrack":1},
+		{"timestamp":1609459200,"open":1,"high":1,"low":1,"close":1,"volume":1,"backtrack":2}
+	]`
+	candles, err = decodeCandles([]byte(array))
+	require.NoError(t, err)
+	require.Len(t, candles, 3)
+	assert.Equal(t, int64(1609459200), candles[0].Timestamp)
+	assert.Equal(t, int64(1609466400), candles[2].Timestamp)
+
+	_, err = decodeCandles([]byte(`"nope"`))
+	assert.Error(t, err)
+}
+
+func TestTimeUnitConversion(t *testing.T) {
+	assert.Equal(t, TimeUnitSeconds, DetectTimeUnit(1609459200))
+	assert.Equal(t, TimeUnitMilliseconds, DetectTimeUnit(1609459200000))
+	assert.Equal(t, TimeUnitMicroseconds, DetectTimeUnit(1609459200000000))
+	assert.Equal(t, TimeUnitNanoseconds, DetectTimeUnit(1609459200000000000))
+
+	assert.Equal(t, int64(1609459200), TimeUnitAuto.ToSeconds(1609459200000))
+	assert.Equal(t, int64(1609459200), TimeUnitMicroseconds.ToSeconds(1609459200000000))
+	assert.Equal(t, int64(1609459200000), TimeUnitMilliseconds.FromSeconds(1609459200))
+	assert.Equal(t, int64(1609459200), TimeUnitAuto.FromSeconds(1609459200))
+}
+
+func TestCandleTime(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	candle := NewCandle(start, 1, 2, 0.5, 1.5, 10)
+	assert.Equal(t, int64(1609459200), candle.Timestamp)
+	assert.Equal(t, start, candle.Time())
+
+	millis := &Candle{Timestamp: 1609459200000}
+	assert.Equal(t, start, millis.Time())
+	assert.Equal(t, int64(1609459200), millis.ToArray()[0])
+}
+
+func TestCandleUnmarshalJSON(t *testing.T) {
+	tests := map[string]string{
+		"object seconds":      `{"timestamp":1609459200,"open":1,"high":2,"low":0.5,"close":1.5,"volume":10}`,
+		"object milliseconds": `{"timestamp":1609459200000,"open":1,"high":2,"low":0.5,"close":1.5,"volume":10}`,
+		"object strings":      `{"timestamp":"1609459200","open":"1","high":"2","low":"0.5","close":"1.5","volume":"10"}`,
+		"array":               `[1609459200000,1,2,0.5,1.5,10]`,
+		"array strings":       `[1609459200,"1","2","0.5","1.5","10"]`,
+	}
+
+	expected := &Candle{Timestamp: 1609459200, Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 10}
+	for name, data := range tests {
+		var candle Candle
+		require.NoError(t, json.Unmarshal([]byte(data), &candle), name)
+		assert.Equal(t, expected, &candle, name)
+	}
+
+	var candle Candle
+	assert.Error(t, json.Unmarshal([]byte(`[1609459200,1]`), &candle))
+	assert.Error(t, json.Unmarshal([]byte(`{"timestamp":1609459200,"open":"abc"}`), &candle))
+
+	// Missing or null prices are rejected rather than decoded as zero
+	err := json.Unmarshal([]byte(`{"timestamp":1609459200,"open":1,"high":2,"low":0.5,"volume":10}`), &candle)
+	assert.EqualError(t, err, "candle is missing close")
+	err = json.Unmarshal([]byte(`[1609459200,1,null,0.5,1.5]`), &candle)
+	assert.EqualError(t, err, "candle is missing high")
+
+	// So is a missing timestamp, which would read as 1970
+	err = json.Unmarshal([]byte(`{"open":1,"high":2,"low":0.5,"close":1.5,"volume":10}`), &candle)
+	assert.EqualError(t, err, "candle is missing timestamp")
+	err = json.Unmarshal([]byte(`[null,1,2,0.5,1.5]`), &candle)
+	assert.EqualError(t, err, "candle is missing timestamp")
+
+	// Volume is optional
+	require.NoError(t, json.Unmarshal([]byte(`{"timestamp":1609459200,"open":1,"high":2,"low":0.5,"close":1.5}`), &candle))
+	assert.Zero(t, candle.Volume)
+}
+
+func TestCandleJSONRoundTrip(t *testing.T) {
+	original := NewCandle(time.Unix(1609459200, 0), 1, 2, 0.5, 1.5, 10)
+
+	data, err := json.Marshal(original)
+	require.NoError(t, err)
+
+	var decoded Candle
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, original, &decoded)
+
+	var fromArray Candle
+	arrayData, err := json.Marshal(original.ToArray())
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(arrayData, &fromArray))
+	assert.Equal(t, original, &fromArray)
+}
@@ -0,0 +1,186 @@
+package taapi
+
+import (
+	"fmt"
+	"math"
+)
+
+// HeikinAshi converts candles to Heikin-Ashi candles. Timestamps and volumes
+// are kept; the first open is the midpoint of the first open and close.
+func HeikinAshi(candles []*Candle) []*Candle {
+	result := make([]*Candle, len(candles))
+
+	for i, c := range candles {
+		haClose := (c.Open + c.High + c.Low + c.Close) / 4
+		haOpen := (c.Open + c.Close) / 2
+		if i > 0 {
+			prev := result[i-1]
+			haOpen = (prev.Open + prev.Close) / 2
+		}
+
+		result[i] = &Candle{
+			Timestamp: c.Timestamp,
+			Open:      haOpen,
+			High:      math.Max(c.High, math.Max(haOpen, haClose)),
+			Low:       math.Min(c.Low, math.Min(haOpen, haClose)),
+			Close:     haClose,
+			Volume:    c.Volume,
+		}
+	}
+
+	return result
+}
+
+// RenkoOptions configures Renko. Exactly one of BoxSize or ATRPeriod is used:
+// a zero BoxSize derives the box size from the final ATR over ATRPeriod.
+type RenkoOptions struct {
+	BoxSize   float64
+	ATRPeriod int
+}
+
+// Renko converts candles to close-based Renko bricks. A brick is added each
+// time the close moves one box beyond the last brick; reversals need a move
+// of one box beyond the opposite edge. Each brick carries the timestamp of
+// the candle that completed it, so consecutive bricks may share timestamps.
+// Volume traded since the previous brick is assigned to the first brick
+// formed by a candle.
+func Renko(candles []*Candle, opts RenkoOptions) ([]*Candle, error) {
+	box := opts.BoxSize
+	if box <= 0 {
+		if opts.ATRPeriod <= 0 {
+			return nil, InvalidArgumentError("renko requires a box size or an ATR period")
+		}
+		atr, err := averageTrueRange(candles, opts.ATRPeriod)
+		if err != nil {
+			return nil, err
+		}
+		box = atr
+	}
+	if box <= 0 || len(candles) == 0 {
+		return nil, nil
+	}
+
+	var bricks []*Candle
+	top, bottom := candles[0].Close, candles[0].Close
+	volume := 0.0
+
+	for _, c := range candles[1:] {
+		volume += c.Volume
+		addBrick := func(open, close float64) {
+			bricks = append(bricks, &Candle{
+				Timestamp: c.Timestamp,
+				Open:      open,
+				High:      math.Max(open, close),
+				Low:       math.Min(open, close),
+				Close:     close,
+				Volume:    volume,
+			})
+			volume = 0
+		}
+
+		for c.Close >= top+box {
+			addBrick(top, top+box)
+			bottom, top = top, top+box
+		}
+		for c.Close <= bottom-box {
+			addBrick(bottom, bottom-box)
+			top, bottom = bottom, bottom-box
+		}
+	}
+
+	return bricks, nil
+}
+
+// averageTrueRange returns Wilder's ATR over period at the last candle
+func averageTrueRange(candles []*Candle, period int) (float64, error) {
+	if len(candles) <= period {
+		return 0, InvalidArgumentError(fmt.Sprintf("ATR(%d) needs more than %d candles", period, period))
+	}
+
+	trueRange := func(i int) float64 {
+		c, prevClose := candles[i], candles[i-1].Close
+		return math.Max(c.High-c.Low, math.Max(math.Abs(c.High-prevClose), math.Abs(c.Low-prevClose)))
+	}
+
+	atr := 0.0
+	for i := 1; i <= period; i++ {
+		atr += trueRange(i)
+	}
+	atr /= float64(period)
+
+	for i := period + 1; i < len(candles); i++ {
+		atr = (atr*float64(period-1) + trueRange(i)) / float64(period)
+	}
+
+	return atr, nil
+}
+
+// RangeBars converts candles to range bars whose high-low span equals
+// rangeSize. Intra-candle movement is approximated as open, low, high, close
+// for rising candles and open, high, low, close for falling ones. Bars carry
+// the timestamp of the candle they opened in; a candle's volume goes to the
+// bar that is open when the candle ends. The last bar is still forming and
+// usually spans less than rangeSize.
+func RangeBars(candles []*Candle, rangeSize float64) ([]*Candle, error) {
+	if rangeSize <= 0 {
+		return nil, InvalidArgumentError("range size must be positive")
+	}
+	if len(candles) == 0 {
+		return nil, nil
+	}
+
+	var bars []*Candle
+	open := func(ts int64, price float64) *Candle {
+		return &Candle{Timestamp: ts, Open: price, High: price, Low: price, Close: price}
+	}
+	current := open(candles[0].Timestamp, candles[0].Open)
+
+	for _, c := range candles {
+		path := []float64{c.Open, c.High, c.Low, c.Close}
+		if c.Close >= c.Open {
+			path = []float64{c.Open, c.Low, c.High, c.Close}
+		}
+
+		for _, price := range path {
+			for {
+				if price > current.Low+rangeSize {
+					current.High = current.Low + rangeSize
+					current.Close = current.High
+				} else if price < current.High-rangeSize {
+					current.Low = current.High - rangeSize
+					current.Close = current.Low
+				} else {
+					current.High = math.Max(current.High, price)
+					current.Low = math.Min(current.Low, price)
+					current.Close = price
+					break
+				}
+				bars = append(bars, current)
+				current = open(c.Timestamp, current.Close)
+			}
+		}
+		current.Volume += c.Volume
+	}
+
+	return append(bars, current), nil
+}
+
+// LogPrice converts open, high, low and close to their natural logarithm.
+// It fails on non-positive prices.
+func LogPrice(candles []*Candle) ([]*Candle, error) {
+	result := make([]*Candle, len(candles))
+	for i, c := range candles {
+		if c.Open <= 0 || c.High <= 0 || c.Low <= 0 || c.Close <= 0 {
+			return nil, InvalidArgumentError(fmt.Sprintf("candle %d has a non-positive price", i))
+		}
+		result[i] = &Candle{
+			Timestamp: c.Timestamp,
+			Open:      math.Log(c.Open),
+			High:      math.Log(c.High),
+			Low:       math.Log(c.Low),
+			Close:     math.Log(c.Close),
+			Volume:    c.Volume,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+package taapi
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ohlc(ts int64, open, high, low, close float64) *Candle {
+	return &Candle{Timestamp: ts, Open: open, High: high, Low: low, Close: close, Volume: 1}
+}
+
+func TestHeikinAshi(t *testing.T) {
+	candles := []*Candle{
+		ohlc(1, 10, 12, 9, 11),
+		ohlc(2, 11, 13, 10, 12),
+		ohlc(3, 12, 12.5, 10, 10.5),
+	}
+
+	expected := []*Candle{
+		{Timestamp: 1, Open: 10.5, High: 12, Low: 9, Close: 10.5, Volume: 1},
+		{Timestamp: 2, Open: 10.5, High: 13, Low: 10, Close: 11.5, Volume: 1},
+		{Timestamp: 3, Open: 11, High: 12.5, Low: 10, Close: 11.25, Volume: 1},
+	}
+	assert.Equal(t, expected, HeikinAshi(candles))
+	assert.Empty(t, HeikinAshi(nil))
+}
+
+func TestRenkoBoxSize(t *testing.T) {
+	closes := []float64{10, 11.2, 12.5, 11.9, 10.4, 9.9}
+	candles := make([]*Candle, len(closes))
+	for i, c := range closes {
+		candles[i] = ohlc(int64(i), c, c, c, c)
+	}
+
+	bricks, err := Renko(candles, RenkoOptions{BoxSize: 1})
+	require.NoError(t, err)
+
+	expected := []*Candle{
+		{Timestamp: 1, Open: 10, High: 11, Low: 10, Close: 11, Volume: 1},
+		{Timestamp: 2, Open: 11, High: 12, Low: 11, Close: 12, Volume: 1},
+		{Timestamp: 5, Open: 11, High: 11, Low: 10, Close: 10, Volume: 3},
+	}
+	assert.Equal(t, expected, bricks)
+}
+
+func TestRenkoATR(t *testing.T) {
+	candles := make([]*Candle, 20)
+	for i := range candles {
+		candles[i] = ohlc(int64(i), 100, 101, 99, 100)
+	}
+	candles = append(candles, ohlc(20, 100, 105, 100, 104.5))
+
+	atr, err := averageTrueRange(candles[:20], 14)
+	require.NoError(t, err)
+	assert.InDelta(t, 2.0, atr, 1e-9)
+
+	bricks, err := Renko(candles, RenkoOptions{ATRPeriod: 14})
+	require.NoError(t, err)
+	// Final ATR is (13*2 + 5) / 14; the last candle moves just over two boxes
+	require.Len(t, bricks, 2)
+	assert.InDelta(t, 31.0/14, bricks[0].Close-bricks[0].Open, 1e-9)
+	assert.Equal(t, 0.0, bricks[1].Volume)
+
+	_, err = Renko(candles[:5], RenkoOptions{ATRPeriod: 14})
+	assert.ErrorIs(t, err, ErrInvalidParams)
+
+	_, err = Renko(candles, RenkoOptions{})
+	assert.ErrorIs(t, err, ErrInvalidParams)
+}
+
+func TestRangeBars(t *testing.T) {
+	candles := []*Candle{
+		ohlc(1, 10, 10, 10, 10),
+		ohlc(2, 10, 13, 10, 13),
+		ohlc(3, 13, 13, 8, 8),
+	}
+
+	bars, err := RangeBars(candles, 2)
+	require.NoError(t, err)
+
+	expected := []*Candle{
+		{Timestamp: 1, Open: 10, High: 12, Low: 10, Close: 12, Volume: 1},
+		{Timestamp: 2, Open: 12, High: 13, Low: 11, Close: 11, Volume: 1},
+		{Timestamp: 3, Open: 11, High: 11, Low: 9, Close: 9, Volume: 0},
+		{Timestamp: 3, Open: 9, High: 9, Low: 8, Close: 8, Volume: 1},
+	}
+	assert.Equal(t, expected, bars)
+
+	_, err = RangeBars(candles, 0)
+	assert.ErrorIs(t, err, ErrInvalidParams)
+}
+
+func TestLogPrice(t *testing.T) {
+	candles := []*Candle{ohlc(1, 1, math.E, 1, math.E)}
+
+	result, err := LogPrice(candles)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, result[0].Open)
+	assert.InDelta(t, 1.0, result[0].High, 1e-12)
+	assert.Equal(t, 1.0, result[0].Volume)
+
+	_, err = LogPrice([]*Candle{ohlc(1, 0, 1, 0, 1)})
+	assert.ErrorIs(t, err, ErrInvalidParams)
+}
+
+func TestTransformsFeedManualBuilder(t *testing.T) {
+	client := NewClient("test_secret")
+	builder := client.Manual(IndicatorEMA).WithCandleStructs(HeikinAshi(sampleCandles()))
+	assert.Len(t, builder.candles, 3)
+}
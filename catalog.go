@@ -0,0 +1,252 @@
+package taapi
+
+//go:generate go run ./internal/gencatalog -in internal/gencatalog/indicators.txt -out catalog_gen.go -params params_gen.go
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// IndicatorCategory groups indicators by what they measure
+type IndicatorCategory string
+
+const (
+	CategoryMomentum   IndicatorCategory = "momentum"
+	CategoryTrend      IndicatorCategory = "trend"
+	CategoryOverlap    IndicatorCategory = "overlap"
+	CategoryVolatility IndicatorCategory = "volatility"
+	CategoryVolume     IndicatorCategory = "volume"
+	CategoryCycle      IndicatorCategory = "cycle"
+	CategoryPrice      IndicatorCategory = "price"
+	CategorySupport    IndicatorCategory = "support"
+	CategoryStatistic  IndicatorCategory = "statistic"
+	CategoryMath       IndicatorCategory = "math"
+	CategoryPattern    IndicatorCategory = "pattern"
+)
+
+// ParamType is the value type of an indicator parameter
+type ParamType string
+
+const (
+	ParamInt    ParamType = "int"
+	ParamFloat  ParamType = "float"
+	ParamString ParamType = "string"
+	ParamBool   ParamType = "bool"
+)
+
+// ParamSpec describes a single indicator parameter
+type ParamSpec struct {
+	Name    string
+	Type    ParamType
+	Default interface{}
+	// Min and Max bound numeric parameters; nil means unbounded
+	Min *float64
+	Max *float64
+	// Enum lists the accepted values of string parameters, if restricted
+	Enum []string
+}
+
+// IndicatorInfo describes an indicator supported by taapi.io
+type IndicatorInfo struct {
+	Indicator Indicator
+	Name      string
+	Category  IndicatorCategory
+	Params    []ParamSpec
+	// Outputs lists the fields of the indicator's response
+	Outputs []string
+	// Backtracks reports whether backtrack and backtracks are supported
+	Backtracks bool
+	// Manual reports whether the indicator can be used with manual candles
+	Manual bool
+}
+
+// commonParams are accepted by every indicator in addition to its own
+var commonParams = map[string]ParamSpec{
+	"backtrack":          {Name: "backtrack", Type: ParamInt, Min: bound(0)},
+	"backtracks":         {Name: "backtracks", Type: ParamInt, Min: bound(1)},
+	"addResultTimestamp": {Name: "addResultTimestamp", Type: ParamBool},
+	"gaps":               {Name: "gaps", Type: ParamBool},
+	"chart":              {Name: "chart", Type: ParamString, Enum: []string{"candles", "heikinashi"}},
+	"id":                 {Name: "id", Type: ParamString},
+}
+
+// bound returns a pointer to a parameter bound
+func bound(v float64) *float64 {
+	return &v
+}
+
+// LookupIndicator returns the catalog entry of an indicator
+func LookupIndicator(indicator Indicator) (*IndicatorInfo, bool) {
+	info, ok := indicatorCatalog[indicator]
+	return info, ok
+}
+
+// Info returns the catalog entry of the indicator, or nil if unknown
+func (i Indicator) Info() *IndicatorInfo {
+	return indicatorCatalog[i]
+}
+
+// Indicators returns every cataloged indicator sorted by identifier
+func Indicators() []*IndicatorInfo {
+	result := make([]*IndicatorInfo, 0, len(indicatorCatalog))
+	for _, info := range indicatorCatalog {
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Indicator < result[j].Indicator
+	})
+	return result
+}
+
+// IndicatorsByCategory returns the cataloged indicators of a category sorted
+// by identifier
+func IndicatorsByCategory(category IndicatorCategory) []*IndicatorInfo {
+	var result []*IndicatorInfo
+	for _, info := range Indicators() {
+		if info.Category == category {
+			result = append(result, info)
+		}
+	}
+	return result
+}
+
+// Param returns the spec of a named parameter
+func (info *IndicatorInfo) Param(name string) (ParamSpec, bool) {
+	for _, param := range info.Params {
+		if param.Name == name {
+			return param, true
+		}
+	}
+	return ParamSpec{}, false
+}
+
+// Defaults returns the default value of every parameter
+func (info *IndicatorInfo) Defaults() map[string]interface{} {
+	defaults := make(map[string]interface{}, len(info.Params))
+	for _, param := range info.Params {
+		if param.Default != nil {
+			defaults[param.Name] = param.Default
+		}
+	}
+	return defaults
+}
+
+// ValidateParams checks the type and range of every known parameter.
+// Unknown parameters are passed through to the API unchecked.
+func (info *IndicatorInfo) ValidateParams(params map[string]interface{}) error {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		spec, ok := info.Param(name)
+		if !ok {
+			if spec, ok = commonParams[name]; !ok {
+				continue
+			}
+		}
+		if !info.Backtracks && (name == "backtrack" || name == "backtracks") {
+			return InvalidArgumentError(fmt.Sprintf("%s does not support %s", info.Indicator, name))
+		}
+		if err := spec.Validate(params[name]); err != nil {
+			return InvalidArgumentError(fmt.Sprintf("%s: %v", info.Indicator, err))
+		}
+	}
+	return nil
+}
+
+// Validate checks a value against the parameter spec
+func (p ParamSpec) Validate(value interface{}) error {
+	switch p.Type {
+	case ParamInt, ParamFloat:
+		f, ok := toFloat(value)
+		if !ok {
+			return fmt.Errorf("parameter %s must be a number, got %T", p.Name, value)
+		}
+		if p.Type == ParamInt && f != math.Trunc(f) {
+			return fmt.Errorf("parameter %s must be an integer, got %v", p.Name, value)
+		}
+		if p.Min != nil && f < *p.Min {
+			return fmt.Errorf("parameter %s must be at least %v, got %v", p.Name, *p.Min, value)
+		}
+		if p.Max != nil && f > *p.Max {
+			return fmt.Errorf("parameter %s must be at most %v, got %v", p.Name, *p.Max, value)
+		}
+	case ParamString:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("parameter %s must be a string, got %T", p.Name, value)
+		}
+		if len(p.Enum) > 0 {
+			for _, allowed := range p.Enum {
+				if s == allowed {
+					return nil
+				}
+			}
+			return fmt.Errorf("parameter %s must be one of %v, got %q", p.Name, p.Enum, s)
+		}
+	case ParamBool:
+		switch v := value.(type) {
+		case bool:
+		case string:
+			if _, err := strconv.ParseBool(v); err != nil {
+				return fmt.Errorf("parameter %s must be a boolean, got %q", p.Name, v)
+			}
+		default:
+			return fmt.Errorf("parameter %s must be a boolean, got %T", p.Name, value)
+		}
+	}
+	return nil
+}
+
+// toFloat converts numeric values, including numeric strings, to float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// validateIndicatorParams validates params for a cataloged indicator.
+// Indicators missing from the catalog are passed through unchecked so new
+// API endpoints remain usable.
+func validateIndicatorParams(indicator string, params map[string]interface{}, manual bool) error {
+	info, ok := LookupIndicator(Indicator(indicator))
+	if !ok {
+		return nil
+	}
+	if manual && !info.Manual {
+		return InvalidArgumentError(fmt.Sprintf("%s does not support manual candles", indicator))
+	}
+	return info.ValidateParams(params)
+}
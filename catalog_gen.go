@@ -0,0 +1,2096 @@
+// Code generated by gencatalog from internal/gencatalog/indicators.txt. DO NOT EDIT.
+
+package taapi
+
+// indicatorCatalog holds every indicator supported by taapi.io
+var indicatorCatalog = map[Indicator]*IndicatorInfo{
+	"ao": {
+		Indicator: "ao",
+		Name:      "Awesome Oscillator",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "fastPeriod", Type: ParamInt, Default: 5, Min: bound(1)},
+			{Name: "slowPeriod", Type: ParamInt, Default: 34, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"apo": {
+		Indicator: "apo",
+		Name:      "Absolute Price Oscillator",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInFastPeriod", Type: ParamInt, Default: 12, Min: bound(2)},
+			{Name: "optInSlowPeriod", Type: ParamInt, Default: 26, Min: bound(2)},
+			{Name: "optInMAType", Type: ParamInt, Default: 0, Min: bound(0), Max: bound(8)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"aroon": {
+		Indicator: "aroon",
+		Name:      "Aroon",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"valueAroonDown", "valueAroonUp"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"aroonosc": {
+		Indicator: "aroonosc",
+		Name:      "Aroon Oscillator",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"bop": {
+		Indicator:  "bop",
+		Name:       "Balance Of Power",
+		Category:   CategoryMomentum,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"cci": {
+		Indicator: "cci",
+		Name:      "Commodity Channel Index",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"cmo": {
+		Indicator: "cmo",
+		Name:      "Chande Momentum Oscillator",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"coppockcurve": {
+		Indicator: "coppockcurve",
+		Name:      "Coppock Curve",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "wmaPeriod", Type: ParamInt, Default: 10, Min: bound(1)},
+			{Name: "longRoCPeriod", Type: ParamInt, Default: 14, Min: bound(1)},
+			{Name: "shortRoCPeriod", Type: ParamInt, Default: 11, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"dmi": {
+		Indicator: "dmi",
+		Name:      "Directional Movement Index",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(1)},
+		},
+		Outputs:    []string{"adx", "pdi", "mdi"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"dx": {
+		Indicator: "dx",
+		Name:      "Directional Movement Index (DX)",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"fisher": {
+		Indicator: "fisher",
+		Name:      "Fisher Transform",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 9, Min: bound(1)},
+		},
+		Outputs:    []string{"fisher", "trigger"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"kdj": {
+		Indicator: "kdj",
+		Name:      "KDJ",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 9, Min: bound(1)},
+			{Name: "signal", Type: ParamInt, Default: 3, Min: bound(1)},
+		},
+		Outputs:    []string{"valueK", "valueD", "valueJ"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"macd": {
+		Indicator: "macd",
+		Name:      "Moving Average Convergence Divergence",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInFastPeriod", Type: ParamInt, Default: 12, Min: bound(2)},
+			{Name: "optInSlowPeriod", Type: ParamInt, Default: 26, Min: bound(2)},
+			{Name: "optInSignalPeriod", Type: ParamInt, Default: 9, Min: bound(1)},
+		},
+		Outputs:    []string{"valueMACD", "valueMACDSignal", "valueMACDHist"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"macdext": {
+		Indicator: "macdext",
+		Name:      "MACD with controllable MA type",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInFastPeriod", Type: ParamInt, Default: 12, Min: bound(2)},
+			{Name: "optInFastMAType", Type: ParamInt, Default: 0, Min: bound(0), Max: bound(8)},
+			{Name: "optInSlowPeriod", Type: ParamInt, Default: 26, Min: bound(2)},
+			{Name: "optInSlowMAType", Type: ParamInt, Default: 0, Min: bound(0), Max: bound(8)},
+			{Name: "optInSignalPeriod", Type: ParamInt, Default: 9, Min: bound(1)},
+			{Name: "optInSignalMAType", Type: ParamInt, Default: 0, Min: bound(0), Max: bound(8)},
+		},
+		Outputs:    []string{"valueMACD", "valueMACDSignal", "valueMACDHist"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"macdfix": {
+		Indicator: "macdfix",
+		Name:      "MACD Fix 12/26",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInSignalPeriod", Type: ParamInt, Default: 9, Min: bound(1)},
+		},
+		Outputs:    []string{"valueMACD", "valueMACDSignal", "valueMACDHist"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"mfi": {
+		Indicator: "mfi",
+		Name:      "Money Flow Index",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"minus_di": {
+		Indicator: "minus_di",
+		Name:      "Minus Directional Indicator",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"minus_dm": {
+		Indicator: "minus_dm",
+		Name:      "Minus Directional Movement",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"mom": {
+		Indicator: "mom",
+		Name:      "Momentum",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 10, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"plus_di": {
+		Indicator: "plus_di",
+		Name:      "Plus Directional Indicator",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"plus_dm": {
+		Indicator: "plus_dm",
+		Name:      "Plus Directional Movement",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ppo": {
+		Indicator: "ppo",
+		Name:      "Percentage Price Oscillator",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInFastPeriod", Type: ParamInt, Default: 12, Min: bound(2)},
+			{Name: "optInSlowPeriod", Type: ParamInt, Default: 26, Min: bound(2)},
+			{Name: "optInMAType", Type: ParamInt, Default: 0, Min: bound(0), Max: bound(8)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"roc": {
+		Indicator: "roc",
+		Name:      "Rate of Change",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 10, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"rocp": {
+		Indicator: "rocp",
+		Name:      "Rate of Change Percentage",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 10, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"rocr": {
+		Indicator: "rocr",
+		Name:      "Rate of Change Ratio",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 10, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"rocr100": {
+		Indicator: "rocr100",
+		Name:      "Rate of Change Ratio 100 Scale",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 10, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"rsi": {
+		Indicator: "rsi",
+		Name:      "Relative Strength Index",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"rvgi": {
+		Indicator: "rvgi",
+		Name:      "Relative Vigor Index",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 10, Min: bound(1)},
+		},
+		Outputs:    []string{"valueRVGI", "valueSignal"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"squeeze": {
+		Indicator:  "squeeze",
+		Name:       "Squeeze Momentum",
+		Category:   CategoryMomentum,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"stc": {
+		Indicator: "stc",
+		Name:      "Schaff Trend Cycle",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "cycleLength", Type: ParamInt, Default: 10, Min: bound(1)},
+			{Name: "fastLength", Type: ParamInt, Default: 23, Min: bound(1)},
+			{Name: "slowLength", Type: ParamInt, Default: 50, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"stoch": {
+		Indicator: "stoch",
+		Name:      "Stochastic",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "kPeriod", Type: ParamInt, Default: 14, Min: bound(1)},
+			{Name: "kSmooth", Type: ParamInt, Default: 3, Min: bound(1)},
+			{Name: "dPeriod", Type: ParamInt, Default: 3, Min: bound(1)},
+		},
+		Outputs:    []string{"valueK", "valueD"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"stochf": {
+		Indicator: "stochf",
+		Name:      "Stochastic Fast",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInFastK_Period", Type: ParamInt, Default: 5, Min: bound(1)},
+			{Name: "optInFastD_Period", Type: ParamInt, Default: 3, Min: bound(1)},
+			{Name: "optInFastD_MAType", Type: ParamInt, Default: 0, Min: bound(0), Max: bound(8)},
+		},
+		Outputs:    []string{"valueFastK", "valueFastD"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"stochrsi": {
+		Indicator: "stochrsi",
+		Name:      "Stochastic RSI",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "kPeriod", Type: ParamInt, Default: 3, Min: bound(1)},
+			{Name: "dPeriod", Type: ParamInt, Default: 3, Min: bound(1)},
+			{Name: "rsiPeriod", Type: ParamInt, Default: 14, Min: bound(2)},
+			{Name: "stochasticPeriod", Type: ParamInt, Default: 14, Min: bound(1)},
+		},
+		Outputs:    []string{"valueFastK", "valueFastD"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"trix": {
+		Indicator: "trix",
+		Name:      "Triple Exponential Average",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 30, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"tsi": {
+		Indicator: "tsi",
+		Name:      "True Strength Index",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "longPeriod", Type: ParamInt, Default: 25, Min: bound(1)},
+			{Name: "shortPeriod", Type: ParamInt, Default: 13, Min: bound(1)},
+			{Name: "signalPeriod", Type: ParamInt, Default: 13, Min: bound(1)},
+		},
+		Outputs:    []string{"value", "signal"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"uo": {
+		Indicator: "uo",
+		Name:      "Ultimate Oscillator",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period1", Type: ParamInt, Default: 7, Min: bound(1)},
+			{Name: "period2", Type: ParamInt, Default: 14, Min: bound(1)},
+			{Name: "period3", Type: ParamInt, Default: 28, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ultosc": {
+		Indicator: "ultosc",
+		Name:      "Ultimate Oscillator (TA-Lib)",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod1", Type: ParamInt, Default: 7, Min: bound(1)},
+			{Name: "optInTimePeriod2", Type: ParamInt, Default: 14, Min: bound(1)},
+			{Name: "optInTimePeriod3", Type: ParamInt, Default: 28, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"williams": {
+		Indicator: "williams",
+		Name:      "Williams %R",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"willr": {
+		Indicator: "willr",
+		Name:      "Williams %R (TA-Lib)",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"wavetrend": {
+		Indicator: "wavetrend",
+		Name:      "WaveTrend Oscillator",
+		Category:  CategoryMomentum,
+		Params: []ParamSpec{
+			{Name: "channelLength", Type: ParamInt, Default: 10, Min: bound(1)},
+			{Name: "averageLength", Type: ParamInt, Default: 21, Min: bound(1)},
+		},
+		Outputs:    []string{"wt1", "wt2"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"adx": {
+		Indicator: "adx",
+		Name:      "Average Directional Index",
+		Category:  CategoryTrend,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"adxr": {
+		Indicator: "adxr",
+		Name:      "Average Directional Movement Rating",
+		Category:  CategoryTrend,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"chop": {
+		Indicator: "chop",
+		Name:      "Choppiness Index",
+		Category:  CategoryTrend,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"dm": {
+		Indicator: "dm",
+		Name:      "Directional Movement",
+		Category:  CategoryTrend,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(1)},
+		},
+		Outputs:    []string{"plus_dm", "minus_dm"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ichimoku": {
+		Indicator: "ichimoku",
+		Name:      "Ichimoku Cloud",
+		Category:  CategoryTrend,
+		Params: []ParamSpec{
+			{Name: "conversionPeriod", Type: ParamInt, Default: 9, Min: bound(1)},
+			{Name: "basePeriod", Type: ParamInt, Default: 26, Min: bound(1)},
+			{Name: "spanPeriod", Type: ParamInt, Default: 52, Min: bound(1)},
+			{Name: "displacement", Type: ParamInt, Default: 26, Min: bound(0)},
+		},
+		Outputs:    []string{"conversion", "base", "spanA", "spanB", "currentSpanA", "currentSpanB", "laggingSpanA", "laggingSpanB"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"psar": {
+		Indicator: "psar",
+		Name:      "Parabolic SAR",
+		Category:  CategoryTrend,
+		Params: []ParamSpec{
+			{Name: "start", Type: ParamFloat, Default: float64(0.02), Min: bound(0)},
+			{Name: "increment", Type: ParamFloat, Default: float64(0.02), Min: bound(0)},
+			{Name: "maximum", Type: ParamFloat, Default: float64(0.2), Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"sar": {
+		Indicator: "sar",
+		Name:      "Parabolic SAR (TA-Lib)",
+		Category:  CategoryTrend,
+		Params: []ParamSpec{
+			{Name: "optInAcceleration", Type: ParamFloat, Default: float64(0.02), Min: bound(0)},
+			{Name: "optInMaximum", Type: ParamFloat, Default: float64(0.2), Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"sarext": {
+		Indicator: "sarext",
+		Name:      "Parabolic SAR Extended",
+		Category:  CategoryTrend,
+		Params: []ParamSpec{
+			{Name: "optInStartValue", Type: ParamFloat, Default: float64(0)},
+			{Name: "optInOffsetOnReverse", Type: ParamFloat, Default: float64(0), Min: bound(0)},
+			{Name: "optInAccelerationInitLong", Type: ParamFloat, Default: float64(0.02), Min: bound(0)},
+			{Name: "optInAccelerationLong", Type: ParamFloat, Default: float64(0.02), Min: bound(0)},
+			{Name: "optInAccelerationMaxLong", Type: ParamFloat, Default: float64(0.2), Min: bound(0)},
+			{Name: "optInAccelerationInitShort", Type: ParamFloat, Default: float64(0.02), Min: bound(0)},
+			{Name: "optInAccelerationShort", Type: ParamFloat, Default: float64(0.02), Min: bound(0)},
+			{Name: "optInAccelerationMaxShort", Type: ParamFloat, Default: float64(0.2), Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"supertrend": {
+		Indicator: "supertrend",
+		Name:      "Supertrend",
+		Category:  CategoryTrend,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 7, Min: bound(1)},
+			{Name: "multiplier", Type: ParamFloat, Default: float64(3), Min: bound(0)},
+		},
+		Outputs:    []string{"value", "valueAdvice"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"tdsequential": {
+		Indicator:  "tdsequential",
+		Name:       "TD Sequential",
+		Category:   CategoryTrend,
+		Outputs:    []string{"buySetupIndex", "sellSetupIndex", "buyCoundownIndex", "sellCoundownIndex"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"vi": {
+		Indicator: "vi",
+		Name:      "Vortex Indicator",
+		Category:  CategoryTrend,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"plus", "minus"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"vosc": {
+		Indicator: "vosc",
+		Name:      "Volume Oscillator",
+		Category:  CategoryTrend,
+		Params: []ParamSpec{
+			{Name: "shortPeriod", Type: ParamInt, Default: 5, Min: bound(1)},
+			{Name: "longPeriod", Type: ParamInt, Default: 10, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"accbands": {
+		Indicator: "accbands",
+		Name:      "Acceleration Bands",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(2)},
+		},
+		Outputs:    []string{"valueUpperBand", "valueMiddleBand", "valueLowerBand"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"alma": {
+		Indicator: "alma",
+		Name:      "Arnaud Legoux Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 9, Min: bound(1)},
+			{Name: "offset", Type: ParamFloat, Default: float64(0.85), Min: bound(0), Max: bound(1)},
+			{Name: "sigma", Type: ParamFloat, Default: float64(6), Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"bbands": {
+		Indicator: "bbands",
+		Name:      "Bollinger Bands",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(2)},
+			{Name: "stddev", Type: ParamFloat, Default: float64(2), Min: bound(0)},
+			{Name: "maType", Type: ParamInt, Default: 0, Min: bound(0), Max: bound(8)},
+		},
+		Outputs:    []string{"valueUpperBand", "valueMiddleBand", "valueLowerBand"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"bbands2": {
+		Indicator: "bbands2",
+		Name:      "Bollinger Bands (TA-Lib)",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 5, Min: bound(2)},
+			{Name: "optInNbDevUp", Type: ParamFloat, Default: float64(2), Min: bound(0)},
+			{Name: "optInNbDevDn", Type: ParamFloat, Default: float64(2), Min: bound(0)},
+			{Name: "optInMAType", Type: ParamInt, Default: 0, Min: bound(0), Max: bound(8)},
+		},
+		Outputs:    []string{"valueUpperBand", "valueMiddleBand", "valueLowerBand"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"bbp": {
+		Indicator: "bbp",
+		Name:      "Bollinger Bands %B",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(2)},
+			{Name: "stddev", Type: ParamFloat, Default: float64(2), Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"bbw": {
+		Indicator: "bbw",
+		Name:      "Bollinger Bands Width",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(2)},
+			{Name: "stddev", Type: ParamFloat, Default: float64(2), Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"dema": {
+		Indicator: "dema",
+		Name:      "Double Exponential Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 30, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"donchian": {
+		Indicator: "donchian",
+		Name:      "Donchian Channels",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(1)},
+		},
+		Outputs:    []string{"upper", "basis", "lower"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"donchianchannels": {
+		Indicator: "donchianchannels",
+		Name:      "Donchian Channels (alias)",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(1)},
+		},
+		Outputs:    []string{"upper", "basis", "lower"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ema": {
+		Indicator: "ema",
+		Name:      "Exponential Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 30, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"hma": {
+		Indicator: "hma",
+		Name:      "Hull Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 9, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ht_trendline": {
+		Indicator:  "ht_trendline",
+		Name:       "Hilbert Transform Instantaneous Trendline",
+		Category:   CategoryOverlap,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"kama": {
+		Indicator: "kama",
+		Name:      "Kaufman Adaptive Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 30, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"keltner": {
+		Indicator: "keltner",
+		Name:      "Keltner Channels",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(1)},
+			{Name: "multiplier", Type: ParamFloat, Default: float64(2), Min: bound(0)},
+			{Name: "atrLength", Type: ParamInt, Default: 10, Min: bound(1)},
+		},
+		Outputs:    []string{"upper", "middle", "lower"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"keltnerchannels": {
+		Indicator: "keltnerchannels",
+		Name:      "Keltner Channels (alias)",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(1)},
+			{Name: "multiplier", Type: ParamFloat, Default: float64(2), Min: bound(0)},
+			{Name: "atrLength", Type: ParamInt, Default: 10, Min: bound(1)},
+		},
+		Outputs:    []string{"upper", "middle", "lower"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"lsma": {
+		Indicator: "lsma",
+		Name:      "Least Squares Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 25, Min: bound(2)},
+			{Name: "offset", Type: ParamInt, Default: 0, Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ma": {
+		Indicator: "ma",
+		Name:      "Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 30, Min: bound(1)},
+			{Name: "optInMAType", Type: ParamInt, Default: 0, Min: bound(0), Max: bound(8)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"mama": {
+		Indicator: "mama",
+		Name:      "MESA Adaptive Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "optInFastLimit", Type: ParamFloat, Default: float64(0.5), Min: bound(0.01), Max: bound(0.99)},
+			{Name: "optInSlowLimit", Type: ParamFloat, Default: float64(0.05), Min: bound(0.01), Max: bound(0.99)},
+		},
+		Outputs:    []string{"valueMAMA", "valueFAMA"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"mavp": {
+		Indicator: "mavp",
+		Name:      "Moving Average with Variable Period",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "optInMinPeriod", Type: ParamInt, Default: 2, Min: bound(2)},
+			{Name: "optInMaxPeriod", Type: ParamInt, Default: 30, Min: bound(2)},
+			{Name: "optInMAType", Type: ParamInt, Default: 0, Min: bound(0), Max: bound(8)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"mcginley": {
+		Indicator: "mcginley",
+		Name:      "McGinley Dynamic",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"midpoint": {
+		Indicator: "midpoint",
+		Name:      "MidPoint over period",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"midprice": {
+		Indicator: "midprice",
+		Name:      "Midpoint Price over period",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"rma": {
+		Indicator: "rma",
+		Name:      "Relative Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"sma": {
+		Indicator: "sma",
+		Name:      "Simple Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 30, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"smma": {
+		Indicator: "smma",
+		Name:      "Smoothed Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 7, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"t3": {
+		Indicator: "t3",
+		Name:      "Triple Exponential Moving Average (T3)",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 5, Min: bound(2)},
+			{Name: "vfactor", Type: ParamFloat, Default: float64(0.7), Min: bound(0), Max: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"tema": {
+		Indicator: "tema",
+		Name:      "Triple Exponential Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 30, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"trima": {
+		Indicator: "trima",
+		Name:      "Triangular Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 30, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"vwap": {
+		Indicator: "vwap",
+		Name:      "Volume Weighted Average Price",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "anchorPeriod", Type: ParamString, Default: "session", Enum: []string{"session", "week", "month", "year"}},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"vwma": {
+		Indicator: "vwma",
+		Name:      "Volume Weighted Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"wma": {
+		Indicator: "wma",
+		Name:      "Weighted Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 30, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"zlema": {
+		Indicator: "zlema",
+		Name:      "Zero Lag Exponential Moving Average",
+		Category:  CategoryOverlap,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"atr": {
+		Indicator: "atr",
+		Name:      "Average True Range",
+		Category:  CategoryVolatility,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"natr": {
+		Indicator: "natr",
+		Name:      "Normalized Average True Range",
+		Category:  CategoryVolatility,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"stddev": {
+		Indicator: "stddev",
+		Name:      "Standard Deviation",
+		Category:  CategoryVolatility,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 5, Min: bound(2)},
+			{Name: "stddev", Type: ParamFloat, Default: float64(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"trange": {
+		Indicator:  "trange",
+		Name:       "True Range",
+		Category:   CategoryVolatility,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"volatility": {
+		Indicator: "volatility",
+		Name:      "Historical Volatility",
+		Category:  CategoryVolatility,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ad": {
+		Indicator:  "ad",
+		Name:       "Chaikin A/D Line",
+		Category:   CategoryVolume,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"adosc": {
+		Indicator: "adosc",
+		Name:      "Chaikin A/D Oscillator",
+		Category:  CategoryVolume,
+		Params: []ParamSpec{
+			{Name: "optInFastPeriod", Type: ParamInt, Default: 3, Min: bound(2)},
+			{Name: "optInSlowPeriod", Type: ParamInt, Default: 10, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"cmf": {
+		Indicator: "cmf",
+		Name:      "Chaikin Money Flow",
+		Category:  CategoryVolume,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"efi": {
+		Indicator: "efi",
+		Name:      "Elder Force Index",
+		Category:  CategoryVolume,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 13, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"eom": {
+		Indicator: "eom",
+		Name:      "Ease of Movement",
+		Category:  CategoryVolume,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 14, Min: bound(1)},
+			{Name: "divisor", Type: ParamInt, Default: 10000, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"nvi": {
+		Indicator:  "nvi",
+		Name:       "Negative Volume Index",
+		Category:   CategoryVolume,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"obv": {
+		Indicator:  "obv",
+		Name:       "On Balance Volume",
+		Category:   CategoryVolume,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"pvi": {
+		Indicator:  "pvi",
+		Name:       "Positive Volume Index",
+		Category:   CategoryVolume,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"pvt": {
+		Indicator:  "pvt",
+		Name:       "Price Volume Trend",
+		Category:   CategoryVolume,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"volume": {
+		Indicator:  "volume",
+		Name:       "Volume",
+		Category:   CategoryVolume,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"wad": {
+		Indicator:  "wad",
+		Name:       "Williams Accumulation/Distribution",
+		Category:   CategoryVolume,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ht_dcperiod": {
+		Indicator:  "ht_dcperiod",
+		Name:       "Hilbert Transform Dominant Cycle Period",
+		Category:   CategoryCycle,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ht_dcphase": {
+		Indicator:  "ht_dcphase",
+		Name:       "Hilbert Transform Dominant Cycle Phase",
+		Category:   CategoryCycle,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ht_phasor": {
+		Indicator:  "ht_phasor",
+		Name:       "Hilbert Transform Phasor Components",
+		Category:   CategoryCycle,
+		Outputs:    []string{"valueInPhase", "valueQuadrature"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ht_sine": {
+		Indicator:  "ht_sine",
+		Name:       "Hilbert Transform SineWave",
+		Category:   CategoryCycle,
+		Outputs:    []string{"valueSine", "valueLeadSine"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ht_trendmode": {
+		Indicator:  "ht_trendmode",
+		Name:       "Hilbert Transform Trend vs Cycle Mode",
+		Category:   CategoryCycle,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"avgprice": {
+		Indicator:  "avgprice",
+		Name:       "Average Price",
+		Category:   CategoryPrice,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"candle": {
+		Indicator:  "candle",
+		Name:       "Candle",
+		Category:   CategoryPrice,
+		Outputs:    []string{"timestamp", "open", "high", "low", "close", "volume"},
+		Backtracks: true,
+	},
+	"candles": {
+		Indicator: "candles",
+		Name:      "Candles",
+		Category:  CategoryPrice,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(1), Max: bound(300)},
+		},
+		Outputs: []string{"timestamp", "open", "high", "low", "close", "volume"},
+	},
+	"medprice": {
+		Indicator:  "medprice",
+		Name:       "Median Price",
+		Category:   CategoryPrice,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"price": {
+		Indicator:  "price",
+		Name:       "Price",
+		Category:   CategoryPrice,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+	},
+	"typprice": {
+		Indicator:  "typprice",
+		Name:       "Typical Price",
+		Category:   CategoryPrice,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"wclprice": {
+		Indicator:  "wclprice",
+		Name:       "Weighted Close Price",
+		Category:   CategoryPrice,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"fibonacci": {
+		Indicator: "fibonacci",
+		Name:      "Fibonacci Retracement",
+		Category:  CategorySupport,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 21, Min: bound(2)},
+		},
+		Outputs:    []string{"value", "trend", "startPrice", "endPrice", "startTimestamp", "endTimestamp"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"fibonacciretracement": {
+		Indicator: "fibonacciretracement",
+		Name:      "Fibonacci Retracement (alias)",
+		Category:  CategorySupport,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 21, Min: bound(2)},
+		},
+		Outputs:    []string{"value", "trend", "startPrice", "endPrice", "startTimestamp", "endTimestamp"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"pivot": {
+		Indicator: "pivot",
+		Name:      "Pivot Points",
+		Category:  CategorySupport,
+		Params: []ParamSpec{
+			{Name: "type", Type: ParamString, Default: "classic", Enum: []string{"classic", "woodie", "camarilla", "fibonacci", "demark"}},
+		},
+		Outputs:    []string{"r3", "r2", "r1", "p", "s1", "s2", "s3"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"pivotpoints": {
+		Indicator: "pivotpoints",
+		Name:      "Pivot Points (alias)",
+		Category:  CategorySupport,
+		Params: []ParamSpec{
+			{Name: "type", Type: ParamString, Default: "classic", Enum: []string{"classic", "woodie", "camarilla", "fibonacci", "demark"}},
+		},
+		Outputs:    []string{"r3", "r2", "r1", "p", "s1", "s2", "s3"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"zigzag": {
+		Indicator: "zigzag",
+		Name:      "ZigZag",
+		Category:  CategorySupport,
+		Params: []ParamSpec{
+			{Name: "deviation", Type: ParamFloat, Default: float64(5), Min: bound(0)},
+			{Name: "depth", Type: ParamInt, Default: 10, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"beta": {
+		Indicator: "beta",
+		Name:      "Beta",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 5, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"correl": {
+		Indicator: "correl",
+		Name:      "Pearson's Correlation Coefficient",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 30, Min: bound(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"linearreg": {
+		Indicator: "linearreg",
+		Name:      "Linear Regression",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"linearreg_angle": {
+		Indicator: "linearreg_angle",
+		Name:      "Linear Regression Angle",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"linearreg_intercept": {
+		Indicator: "linearreg_intercept",
+		Name:      "Linear Regression Intercept",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"linearreg_slope": {
+		Indicator: "linearreg_slope",
+		Name:      "Linear Regression Slope",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"max": {
+		Indicator: "max",
+		Name:      "Highest value over period",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 30, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"maxindex": {
+		Indicator: "maxindex",
+		Name:      "Index of highest value over period",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 30, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"min": {
+		Indicator: "min",
+		Name:      "Lowest value over period",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 30, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"minindex": {
+		Indicator: "minindex",
+		Name:      "Index of lowest value over period",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 30, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"minmax": {
+		Indicator: "minmax",
+		Name:      "Lowest and highest values over period",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 30, Min: bound(2)},
+		},
+		Outputs:    []string{"valueMin", "valueMax"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"minmaxindex": {
+		Indicator: "minmaxindex",
+		Name:      "Indexes of lowest and highest values",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 30, Min: bound(2)},
+		},
+		Outputs:    []string{"valueMinIdx", "valueMaxIdx"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"sum": {
+		Indicator: "sum",
+		Name:      "Summation",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 30, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"tsf": {
+		Indicator: "tsf",
+		Name:      "Time Series Forecast",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 14, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"var": {
+		Indicator: "var",
+		Name:      "Variance",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "optInTimePeriod", Type: ParamInt, Default: 5, Min: bound(1)},
+			{Name: "optInNbDev", Type: ParamFloat, Default: float64(1)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"zscore": {
+		Indicator: "zscore",
+		Name:      "Z-Score",
+		Category:  CategoryStatistic,
+		Params: []ParamSpec{
+			{Name: "period", Type: ParamInt, Default: 20, Min: bound(2)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"acos": {
+		Indicator:  "acos",
+		Name:       "Vector Arc Cosine",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"asin": {
+		Indicator:  "asin",
+		Name:       "Vector Arc Sine",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"atan": {
+		Indicator:  "atan",
+		Name:       "Vector Arc Tangent",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ceil": {
+		Indicator:  "ceil",
+		Name:       "Vector Ceiling",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"cos": {
+		Indicator:  "cos",
+		Name:       "Vector Cosine",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"cosh": {
+		Indicator:  "cosh",
+		Name:       "Vector Hyperbolic Cosine",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"exp": {
+		Indicator:  "exp",
+		Name:       "Vector Exponential",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"floor": {
+		Indicator:  "floor",
+		Name:       "Vector Floor",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ln": {
+		Indicator:  "ln",
+		Name:       "Vector Natural Logarithm",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"log10": {
+		Indicator:  "log10",
+		Name:       "Vector Log10",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"sin": {
+		Indicator:  "sin",
+		Name:       "Vector Sine",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"sinh": {
+		Indicator:  "sinh",
+		Name:       "Vector Hyperbolic Sine",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"sqrt": {
+		Indicator:  "sqrt",
+		Name:       "Vector Square Root",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"tan": {
+		Indicator:  "tan",
+		Name:       "Vector Tangent",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"tanh": {
+		Indicator:  "tanh",
+		Name:       "Vector Hyperbolic Tangent",
+		Category:   CategoryMath,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"2crows": {
+		Indicator:  "2crows",
+		Name:       "Two Crows",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"3blackcrows": {
+		Indicator:  "3blackcrows",
+		Name:       "Three Black Crows",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"3inside": {
+		Indicator:  "3inside",
+		Name:       "Three Inside Up/Down",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"3linestrike": {
+		Indicator:  "3linestrike",
+		Name:       "Three-Line Strike",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"3outside": {
+		Indicator:  "3outside",
+		Name:       "Three Outside Up/Down",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"3starsinsouth": {
+		Indicator:  "3starsinsouth",
+		Name:       "Three Stars In The South",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"3whitesoldiers": {
+		Indicator:  "3whitesoldiers",
+		Name:       "Three Advancing White Soldiers",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"abandonedbaby": {
+		Indicator: "abandonedbaby",
+		Name:      "Abandoned Baby",
+		Category:  CategoryPattern,
+		Params: []ParamSpec{
+			{Name: "optInPenetration", Type: ParamFloat, Default: float64(0.3), Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"advanceblock": {
+		Indicator:  "advanceblock",
+		Name:       "Advance Block",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"belthold": {
+		Indicator:  "belthold",
+		Name:       "Belt-hold",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"breakaway": {
+		Indicator:  "breakaway",
+		Name:       "Breakaway",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"closingmarubozu": {
+		Indicator:  "closingmarubozu",
+		Name:       "Closing Marubozu",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"concealbabyswall": {
+		Indicator:  "concealbabyswall",
+		Name:       "Concealing Baby Swallow",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"counterattack": {
+		Indicator:  "counterattack",
+		Name:       "Counterattack",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"darkcloudcover": {
+		Indicator: "darkcloudcover",
+		Name:      "Dark Cloud Cover",
+		Category:  CategoryPattern,
+		Params: []ParamSpec{
+			{Name: "optInPenetration", Type: ParamFloat, Default: float64(0.5), Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"doji": {
+		Indicator:  "doji",
+		Name:       "Doji",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"dojistar": {
+		Indicator:  "dojistar",
+		Name:       "Doji Star",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"dragonflydoji": {
+		Indicator:  "dragonflydoji",
+		Name:       "Dragonfly Doji",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"engulfing": {
+		Indicator:  "engulfing",
+		Name:       "Engulfing Pattern",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"eveningdojistar": {
+		Indicator: "eveningdojistar",
+		Name:      "Evening Doji Star",
+		Category:  CategoryPattern,
+		Params: []ParamSpec{
+			{Name: "optInPenetration", Type: ParamFloat, Default: float64(0.3), Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"eveningstar": {
+		Indicator: "eveningstar",
+		Name:      "Evening Star",
+		Category:  CategoryPattern,
+		Params: []ParamSpec{
+			{Name: "optInPenetration", Type: ParamFloat, Default: float64(0.3), Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"gapsidesidewhite": {
+		Indicator:  "gapsidesidewhite",
+		Name:       "Up/Down-gap side-by-side white lines",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"gravestonedoji": {
+		Indicator:  "gravestonedoji",
+		Name:       "Gravestone Doji",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"hammer": {
+		Indicator:  "hammer",
+		Name:       "Hammer",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"hangingman": {
+		Indicator:  "hangingman",
+		Name:       "Hanging Man",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"harami": {
+		Indicator:  "harami",
+		Name:       "Harami Pattern",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"haramicross": {
+		Indicator:  "haramicross",
+		Name:       "Harami Cross Pattern",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"highwave": {
+		Indicator:  "highwave",
+		Name:       "High-Wave Candle",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"hikkake": {
+		Indicator:  "hikkake",
+		Name:       "Hikkake Pattern",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"hikkakemod": {
+		Indicator:  "hikkakemod",
+		Name:       "Modified Hikkake Pattern",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"homingpigeon": {
+		Indicator:  "homingpigeon",
+		Name:       "Homing Pigeon",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"identical3crows": {
+		Indicator:  "identical3crows",
+		Name:       "Identical Three Crows",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"inneck": {
+		Indicator:  "inneck",
+		Name:       "In-Neck Pattern",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"invertedhammer": {
+		Indicator:  "invertedhammer",
+		Name:       "Inverted Hammer",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"kicking": {
+		Indicator:  "kicking",
+		Name:       "Kicking",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"kickingbylength": {
+		Indicator:  "kickingbylength",
+		Name:       "Kicking by the longer marubozu",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"ladderbottom": {
+		Indicator:  "ladderbottom",
+		Name:       "Ladder Bottom",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"longleggeddoji": {
+		Indicator:  "longleggeddoji",
+		Name:       "Long Legged Doji",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"longline": {
+		Indicator:  "longline",
+		Name:       "Long Line Candle",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"marubozu": {
+		Indicator:  "marubozu",
+		Name:       "Marubozu",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"matchinglow": {
+		Indicator:  "matchinglow",
+		Name:       "Matching Low",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"mathold": {
+		Indicator: "mathold",
+		Name:      "Mat Hold",
+		Category:  CategoryPattern,
+		Params: []ParamSpec{
+			{Name: "optInPenetration", Type: ParamFloat, Default: float64(0.5), Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"morningdojistar": {
+		Indicator: "morningdojistar",
+		Name:      "Morning Doji Star",
+		Category:  CategoryPattern,
+		Params: []ParamSpec{
+			{Name: "optInPenetration", Type: ParamFloat, Default: float64(0.3), Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"morningstar": {
+		Indicator: "morningstar",
+		Name:      "Morning Star",
+		Category:  CategoryPattern,
+		Params: []ParamSpec{
+			{Name: "optInPenetration", Type: ParamFloat, Default: float64(0.3), Min: bound(0)},
+		},
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"onneck": {
+		Indicator:  "onneck",
+		Name:       "On-Neck Pattern",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"piercing": {
+		Indicator:  "piercing",
+		Name:       "Piercing Pattern",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"rickshawman": {
+		Indicator:  "rickshawman",
+		Name:       "Rickshaw Man",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"risefall3methods": {
+		Indicator:  "risefall3methods",
+		Name:       "Rising/Falling Three Methods",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"separatinglines": {
+		Indicator:  "separatinglines",
+		Name:       "Separating Lines",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"shootingstar": {
+		Indicator:  "shootingstar",
+		Name:       "Shooting Star",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"shortline": {
+		Indicator:  "shortline",
+		Name:       "Short Line Candle",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"spinningtop": {
+		Indicator:  "spinningtop",
+		Name:       "Spinning Top",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"stalledpattern": {
+		Indicator:  "stalledpattern",
+		Name:       "Stalled Pattern",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"sticksandwich": {
+		Indicator:  "sticksandwich",
+		Name:       "Stick Sandwich",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"takuri": {
+		Indicator:  "takuri",
+		Name:       "Takuri (Dragonfly Doji with very long lower shadow)",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"tasukigap": {
+		Indicator:  "tasukigap",
+		Name:       "Tasuki Gap",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"thrusting": {
+		Indicator:  "thrusting",
+		Name:       "Thrusting Pattern",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"tristar": {
+		Indicator:  "tristar",
+		Name:       "Tristar Pattern",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"unique3river": {
+		Indicator:  "unique3river",
+		Name:       "Unique 3 River",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"upsidegap2crows": {
+		Indicator:  "upsidegap2crows",
+		Name:       "Upside Gap Two Crows",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+	"xsidegap3methods": {
+		Indicator:  "xsidegap3methods",
+		Name:       "Upside/Downside Gap Three Methods",
+		Category:   CategoryPattern,
+		Outputs:    []string{"value"},
+		Backtracks: true,
+		Manual:     true,
+	},
+}
@@ -0,0 +1,130 @@
+package taapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndicatorsCatalog(t *testing.T) {
+	all := Indicators()
+	assert.Greater(t, len(all), 200)
+
+	for i := 1; i < len(all); i++ {
+		assert.Less(t, all[i-1].Indicator, all[i].Indicator)
+	}
+
+	for _, indicator := range []Indicator{
+		IndicatorRSI, IndicatorMACD, IndicatorBBANDS, IndicatorSTOCH, IndicatorSUPERTREND,
+		IndicatorCANDLE, Indicator("candles"),
+	} {
+		assert.True(t, indicator.IsValid(), indicator)
+		assert.NotNil(t, indicator.Info(), indicator)
+	}
+	assert.False(t, Indicator("nonexistent").IsValid())
+	assert.Nil(t, Indicator("nonexistent").Info())
+}
+
+func TestLookupIndicator(t *testing.T) {
+	info, ok := LookupIndicator(IndicatorMACD)
+	require.True(t, ok)
+
+	assert.Equal(t, CategoryMomentum, info.Category)
+	assert.Equal(t, []string{"valueMACD", "valueMACDSignal", "valueMACDHist"}, info.Outputs)
+	assert.True(t, info.Backtracks)
+	assert.True(t, info.Manual)
+
+	param, ok := info.Param("optInFastPeriod")
+	require.True(t, ok)
+	assert.Equal(t, ParamInt, param.Type)
+	assert.Equal(t, 12, param.Default)
+
+	assert.Equal(t, map[string]interface{}{
+		"optInFastPeriod":   12,
+		"optInSlowPeriod":   26,
+		"optInSignalPeriod": 9,
+	}, info.Defaults())
+
+	_, ok = LookupIndicator("nonexistent")
+	assert.False(t, ok)
+}
+
+func TestIndicatorsByCategory(t *testing.T) {
+	patterns := IndicatorsByCategory(CategoryPattern)
+	assert.NotEmpty(t, patterns)
+	for _, info := range patterns {
+		assert.Equal(t, CategoryPattern, info.Category)
+	}
+}
+
+func TestValidateParams(t *testing.T) {
+	info := IndicatorRSI.Info()
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr bool
+	}{
+		{"valid", map[string]interface{}{"period": 14, "backtrack": 2}, false},
+		{"numeric string", map[string]interface{}{"period": "21"}, false},
+		{"float integer", map[string]interface{}{"period": 14.0}, false},
+		{"unknown passes", map[string]interface{}{"newParam": "x"}, false},
+		{"below min", map[string]interface{}{"period": 1}, true},
+		{"not integer", map[string]interface{}{"period": 14.5}, true},
+		{"wrong type", map[string]interface{}{"period": true}, true},
+		{"negative backtrack", map[string]interface{}{"backtrack": -1}, true},
+		{"bad chart", map[string]interface{}{"chart": "renko"}, true},
+		{"bad bool", map[string]interface{}{"gaps": "maybe"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := info.ValidateParams(tt.params)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.True(t, errors.Is(err, ErrInvalidParams))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateParamsBacktracksUnsupported(t *testing.T) {
+	err := Indicator("candles").Info().ValidateParams(map[string]interface{}{"backtrack": 1})
+	assert.True(t, errors.Is(err, ErrInvalidParams))
+}
+
+func TestValidateIndicatorParams(t *testing.T) {
+	assert.NoError(t, validateIndicatorParams("nonexistent", map[string]interface{}{"period": -5}, true))
+	assert.Error(t, validateIndicatorParams("candles", nil, true))
+	assert.NoError(t, validateIndicatorParams("rsi", nil, true))
+}
+
+func TestBuildersValidateParams(t *testing.T) {
+	client := NewClient("test_secret")
+
+	_, err := client.Direct().
+		Exchange(ExchangeBinance).
+		Symbol("BTC/USDT").
+		Interval(Interval1h).
+		Indicator(IndicatorRSI).
+		WithParam("period", 0).
+		Get()
+	assert.True(t, errors.Is(err, ErrInvalidParams))
+
+	construct := client.Construct(ExchangeBinance, "BTC/USDT", Interval1h).
+		AddIndicator(IndicatorRSI, map[string]interface{}{"period": 0})
+	_, err = construct.ToMap()
+	assert.True(t, errors.Is(err, ErrInvalidParams))
+
+	_, err = client.Bulk().AddConstruct(construct).Execute()
+	assert.True(t, errors.Is(err, ErrInvalidParams))
+
+	_, err = client.Manual(Indicator("candles")).
+		WithCandles([][]interface{}{{1, 1, 1, 1, 1, 1}}).
+		Execute()
+	assert.True(t, errors.Is(err, ErrInvalidParams))
+}
@@ -2,12 +2,13 @@ package taapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"strconv"
+	"sync/atomic"
 	"time"
 )
 
@@ -21,17 +22,22 @@ type Client struct {
 	apiSecret  string
 	baseURL    string
 	httpClient *http.Client
+	// markets is swapped atomically, as RefreshMarkets may run while
+	// builders validate requests
+	markets atomic.Pointer[MarketCatalog]
 }
 
 // NewClient creates a new TAAPI client
 func NewClient(apiSecret string) *Client {
-	return &Client{
+	c := &Client{
 		apiSecret: apiSecret,
 		baseURL:   defaultBaseURL,
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
 	}
+	c.markets.Store(DefaultMarkets().Clone())
+	return c
 }
 
 // SetTimeout sets the HTTP client timeout
@@ -46,6 +52,19 @@ func (c *Client) SetBaseURL(baseURL string) *Client {
 	return c
 }
 
+// SetTransport sets the transport used for HTTP requests, e.g. a recording
+// or replaying transport in tests
+func (c *Client) SetTransport(transport http.RoundTripper) *Client {
+	c.httpClient.Transport = transport
+	return c
+}
+
+// SetHTTPClient replaces the HTTP client used for requests
+func (c *Client) SetHTTPClient(httpClient *http.Client) *Client {
+	c.httpClient = httpClient
+	return c
+}
+
 // Exchange starts building a direct request with an exchange
 func (c *Client) Exchange(exchange Exchange) *DirectBuilder {
 	return &DirectBuilder{
@@ -97,6 +116,7 @@ func (c *Client) Bulk() *BulkBuilder {
 // Construct creates a new construct for bulk requests
 func (c *Client) Construct(exchange Exchange, symbol string, interval Interval) *ConstructBuilder {
 	return &ConstructBuilder{
+		client:     c,
 		exchange:   exchange.String(),
 		symbol:     symbol,
 		interval:   interval.String(),
@@ -104,6 +124,27 @@ func (c *Client) Construct(exchange Exchange, symbol string, interval Interval)
 	}
 }
 
+// Candles creates a new builder fetching OHLCV candles
+func (c *Client) Candles(exchange Exchange, symbol string, interval Interval) *CandlesBuilder {
+	return &CandlesBuilder{
+		client:   c,
+		exchange: exchange.String(),
+		symbol:   symbol,
+		interval: interval.String(),
+		params:   make(map[string]interface{}),
+	}
+}
+
+// PatternScan creates a new builder scanning a symbol for candlestick patterns
+func (c *Client) PatternScan(exchange Exchange, symbol string, interval Interval) *PatternScanBuilder {
+	return &PatternScanBuilder{
+		client:   c,
+		exchange: exchange,
+		symbol:   symbol,
+		interval: interval,
+	}
+}
+
 // Manual creates a new manual request builder
 func (c *Client) Manual(indicator Indicator) *ManualBuilder {
 	return &ManualBuilder{
@@ -113,13 +154,14 @@ func (c *Client) Manual(indicator Indicator) *ManualBuilder {
 	}
 }
 
-// doGet performs a GET request
-func (c *Client) doGet(endpoint string, params map[string]interface{}) (*IndicatorResponse, error) {
+// newGetRequest builds a GET request carrying the secret and params in the query
+func (c *Client) newGetRequest(ctx context.Context, endpoint string, params map[string]interface{}) (*http.Request, *RequestInfo, error) {
+	info := newRequestInfo(http.MethodGet, endpoint, params)
 	urlStr := c.baseURL + endpoint
 
 	u, err := url.Parse(urlStr)
 	if err != nil {
-		return nil, NetworkError("invalid URL", err)
+		return nil, info, NetworkError("invalid URL", err).withRequest(info)
 	}
 
 	q := u.Query()
@@ -131,73 +173,134 @@ func (c *Client) doGet(endpoint string, params map[string]interface{}) (*Indicat
 
 	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return nil, NetworkError("failed to create request", err)
+		return nil, info, NetworkError("failed to create request", err).withRequest(info)
 	}
 
 	req.Header.Set("Accept", "application/json")
+	return req, info, nil
+}
 
-	resp, err := c.httpClient.Do(req)
+// doGet performs a GET request
+func (c *Client) doGet(ctx context.Context, endpoint string, params map[string]interface{}) (*IndicatorResponse, error) {
+	req, info, err := c.newGetRequest(ctx, endpoint, params)
 	if err != nil {
-		return nil, NetworkError("request failed", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	result, err := c.handleResponse(resp, false)
+	result, err := c.handleResponse(req, info, false)
 	if err != nil {
 		return nil, err
 	}
 
 	indicatorResp, ok := result.(*IndicatorResponse)
 	if !ok {
-		return nil, APIError(0, "unexpected response type", nil)
+		return nil, DecodeError("unexpected response type", nil).withRequest(info)
 	}
 
 	return indicatorResp, nil
 }
 
+// doGetSeries performs a GET request returning an array of results, as the
+// indicator endpoints do when backtracks is set. A single object is returned
+// as a one-element series.
+func (c *Client) doGetSeries(ctx context.Context, endpoint string, params map[string]interface{}) ([]*IndicatorResponse, error) {
+	req, info, err := c.newGetRequest(ctx, endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, body, meta, err := c.send(req, info)
+	if err != nil {
+		return nil, err
+	}
+
+	var series []*IndicatorResponse
+	if err := json.Unmarshal(body, &series); err != nil {
+		var single IndicatorResponse
+		if err := json.Unmarshal(body, &single); err != nil {
+			decodeErr := newDecodeError("failed to decode series response", err, resp, body).withRequest(info)
+			decodeErr.Meta = meta
+			return nil, decodeErr
+		}
+		series = []*IndicatorResponse{&single}
+	}
+
+	for _, item := range series {
+		item.Meta = meta
+	}
+	return series, nil
+}
+
+// doGetCandles performs a GET request for candle data. The endpoint returns a
+// single object, or an array of objects when backtracks is set.
+func (c *Client) doGetCandles(ctx context.Context, endpoint string, params map[string]interface{}) ([]*Candle, *ResponseMeta, error) {
+	req, info, err := c.newGetRequest(ctx, endpoint, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, body, meta, err := c.send(req, info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candles, err := decodeCandles(body)
+	if err != nil {
+		decodeErr := newDecodeError("failed to decode candles", err, resp, body).withRequest(info)
+		decodeErr.Meta = meta
+		return nil, nil, decodeErr
+	}
+
+	return candles, meta, nil
+}
+
 // doPost performs a POST request
-func (c *Client) doPost(endpoint string, payload map[string]interface{}) (interface{}, error) {
+func (c *Client) doPost(ctx context.Context, endpoint string, payload map[string]interface{}) (interface{}, error) {
+	info := newRequestInfo(http.MethodPost, endpoint, payload)
 	urlStr := c.baseURL + endpoint
 
 	payload["secret"] = c.apiSecret
 
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return nil, NetworkError("failed to marshal JSON", err)
+		return nil, InvalidArgumentError(fmt.Sprintf("failed to marshal JSON: %v", err)).withRequest(info)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, urlStr, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, urlStr, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return nil, NetworkError("failed to create request", err)
+		return nil, NetworkError("failed to create request", err).withRequest(info)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
+	isBulk := endpoint == "/bulk"
+	return c.handleResponse(req, info, isBulk)
+}
+
+// send performs the HTTP request, reads the body and converts error statuses
+// into errors. The returned metadata is set whenever a response was received.
+func (c *Client) send(req *http.Request, info *RequestInfo) (*http.Response, []byte, *ResponseMeta, error) {
+	start := time.Now()
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, NetworkError("request failed", err)
+		return nil, nil, nil, NetworkError("request failed", err).withRequest(info)
 	}
 	defer resp.Body.Close()
 
-	isBulk := endpoint == "/bulk"
-	return c.handleResponse(resp, isBulk)
-}
-
-// handleResponse processes the HTTP response
-func (c *Client) handleResponse(resp *http.Response, isBulk bool) (interface{}, error) {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, NetworkError("failed to read response body", err)
+		return nil, nil, nil, NetworkError("failed to read response body", err).withRequest(info)
 	}
 
+	meta := newResponseMeta(resp, time.Since(start), 1)
+
 	if resp.StatusCode == http.StatusTooManyRequests {
-		retryAfter := 0
-		if retryAfterStr := resp.Header.Get("Retry-After"); retryAfterStr != "" {
-			retryAfter, _ = strconv.Atoi(retryAfterStr)
-		}
+		// Whole seconds, rounded up
+		retryAfter := int((meta.Quota.RetryAfter + time.Second - 1) / time.Second)
 
 		var errorData map[string]interface{}
 		json.Unmarshal(body, &errorData)
@@ -207,7 +310,10 @@ func (c *Client) handleResponse(resp *http.Response, isBulk bool) (interface{},
 			message = msg
 		}
 
-		return nil, NewRateLimitError(message, retryAfter, errorData)
+		rateLimitErr := NewRateLimitError(message, retryAfter, errorData)
+		rateLimitErr.Request = info
+		rateLimitErr.Meta = meta
+		return resp, body, meta, rateLimitErr
 	}
 
 	if resp.StatusCode >= 400 {
@@ -221,20 +327,38 @@ func (c *Client) handleResponse(resp *http.Response, isBulk bool) (interface{},
 			message = msg
 		}
 
-		return nil, APIError(resp.StatusCode, message, errorData)
+		apiErr := APIError(resp.StatusCode, message, errorData).withRequest(info)
+		apiErr.Meta = meta
+		return resp, body, meta, apiErr
+	}
+
+	return resp, body, meta, nil
+}
+
+// handleResponse sends the request and decodes the response body
+func (c *Client) handleResponse(req *http.Request, info *RequestInfo, isBulk bool) (interface{}, error) {
+	resp, body, meta, err := c.send(req, info)
+	if err != nil {
+		return nil, err
 	}
 
 	if isBulk {
 		var bulkResp BulkResponse
 		if err := json.Unmarshal(body, &bulkResp); err != nil {
-			return nil, APIError(0, "failed to decode bulk response", nil)
+			decodeErr := newDecodeError("failed to decode bulk response", err, resp, body).withRequest(info)
+			decodeErr.Meta = meta
+			return nil, decodeErr
 		}
+		bulkResp.Meta = meta
 		return &bulkResp, nil
 	}
 
 	var indicatorResp IndicatorResponse
 	if err := json.Unmarshal(body, &indicatorResp); err != nil {
-		return nil, APIError(0, "failed to decode response", nil)
+		decodeErr := newDecodeError("failed to decode response", err, resp, body).withRequest(info)
+		decodeErr.Meta = meta
+		return nil, decodeErr
 	}
+	indicatorResp.Meta = meta
 	return &indicatorResp, nil
 }
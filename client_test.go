@@ -1,6 +1,7 @@
 package taapi
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,6 +28,18 @@ func TestClientSetBaseURL(t *testing.T) {
 	assert.Equal(t, client, result)
 }
 
+func TestClientSetTransport(t *testing.T) {
+	client := NewClient("test_secret")
+	transport := &http.Transport{}
+	result := client.SetTransport(transport)
+	assert.Equal(t, transport, client.httpClient.Transport)
+	assert.Equal(t, client, result)
+
+	httpClient := &http.Client{}
+	client.SetHTTPClient(httpClient)
+	assert.Same(t, httpClient, client.httpClient)
+}
+
 func TestClientExchange(t *testing.T) {
 	client := NewClient("test_secret")
 	builder := client.Exchange(ExchangeBinance)
@@ -84,3 +97,12 @@ func TestClientManual(t *testing.T) {
 	assert.NotNil(t, builder)
 	assert.Equal(t, "ema", builder.indicator)
 }
+
+func TestClientCandles(t *testing.T) {
+	client := NewClient("test_secret")
+	builder := client.Candles(ExchangeBinance, "BTC/USDT", Interval1h)
+	assert.NotNil(t, builder)
+	assert.Equal(t, "binance", builder.exchange)
+	assert.Equal(t, "BTC/USDT", builder.symbol)
+	assert.Equal(t, "1h", builder.interval)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+)
+
+// parseArgs parses the flags defined in fs and returns the remaining
+// positional arguments. Flags unknown to fs are returned as indicator
+// parameters, so --period 14 and --period=14 both set period to 14.
+func parseArgs(fs *flag.FlagSet, args []string) (map[string]interface{}, []string, error) {
+	params := make(map[string]interface{})
+	var known, positional []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name := strings.TrimLeft(arg, "-")
+		value, hasValue := "", false
+		if j := strings.Index(name, "="); j >= 0 {
+			name, value, hasValue = name[:j], name[j+1:], true
+		}
+		if name == "h" || name == "help" {
+			fs.Usage()
+			return nil, nil, flag.ErrHelp
+		}
+
+		if f := fs.Lookup(name); f != nil {
+			known = append(known, arg)
+			if !hasValue && !isBoolFlag(f) && i+1 < len(args) {
+				known = append(known, args[i+1])
+				i++
+			}
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, nil, usagef("missing value for parameter --%s", name)
+			}
+			value = args[i+1]
+			i++
+		}
+		params[name] = parseValue(value)
+	}
+
+	if err := fs.Parse(known); err != nil {
+		return nil, nil, &usageError{msg: err.Error()}
+	}
+	return params, positional, nil
+}
+
+// isBoolFlag reports whether the flag takes no value
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// parseValue converts a parameter value to an int, float or bool when it
+// looks like one
+func parseValue(s string) interface{} {
+	if i, err := strconv.Atoi(s); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	if s == "true" || s == "false" {
+		return s == "true"
+	}
+	return s
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArgs(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	symbol := fs.String("symbol", "", "")
+	verbose := fs.Bool("verbose", false, "")
+
+	params, positional, err := parseArgs(fs, []string{
+		"rsi", "--symbol", "BTC/USDT", "--verbose", "--period", "14", "--stddev=2.5", "--anchor", "session", "--", "-x",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "BTC/USDT", *symbol)
+	assert.True(t, *verbose)
+	assert.Equal(t, []string{"rsi", "-x"}, positional)
+	assert.Equal(t, map[string]interface{}{"period": 14, "stddev": 2.5, "anchor": "session"}, params)
+
+	_, _, err = parseArgs(fs, []string{"--period"})
+	assert.Error(t, err)
+
+	_, _, err = parseArgs(fs, []string{"--help"})
+	assert.ErrorIs(t, err, flag.ErrHelp)
+}
+
+func TestParseValue(t *testing.T) {
+	assert.Equal(t, 14, parseValue("14"))
+	assert.Equal(t, -1.5, parseValue("-1.5"))
+	assert.Equal(t, true, parseValue("true"))
+	assert.Equal(t, "sma", parseValue("sma"))
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tigusigalpa/taapi-go"
+	"github.com/tigusigalpa/taapi-go/bulkspec"
+)
+
+// runGet fetches an indicator for one symbol
+func runGet(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, opts := newFlagSet("get", stderr)
+	asset := fs.String("type", "", "asset type: crypto, stocks or forex")
+	exchange := fs.String("exchange", "", "exchange, e.g. binance")
+	symbol := fs.String("symbol", "", "symbol, e.g. BTC/USDT")
+	interval := fs.String("interval", "", "interval, e.g. 1h")
+	backtrack := fs.Int("backtrack", 0, "candles to go back")
+	backtracks := fs.Int("backtracks", 0, "number of past values to return")
+
+	params, positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("get takes exactly one indicator, e.g. taapi get rsi")
+	}
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	builder := client.Direct().
+		Type(taapi.AssetType(*asset)).
+		Exchange(taapi.Exchange(*exchange)).
+		Symbol(*symbol).
+		Interval(taapi.Interval(*interval)).
+		Indicator(taapi.Indicator(strings.ToLower(positional[0]))).
+		WithParams(params)
+	if *backtrack > 0 {
+		builder.Backtrack(*backtrack)
+	}
+
+	if *backtracks > 0 {
+		series, err := builder.Backtracks(*backtracks).GetSeriesContext(ctx)
+		if err != nil {
+			return err
+		}
+		return writeResponses(stdout, opts.output, series, series)
+	}
+
+	resp, err := builder.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	return writeResponses(stdout, opts.output, resp, []*taapi.IndicatorResponse{resp})
+}
+
+// runBulk executes a bulk request defined in a bulkspec YAML or JSON file
+func runBulk(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, opts := newFlagSet("bulk", stderr)
+	file := fs.String("f", "", "YAML or JSON file with the constructs")
+
+	params, positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(params) > 0 || len(positional) > 0 {
+		return usagef("bulk takes no indicator parameters; set them in the file")
+	}
+	if *file == "" {
+		return usagef("bulk requires a file: taapi bulk -f constructs.yaml")
+	}
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	spec, err := bulkspec.Load(*file)
+	if err != nil {
+		return err
+	}
+	bulk, err := spec.Build(client)
+	if err != nil {
+		return err
+	}
+
+	resp, err := bulk.ExecuteContext(ctx)
+	if err != nil {
+		return err
+	}
+	return writeResponses(stdout, opts.output, resp.Responses, resp.Responses)
+}
+
+// runManual calculates an indicator on candles read from a file
+func runManual(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs, opts := newFlagSet("manual", stderr)
+	indicator := fs.String("indicator", "", "indicator, e.g. ema")
+	candlesFile := fs.String("candles", "", "CSV or JSON Lines file with candles")
+
+	params, positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *indicator == "" && len(positional) == 1 {
+		*indicator = positional[0]
+	} else if len(positional) > 0 {
+		return usagef("unexpected arguments %v", positional)
+	}
+	if *indicator == "" {
+		return usagef("manual requires --indicator")
+	}
+	if *candlesFile == "" {
+		return usagef("manual requires --candles")
+	}
+	client, err := opts.client()
+	if err != nil {
+		return err
+	}
+
+	candles, err := readCandles(*candlesFile)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Manual(taapi.Indicator(strings.ToLower(*indicator))).
+		WithCandleStructs(candles).
+		WithParams(params).
+		ExecuteContext(ctx)
+	if err != nil {
+		return err
+	}
+	return writeResponses(stdout, opts.output, resp, []*taapi.IndicatorResponse{resp})
+}
+
+// readCandles reads candles from a JSON Lines file (.jsonl, .ndjson) or a
+// CSV file with or without a header row
+func readCandles(path string) ([]*taapi.Candle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return taapi.ReadCandlesJSONL(f, taapi.TimeUnitAuto)
+	}
+
+	r := bufio.NewReader(f)
+	first, err := r.Peek(64)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	candles, err := taapi.ReadCandlesCSV(r, &taapi.CSVOptions{Header: hasHeader(first)})
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return candles, nil
+}
+
+// hasHeader reports whether the first CSV field is a column name rather than
+// a timestamp
+func hasHeader(data []byte) bool {
+	field := string(data)
+	if i := strings.IndexAny(field, ",;\t\r\n"); i >= 0 {
+		field = field[:i]
+	}
+	_, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+	return err != nil
+}
@@ -0,0 +1,180 @@
+// Command taapi queries taapi.io indicators from the command line.
+//
+//	taapi get rsi --exchange binance --symbol BTC/USDT --interval 1h --backtracks 10
+//	taapi bulk -f constructs.yaml -o json
+//	taapi manual --candles candles.csv --indicator ema --period 20
+//
+// The API secret is read from --secret or the TAAPI_SECRET environment
+// variable. Flags not known to a command are sent as indicator parameters.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// Exit codes. Library errors map to a code per error kind.
+const (
+	exitOK           = 0
+	exitError        = 1
+	exitUsage        = 2
+	exitUnauthorized = 3
+	exitPlanLimit    = 4
+	exitRateLimited  = 5
+	exitServer       = 6
+	exitNetwork      = 7
+	exitDecode       = 8
+	exitCanceled     = 130
+)
+
+const usage = `Usage: taapi <command> [flags]
+
+Commands:
+  get <indicator>   fetch an indicator for one symbol
+  bulk -f <file>    run a bulk request defined in a YAML or JSON file
+  manual            calculate an indicator on candles from a CSV or JSON Lines file
+
+Common flags:
+  --secret      API secret (default $TAAPI_SECRET)
+  --base-url    API base URL
+  --timeout     request timeout (default 30s)
+  -o, --output  output format: table, json or csv (default table)
+
+Unknown flags such as --period 14 are sent as indicator parameters.
+
+Exit codes:
+  0 success, 1 error, 2 invalid arguments or parameters, 3 unauthorized,
+  4 plan limit, 5 rate limited, 6 server error, 7 network error or timeout,
+  8 decode failure, 130 canceled
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run executes the command line and returns the exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	var err error
+	switch args[0] {
+	case "get":
+		err = runGet(ctx, args[1:], stdout, stderr)
+	case "bulk":
+		err = runBulk(ctx, args[1:], stdout, stderr)
+	case "manual":
+		err = runManual(ctx, args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "taapi: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+
+	if err == nil {
+		return exitOK
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	fmt.Fprintf(stderr, "taapi: %v\n", err)
+	return exitCode(err)
+}
+
+// exitCode maps an error to the process exit code
+func exitCode(err error) int {
+	var usageErr *usageError
+	switch {
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.Is(err, taapi.ErrInvalidParams),
+		errors.Is(err, taapi.ErrInvalidSymbol),
+		errors.Is(err, taapi.ErrUnsupportedExchange),
+		errors.Is(err, taapi.ErrInvalidCandles):
+		return exitUsage
+	case errors.Is(err, taapi.ErrUnauthorized):
+		return exitUnauthorized
+	case errors.Is(err, taapi.ErrPlanLimit):
+		return exitPlanLimit
+	case errors.Is(err, taapi.ErrRateLimited):
+		return exitRateLimited
+	case errors.Is(err, taapi.ErrServer):
+		return exitServer
+	case errors.Is(err, taapi.ErrNetwork), errors.Is(err, taapi.ErrTimeout):
+		return exitNetwork
+	case errors.Is(err, taapi.ErrDecode):
+		return exitDecode
+	case errors.Is(err, taapi.ErrCanceled), errors.Is(err, context.Canceled):
+		return exitCanceled
+	}
+	return exitError
+}
+
+// usageError reports invalid command line arguments
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// usagef returns a usage error
+func usagef(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// globalOptions are the flags shared by all commands
+type globalOptions struct {
+	secret  string
+	baseURL string
+	timeout time.Duration
+	output  string
+}
+
+// newFlagSet creates the flag set of a command with the common flags
+func newFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *globalOptions) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	opts := &globalOptions{}
+	fs.StringVar(&opts.secret, "secret", os.Getenv("TAAPI_SECRET"), "API secret")
+	fs.StringVar(&opts.baseURL, "base-url", "", "API base URL")
+	fs.DurationVar(&opts.timeout, "timeout", 30*time.Second, "request timeout")
+	fs.StringVar(&opts.output, "output", "table", "output format: table, json or csv")
+	fs.StringVar(&opts.output, "o", "table", "output format (shorthand)")
+	return fs, opts
+}
+
+// client creates the API client from the common flags
+func (o *globalOptions) client() (*taapi.Client, error) {
+	if o.secret == "" {
+		return nil, usagef("an API secret is required: set --secret or TAAPI_SECRET")
+	}
+	switch o.output {
+	case formatTable, formatJSON, formatCSV:
+	default:
+		return nil, usagef("unknown output format %q", o.output)
+	}
+
+	client := taapi.NewClient(o.secret).SetTimeout(o.timeout)
+	if o.baseURL != "" {
+		client.SetBaseURL(o.baseURL)
+	}
+	return client, nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigusigalpa/taapi-go"
+	"github.com/tigusigalpa/taapi-go/taapitest"
+)
+
+// runCLI runs the command line against the fake server
+func runCLI(t *testing.T, server *taapitest.Server, args ...string) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	args = append(args, "--secret", server.Secret(), "--base-url", server.URL)
+	code := run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestGet(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, taapitest.Value(28.5))
+
+	code, out, stderr := runCLI(t, server, "get", "rsi", "--exchange", "binance", "--symbol", "BTC/USDT",
+		"--interval", "1h", "--period", "21")
+	require.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "VALUE\n28.5\n", out)
+	server.AssertParam(t, taapi.IndicatorRSI, "period", "21")
+
+	code, out, _ = runCLI(t, server, "get", "rsi", "--exchange=binance", "--symbol=BTC/USDT", "--interval=1h", "-o", "json")
+	require.Equal(t, exitOK, code)
+	assert.JSONEq(t, `{"value": 28.5}`, out)
+}
+
+func TestGetBacktracks(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, taapitest.Series(30, 40, 50))
+
+	code, out, stderr := runCLI(t, server, "get", "rsi", "--exchange", "binance", "--symbol", "BTC/USDT",
+		"--interval", "1h", "--backtracks", "3", "-o", "csv")
+	require.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "backtrack,value\n0,30\n1,40\n2,50\n", out)
+	server.AssertParam(t, taapi.IndicatorRSI, "backtracks", "3")
+}
+
+func TestBulk(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, taapitest.Value(42))
+	server.Handle(taapi.IndicatorMACD, taapitest.Values(map[string]float64{"valueMACD": 1, "valueMACDSignal": 2}))
+
+	file := filepath.Join(t.TempDir(), "constructs.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+constructs:
+  - exchange: binance
+    symbol: BTC/USDT
+    interval: 1h
+    indicators:
+      - indicator: rsi
+        id: btc_rsi
+        period: 14
+      - indicator: macd
+        id: btc_macd
+`), 0o644))
+
+	code, out, stderr := runCLI(t, server, "bulk", "-f", file)
+	require.Equal(t, exitOK, code, stderr)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"ID", "INDICATOR", "VALUE", "VALUEMACD", "VALUEMACDSIGNAL"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"btc_rsi", "rsi", "42"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"btc_macd", "macd", "1", "2"}, strings.Fields(lines[2]))
+	server.AssertParam(t, taapi.IndicatorRSI, "period", "14")
+}
+
+func TestManual(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorEMA, taapitest.Value(101.25))
+
+	file := filepath.Join(t.TempDir(), "candles.csv")
+	require.NoError(t, os.WriteFile(file, []byte(
+		"timestamp,open,high,low,close,volume\n"+
+			"1609459200,100,110,90,105,10\n"+
+			"1609462800,105,115,95,110,12\n"), 0o644))
+
+	code, out, stderr := runCLI(t, server, "manual", "--candles", file, "--indicator", "ema", "--period", "20")
+	require.Equal(t, exitOK, code, stderr)
+	assert.Equal(t, "VALUE\n101.25\n", out)
+
+	last, ok := server.LastRequest()
+	require.True(t, ok)
+	assert.Equal(t, "/manual", last.Path)
+	assert.Equal(t, "20", last.Param("period"))
+}
+
+func TestExitCodes(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, taapitest.Unauthorized())
+	server.Handle(taapi.IndicatorMACD, taapitest.PlanLimit())
+
+	base := []string{"--exchange", "binance", "--symbol", "BTC/USDT", "--interval", "1h"}
+
+	code, _, stderr := runCLI(t, server, append([]string{"get", "rsi"}, base...)...)
+	assert.Equal(t, exitUnauthorized, code)
+	assert.Contains(t, stderr, "taapi:")
+
+	code, _, _ = runCLI(t, server, append([]string{"get", "macd"}, base...)...)
+	assert.Equal(t, exitPlanLimit, code)
+
+	code, _, _ = runCLI(t, server, "get", "rsi", "--symbol", "BTC/USDT", "--interval", "1h")
+	assert.Equal(t, exitUsage, code)
+
+	code, _, _ = runCLI(t, server, "bulk")
+	assert.Equal(t, exitUsage, code)
+
+	code, _, _ = runCLI(t, server, "unknown")
+	assert.Equal(t, exitUsage, code)
+
+	assert.Equal(t, exitRateLimited, exitCode(taapi.NewRateLimitError("slow down", 1, nil)))
+	assert.Equal(t, exitNetwork, exitCode(taapi.NetworkError("down", errors.New("refused"))))
+	assert.Equal(t, exitError, exitCode(errors.New("other")))
+}
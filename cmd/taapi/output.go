@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// Output formats
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// leadingColumns are shown before the value columns, which follow sorted
+var leadingColumns = []string{"id", "indicator", "backtrack", "timestamp"}
+
+// writeResponses writes indicator responses in the output format. JSON
+// output writes v as is; table and CSV output write one row per response.
+func writeResponses(w io.Writer, format string, v interface{}, responses []*taapi.IndicatorResponse) error {
+	if format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	rows := make([]map[string]string, len(responses))
+	for i, resp := range responses {
+		rows[i] = flattenResponse(resp)
+	}
+	header := columns(rows)
+
+	if format == formatCSV {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := cw.Write(rowValues(header, row)); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	upper := make([]string, len(header))
+	for i, column := range header {
+		upper[i] = strings.ToUpper(column)
+	}
+	fmt.Fprintln(tw, strings.Join(upper, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(rowValues(header, row), "\t"))
+	}
+	return tw.Flush()
+}
+
+// flattenResponse converts a response to a row. The fields of a bulk
+// "result" object become columns of their own; other nested objects are
+// flattened into dotted column names.
+func flattenResponse(resp *taapi.IndicatorResponse) map[string]string {
+	row := make(map[string]string)
+	if resp.ID != "" {
+		row["id"] = resp.ID
+	}
+	if resp.Indicator != "" {
+		row["indicator"] = resp.Indicator
+	}
+	for k, v := range resp.Data {
+		if k == "result" {
+			flattenValue(row, "", v)
+			continue
+		}
+		flattenValue(row, k, v)
+	}
+	return row
+}
+
+// flattenValue adds a value to the row under the key
+func flattenValue(row map[string]string, key string, v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, nested := range v {
+			if key != "" {
+				k = key + "." + k
+			}
+			flattenValue(row, k, nested)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return
+		}
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatValue(item)
+		}
+		row[key] = strings.Join(parts, "; ")
+	default:
+		if key == "" {
+			key = "value"
+		}
+		row[key] = formatValue(v)
+	}
+}
+
+// formatValue formats a JSON value for a table cell
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+// columns returns the header of the rows: leading columns first, the rest
+// sorted
+func columns(rows []map[string]string) []string {
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		for k := range row {
+			seen[k] = true
+		}
+	}
+
+	var header []string
+	for _, column := range leadingColumns {
+		if seen[column] {
+			header = append(header, column)
+			delete(seen, column)
+		}
+	}
+	rest := make([]string, 0, len(seen))
+	for column := range seen {
+		rest = append(rest, column)
+	}
+	sort.Strings(rest)
+	return append(header, rest...)
+}
+
+// rowValues returns the values of a row in header order
+func rowValues(header []string, row map[string]string) []string {
+	values := make([]string, len(header))
+	for i, column := range header {
+		values[i] = row[column]
+	}
+	return values
+}
@@ -1,24 +1,107 @@
 package taapi
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 )
 
+// Sentinel errors describing the kind of failure. Every error returned by
+// the client matches exactly one of them via errors.Is. ErrInvalidCandles is
+// returned by candle series validation.
+var (
+	ErrUnauthorized        = errors.New("taapi: unauthorized")
+	ErrInvalidSymbol       = errors.New("taapi: invalid symbol")
+	ErrUnsupportedExchange = errors.New("taapi: unsupported exchange")
+	ErrInvalidParams       = errors.New("taapi: invalid parameters")
+	ErrPlanLimit           = errors.New("taapi: plan limit exceeded")
+	ErrRateLimited         = errors.New("taapi: rate limited")
+	ErrServer              = errors.New("taapi: server error")
+	ErrTimeout             = errors.New("taapi: timeout")
+	ErrDecode              = errors.New("taapi: decode failure")
+	ErrCanceled            = errors.New("taapi: canceled")
+	ErrNetwork             = errors.New("taapi: network error")
+	ErrInvalidCandles      = errors.New("taapi: invalid candles")
+)
+
+// RequestInfo describes the request that produced an error
+type RequestInfo struct {
+	Method    string
+	Endpoint  string
+	Exchange  string
+	Symbol    string
+	Interval  string
+	Indicator string
+}
+
+// String returns a compact representation of the request
+func (r *RequestInfo) String() string {
+	if r == nil {
+		return ""
+	}
+	parts := []string{r.Method, r.Endpoint}
+	for _, v := range []string{r.Exchange, r.Symbol, r.Interval, r.Indicator} {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
+
+// newRequestInfo builds a request descriptor from an endpoint and its params
+func newRequestInfo(method, endpoint string, params map[string]interface{}) *RequestInfo {
+	info := &RequestInfo{
+		Method:   method,
+		Endpoint: endpoint,
+	}
+	str := func(key string) string {
+		if v, ok := params[key]; ok && v != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return ""
+	}
+	info.Exchange = str("exchange")
+	info.Symbol = str("symbol")
+	info.Interval = str("interval")
+	info.Indicator = str("indicator")
+	if info.Indicator == "" && method == http.MethodGet {
+		info.Indicator = strings.TrimPrefix(endpoint, "/")
+	}
+	return info
+}
+
 // Error represents a TAAPI error
 type Error struct {
 	Message    string
 	StatusCode int
 	Response   map[string]interface{}
 	Err        error
+	// Kind is the sentinel error classifying the failure, e.g. ErrUnauthorized
+	Kind error
+	// Request describes the request that failed, when known
+	Request *RequestInfo
+	// ContentType is the Content-Type of the response that failed to decode
+	ContentType string
+	// Body holds the (truncated) raw response body for decode failures
+	Body string
+	// Meta holds HTTP metadata when a response was received
+	Meta *ResponseMeta
 }
 
 // Error implements the error interface
 func (e *Error) Error() string {
+	message := e.Message
+	if e.Err != nil {
+		message = fmt.Sprintf("%s: %v", message, e.Err)
+	}
 	if e.StatusCode > 0 {
-		return fmt.Sprintf("taapi error [%d]: %s", e.StatusCode, e.Message)
+		return fmt.Sprintf("taapi error [%d]: %s", e.StatusCode, message)
 	}
-	return fmt.Sprintf("taapi error: %s", e.Message)
+	return fmt.Sprintf("taapi error: %s", message)
 }
 
 // Unwrap returns the underlying error
@@ -26,10 +109,22 @@ func (e *Error) Unwrap() error {
 	return e.Err
 }
 
+// Is reports whether the error is of the given kind
+func (e *Error) Is(target error) bool {
+	return e.Kind != nil && e.Kind == target
+}
+
+// withRequest attaches a request descriptor to the error
+func (e *Error) withRequest(info *RequestInfo) *Error {
+	e.Request = info
+	return e
+}
+
 // InvalidArgumentError creates an error for invalid arguments
 func InvalidArgumentError(message string) *Error {
 	return &Error{
 		Message: message,
+		Kind:    ErrInvalidParams,
 	}
 }
 
@@ -39,6 +134,7 @@ func APIError(statusCode int, message string, response map[string]interface{}) *
 		Message:    message,
 		StatusCode: statusCode,
 		Response:   response,
+		Kind:       classifyStatus(statusCode, message),
 	}
 }
 
@@ -47,15 +143,132 @@ func NetworkError(message string, err error) *Error {
 	return &Error{
 		Message: fmt.Sprintf("network error: %s", message),
 		Err:     err,
+		Kind:    classifyNetwork(err),
+	}
+}
+
+// DecodeError creates an error for responses that could not be decoded
+func DecodeError(message string, err error) *Error {
+	return &Error{
+		Message: message,
+		Err:     err,
+		Kind:    ErrDecode,
 	}
 }
 
+// maxErrorBodyLength limits how much of a raw body is kept on decode errors
+const maxErrorBodyLength = 512
+
+// newDecodeError creates a decode error carrying the response status, content
+// type and a truncated copy of the body that failed to decode
+func newDecodeError(message string, err error, resp *http.Response, body []byte) *Error {
+	decodeErr := DecodeError(message, err)
+	decodeErr.StatusCode = resp.StatusCode
+	decodeErr.ContentType = resp.Header.Get("Content-Type")
+	decodeErr.Body = truncateBody(body, maxErrorBodyLength)
+	return decodeErr
+}
+
+// truncateBody returns body as a string, cut to at most limit bytes without
+// splitting a UTF-8 sequence
+func truncateBody(body []byte, limit int) string {
+	if len(body) <= limit {
+		return string(body)
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return string(body[:cut]) + "...(truncated)"
+}
+
+// messageKinds maps phrases of taapi.io error messages to error kinds. They
+// are matched in order, so the most specific phrases come first, and a
+// message naming both a symbol and an exchange, such as "symbol FOO/BAR not
+// found on exchange binance", is about the symbol.
+var messageKinds = []struct {
+	phrase string
+	kind   error
+}{
+	{"upgrade", ErrPlanLimit},
+	{"plan", ErrPlanLimit},
+	{"exchange not supported", ErrUnsupportedExchange},
+	{"unsupported exchange", ErrUnsupportedExchange},
+	{"invalid exchange", ErrUnsupportedExchange},
+	{"invalid symbol", ErrInvalidSymbol},
+	{"unknown symbol", ErrInvalidSymbol},
+	{"symbol not", ErrInvalidSymbol},
+	{"symbol", ErrInvalidSymbol},
+	{"exchange", ErrUnsupportedExchange},
+}
+
+// classifyMessage returns the kind of the first phrase the message contains
+func classifyMessage(message string) error {
+	msg := strings.ToLower(message)
+	for _, m := range messageKinds {
+		if strings.Contains(msg, m.phrase) {
+			return m.kind
+		}
+	}
+	return nil
+}
+
+// classifyStatus maps an HTTP status and API message to an error kind.
+// Statuses the API is not expected to return, such as redirects, are
+// classified as server errors.
+func classifyStatus(statusCode int, message string) error {
+	kind := classifyMessage(message)
+	switch {
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		if kind == ErrPlanLimit {
+			return ErrPlanLimit
+		}
+		return ErrUnauthorized
+	case statusCode == http.StatusPaymentRequired:
+		return ErrPlanLimit
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode >= 500:
+		return ErrServer
+	case statusCode >= 400:
+		if kind != nil {
+			return kind
+		}
+		return ErrInvalidParams
+	case statusCode == 0:
+		return ErrDecode
+	}
+	return ErrServer
+}
+
+// classifyNetwork maps a transport error to an error kind
+func classifyNetwork(err error) error {
+	if err == nil {
+		return ErrNetwork
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrCanceled
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrTimeout
+	}
+	return ErrNetwork
+}
+
 // RateLimitError represents a rate limit error
 type RateLimitError struct {
 	Message    string
 	StatusCode int
 	Response   map[string]interface{}
 	RetryAfter int
+	// Request describes the request that was rate limited, when known
+	Request *RequestInfo
+	// Meta holds HTTP metadata of the rate-limited response
+	Meta *ResponseMeta
 }
 
 // Error implements the error interface
@@ -63,6 +276,11 @@ func (e *RateLimitError) Error() string {
 	return fmt.Sprintf("taapi rate limit error [%d]: %s (retry after %d seconds)", e.StatusCode, e.Message, e.RetryAfter)
 }
 
+// Is reports whether the target is ErrRateLimited
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
 // NewRateLimitError creates a new rate limit error
 func NewRateLimitError(message string, retryAfter int, response map[string]interface{}) *RateLimitError {
 	return &RateLimitError{
@@ -75,12 +293,12 @@ func NewRateLimitError(message string, retryAfter int, response map[string]inter
 
 // IsRateLimitError checks if an error is a rate limit error
 func IsRateLimitError(err error) bool {
-	_, ok := err.(*RateLimitError)
-	return ok
+	var rateLimitErr *RateLimitError
+	return errors.As(err, &rateLimitErr)
 }
 
 // IsAPIError checks if an error is an API error
 func IsAPIError(err error) bool {
-	_, ok := err.(*Error)
-	return ok
+	var apiErr *Error
+	return errors.As(err, &apiErr)
 }
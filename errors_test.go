@@ -0,0 +1,162 @@
+package taapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorIsKind(t *testing.T) {
+	err := APIError(http.StatusUnauthorized, "invalid secret", nil)
+	assert.True(t, errors.Is(err, ErrUnauthorized))
+	assert.False(t, errors.Is(err, ErrServer))
+
+	wrapped := fmt.Errorf("fetching rsi: %w", err)
+	assert.True(t, errors.Is(wrapped, ErrUnauthorized))
+	assert.True(t, IsAPIError(wrapped))
+}
+
+func TestRateLimitErrorWrapped(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", NewRateLimitError("slow down", 10, nil))
+	assert.True(t, IsRateLimitError(err))
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.False(t, IsAPIError(err))
+
+	var rateLimitErr *RateLimitError
+	require.True(t, errors.As(err, &rateLimitErr))
+	assert.Equal(t, 10, rateLimitErr.RetryAfter)
+}
+
+func TestClassifyStatus(t *testing.T) {
+	tests := []struct {
+		status   int
+		message  string
+		expected error
+	}{
+		{http.StatusUnauthorized, "invalid api key", ErrUnauthorized},
+		{http.StatusForbidden, "forbidden", ErrUnauthorized},
+		{http.StatusForbidden, "please upgrade your plan", ErrPlanLimit},
+		{http.StatusPaymentRequired, "", ErrPlanLimit},
+		{http.StatusForbidden, "symbol not allowed", ErrUnauthorized},
+		{http.StatusBadRequest, "Invalid symbol: FOO/BAR", ErrInvalidSymbol},
+		{http.StatusBadRequest, "Exchange not supported", ErrUnsupportedExchange},
+		{http.StatusBadRequest, "Invalid exchange: foo", ErrUnsupportedExchange},
+		{http.StatusBadRequest, "Symbol FOO/BAR not found on exchange binance", ErrInvalidSymbol},
+		{http.StatusBadRequest, "Invalid symbol FOO/BAR for exchange binance", ErrInvalidSymbol},
+		{http.StatusBadRequest, "Exchange not supported for symbol BTC/USDT", ErrUnsupportedExchange},
+		{http.StatusBadRequest, "Bulk requests are not included in your plan", ErrPlanLimit},
+		{http.StatusBadRequest, "period must be a number", ErrInvalidParams},
+		{http.StatusNotFound, "", ErrInvalidParams},
+		{http.StatusTooManyRequests, "", ErrRateLimited},
+		{http.StatusBadGateway, "", ErrServer},
+		{http.StatusInternalServerError, "invalid symbol", ErrServer},
+		{http.StatusMovedPermanently, "", ErrServer},
+		{http.StatusNotModified, "", ErrServer},
+		{0, "", ErrDecode},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, classifyStatus(tt.status, tt.message), "status %d %q", tt.status, tt.message)
+	}
+}
+
+func TestInvalidArgumentErrorKind(t *testing.T) {
+	err := InvalidArgumentError("symbol is required")
+	assert.True(t, errors.Is(err, ErrInvalidParams))
+}
+
+func TestClientErrorCarriesRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"Invalid symbol"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL)
+	_, err := client.Direct().
+		Exchange(ExchangeBinance).
+		Symbol("FOO/BAR").
+		Interval(Interval1h).
+		Indicator(IndicatorRSI).
+		Get()
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidSymbol))
+
+	var apiErr *Error
+	require.True(t, errors.As(err, &apiErr))
+	require.NotNil(t, apiErr.Request)
+	assert.Equal(t, "/rsi", apiErr.Request.Endpoint)
+	assert.Equal(t, "FOO/BAR", apiErr.Request.Symbol)
+	assert.Equal(t, "rsi", apiErr.Request.Indicator)
+}
+
+func TestClientCanceledAndTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL)
+	builder := client.Direct().
+		Exchange(ExchangeBinance).
+		Symbol("BTC/USDT").
+		Interval(Interval1h).
+		Indicator(IndicatorRSI)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := builder.GetContext(ctx)
+	assert.True(t, errors.Is(err, ErrCanceled))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = builder.GetContext(ctx)
+	assert.True(t, errors.Is(err, ErrTimeout))
+}
+
+func TestClientDecodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html>oops</html>`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL)
+	_, err := client.Manual(IndicatorEMA).
+		WithCandles([][]interface{}{{1609459200, 1.0, 1.0, 1.0, 1.0, 0.0}}).
+		Execute()
+
+	assert.True(t, errors.Is(err, ErrDecode))
+	var apiErr *Error
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "ema", apiErr.Request.Indicator)
+	assert.Equal(t, http.StatusOK, apiErr.StatusCode)
+	assert.Equal(t, "text/html", apiErr.ContentType)
+	assert.Equal(t, "<html>oops</html>", apiErr.Body)
+
+	var syntaxErr *json.SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+	assert.Contains(t, err.Error(), "invalid character")
+}
+
+func TestTruncateBody(t *testing.T) {
+	assert.Equal(t, "short", truncateBody([]byte("short"), 10))
+	assert.Equal(t, "abc...(truncated)", truncateBody([]byte("abcdef"), 3))
+
+	// Multi-byte runes are not split
+	assert.Equal(t, "a...(truncated)", truncateBody([]byte("aéb"), 2))
+	assert.Equal(t, "aé...(truncated)", truncateBody([]byte("aéb"), 3))
+	assert.Equal(t, "...(truncated)", truncateBody([]byte("€uro"), 2))
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -16,7 +17,7 @@ func main() {
 
 	client := taapi.NewClient(apiSecret)
 
-	fmt.Println("=== TAAPI Go Library - Basic Usage Examples ===\n")
+	fmt.Print("=== TAAPI Go Library - Basic Usage Examples ===\n\n")
 
 	// Example 1: Simple RSI Request
 	fmt.Println("1. Simple RSI Request:")
@@ -112,11 +113,16 @@ func main() {
 		Get()
 
 	if err != nil {
-		if rateLimitErr, ok := err.(*taapi.RateLimitError); ok {
+		var rateLimitErr *taapi.RateLimitError
+		var apiErr *taapi.Error
+		switch {
+		case errors.As(err, &rateLimitErr):
 			fmt.Printf("   Rate limit error: retry after %d seconds\n", rateLimitErr.RetryAfter)
-		} else if apiErr, ok := err.(*taapi.Error); ok {
+		case errors.Is(err, taapi.ErrInvalidSymbol):
+			fmt.Printf("   Invalid symbol: %v\n", err)
+		case errors.As(err, &apiErr):
 			fmt.Printf("   API error [%d]: %s\n", apiErr.StatusCode, apiErr.Message)
-		} else {
+		default:
 			fmt.Printf("   Error: %v\n", err)
 		}
 	}
@@ -16,7 +16,7 @@ func main() {
 
 	client := taapi.NewClient(apiSecret)
 
-	fmt.Println("=== Manual Candles Example ===\n")
+	fmt.Print("=== Manual Candles Example ===\n\n")
 
 	// Sample candle data: [timestamp, open, high, low, close, volume]
 	candles := [][]interface{}{
@@ -29,16 +29,7 @@ func (e Exchange) String() string {
 	return string(e)
 }
 
-// IsValid checks if the exchange is valid
+// IsValid checks if the exchange is in the bundled market snapshot
 func (e Exchange) IsValid() bool {
-	switch e {
-	case ExchangeBinance, ExchangeBinanceUS, ExchangeBinanceUSDM,
-		ExchangeBitfinex, ExchangeBitget, ExchangeBitmex,
-		ExchangeBitstamp, ExchangeBybit, ExchangeCoinbase,
-		ExchangeCryptoCom, ExchangeGateIO, ExchangeHuobi,
-		ExchangeKraken, ExchangeKucoin, ExchangeMEXC,
-		ExchangeOKX, ExchangePhemex, ExchangePoloniex:
-		return true
-	}
-	return false
+	return DefaultMarkets().HasExchange(e)
 }
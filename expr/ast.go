@@ -0,0 +1,329 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// node is a node of the syntax tree
+type node interface {
+	// requests appends the indicator requests of the node
+	requests(out []taapi.ParamSet) []taapi.ParamSet
+	// shift returns the node evaluated n candles further back
+	shift(n int) node
+	String() string
+}
+
+// numNode is a node with a numeric value
+type numNode interface {
+	node
+	num(src Source) (float64, error)
+}
+
+// boolNode is a node with a boolean value
+type boolNode interface {
+	node
+	truth(src Source) (bool, error)
+}
+
+// numLit is a numeric literal
+type numLit float64
+
+func (n numLit) requests(out []taapi.ParamSet) []taapi.ParamSet { return out }
+func (n numLit) shift(int) node                                 { return n }
+func (n numLit) num(Source) (float64, error)                    { return float64(n), nil }
+func (n numLit) String() string                                 { return strconv.FormatFloat(float64(n), 'f', -1, 64) }
+
+// ref is an indicator output, offset candles back
+type ref struct {
+	indicator taapi.Indicator
+	params    map[string]interface{}
+	output    string
+	offset    int
+}
+
+// paramSet returns the request of the reference
+func (r *ref) paramSet() taapi.ParamSet {
+	p := taapi.NewIndicatorParams(r.indicator)
+	for k, v := range r.params {
+		p.Set(k, v)
+	}
+	if r.offset > 0 {
+		p.Set("backtrack", r.offset)
+	}
+	return p
+}
+
+func (r *ref) requests(out []taapi.ParamSet) []taapi.ParamSet {
+	return append(out, r.paramSet())
+}
+
+func (r *ref) shift(n int) node {
+	shifted := *r
+	shifted.offset += n
+	return &shifted
+}
+
+func (r *ref) num(src Source) (float64, error) {
+	return src.Value(r.paramSet(), r.output)
+}
+
+func (r *ref) String() string {
+	var b strings.Builder
+	if r.indicator == taapi.IndicatorCANDLE && priceFields[r.output] && len(r.params) == 0 {
+		b.WriteString(r.output)
+	} else {
+		keys := make([]string, 0, len(r.params))
+		for k := range r.params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		args := make([]string, len(keys))
+		for i, k := range keys {
+			args[i] = fmt.Sprintf("%s=%v", k, r.params[k])
+		}
+		fmt.Fprintf(&b, "%s(%s)", r.indicator, strings.Join(args, ", "))
+		if r.output != defaultOutput(r.indicator) {
+			b.WriteString("." + r.output)
+		}
+	}
+	if r.offset > 0 {
+		fmt.Fprintf(&b, "[%d]", r.offset)
+	}
+	return b.String()
+}
+
+// arith is a binary arithmetic operation
+type arith struct {
+	op   string
+	a, b numNode
+}
+
+func (n *arith) requests(out []taapi.ParamSet) []taapi.ParamSet {
+	return n.b.requests(n.a.requests(out))
+}
+
+func (n *arith) shift(k int) node {
+	return &arith{op: n.op, a: n.a.shift(k).(numNode), b: n.b.shift(k).(numNode)}
+}
+
+func (n *arith) num(src Source) (float64, error) {
+	a, err := n.a.num(src)
+	if err != nil {
+		return 0, err
+	}
+	b, err := n.b.num(src)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	}
+	if b == 0 {
+		return 0, errors.New("expr: division by zero")
+	}
+	return a / b, nil
+}
+
+func (n *arith) String() string {
+	return fmt.Sprintf("(%s %s %s)", n.a, n.op, n.b)
+}
+
+// neg negates a number
+type neg struct {
+	x numNode
+}
+
+func (n *neg) requests(out []taapi.ParamSet) []taapi.ParamSet { return n.x.requests(out) }
+func (n *neg) shift(k int) node                               { return &neg{x: n.x.shift(k).(numNode)} }
+func (n *neg) String() string                                 { return "-" + n.x.String() }
+
+func (n *neg) num(src Source) (float64, error) {
+	x, err := n.x.num(src)
+	return -x, err
+}
+
+// call is a numeric function call
+type call struct {
+	name string
+	args []numNode
+}
+
+func (n *call) requests(out []taapi.ParamSet) []taapi.ParamSet {
+	for _, arg := range n.args {
+		out = arg.requests(out)
+	}
+	return out
+}
+
+func (n *call) shift(k int) node {
+	args := make([]numNode, len(n.args))
+	for i, arg := range n.args {
+		args[i] = arg.shift(k).(numNode)
+	}
+	return &call{name: n.name, args: args}
+}
+
+func (n *call) num(src Source) (float64, error) {
+	values := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.num(src)
+		if err != nil {
+			return 0, err
+		}
+		values[i] = v
+	}
+	switch n.name {
+	case "abs":
+		return math.Abs(values[0]), nil
+	case "min":
+		return math.Min(values[0], values[1]), nil
+	default:
+		return math.Max(values[0], values[1]), nil
+	}
+}
+
+func (n *call) String() string {
+	args := make([]string, len(n.args))
+	for i, arg := range n.args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("%s(%s)", n.name, strings.Join(args, ", "))
+}
+
+// compare compares two numbers
+type compare struct {
+	op   string
+	a, b numNode
+}
+
+func (n *compare) requests(out []taapi.ParamSet) []taapi.ParamSet {
+	return n.b.requests(n.a.requests(out))
+}
+
+func (n *compare) shift(k int) node {
+	return &compare{op: n.op, a: n.a.shift(k).(numNode), b: n.b.shift(k).(numNode)}
+}
+
+func (n *compare) truth(src Source) (bool, error) {
+	a, err := n.a.num(src)
+	if err != nil {
+		return false, err
+	}
+	b, err := n.b.num(src)
+	if err != nil {
+		return false, err
+	}
+	switch n.op {
+	case "<":
+		return a < b, nil
+	case "<=":
+		return a <= b, nil
+	case ">":
+		return a > b, nil
+	case ">=":
+		return a >= b, nil
+	case "==":
+		return a == b, nil
+	}
+	return a != b, nil
+}
+
+func (n *compare) String() string {
+	return fmt.Sprintf("%s %s %s", n.a, n.op, n.b)
+}
+
+// logic combines two conditions with and or or
+type logic struct {
+	and  bool
+	a, b boolNode
+}
+
+func (n *logic) requests(out []taapi.ParamSet) []taapi.ParamSet {
+	return n.b.requests(n.a.requests(out))
+}
+
+func (n *logic) shift(k int) node {
+	return &logic{and: n.and, a: n.a.shift(k).(boolNode), b: n.b.shift(k).(boolNode)}
+}
+
+func (n *logic) truth(src Source) (bool, error) {
+	a, err := n.a.truth(src)
+	if err != nil || a != n.and {
+		return a, err
+	}
+	return n.b.truth(src)
+}
+
+func (n *logic) String() string {
+	op := "or"
+	if n.and {
+		op = "and"
+	}
+	return fmt.Sprintf("(%s %s %s)", n.a, op, n.b)
+}
+
+// not negates a condition
+type not struct {
+	x boolNode
+}
+
+func (n *not) requests(out []taapi.ParamSet) []taapi.ParamSet { return n.x.requests(out) }
+func (n *not) shift(k int) node                               { return &not{x: n.x.shift(k).(boolNode)} }
+func (n *not) String() string                                 { return "not " + n.x.String() }
+
+func (n *not) truth(src Source) (bool, error) {
+	x, err := n.x.truth(src)
+	return !x, err
+}
+
+// cross detects a crossover between the previous and the current candle
+type cross struct {
+	above bool
+	a, b  numNode
+}
+
+func (n *cross) requests(out []taapi.ParamSet) []taapi.ParamSet {
+	out = n.b.requests(n.a.requests(out))
+	return n.b.shift(1).requests(n.a.shift(1).requests(out))
+}
+
+func (n *cross) shift(k int) node {
+	return &cross{above: n.above, a: n.a.shift(k).(numNode), b: n.b.shift(k).(numNode)}
+}
+
+func (n *cross) truth(src Source) (bool, error) {
+	var values [4]float64
+	nodes := [4]numNode{n.a.shift(1).(numNode), n.b.shift(1).(numNode), n.a, n.b}
+	for i, x := range nodes {
+		v, err := x.num(src)
+		if err != nil {
+			return false, err
+		}
+		values[i] = v
+	}
+	prevA, prevB, a, b := values[0], values[1], values[2], values[3]
+	if n.above {
+		return prevA <= prevB && a > b, nil
+	}
+	return prevA >= prevB && a < b, nil
+}
+
+func (n *cross) String() string {
+	name := "crosses_below"
+	if n.above {
+		name = "crosses_above"
+	}
+	return fmt.Sprintf("%s(%s, %s)", name, n.a, n.b)
+}
@@ -0,0 +1,199 @@
+// Package expr implements a small expression language over indicator
+// outputs, for alerts and screeners:
+//
+//	rsi(14) < 30 and close > ema(200)
+//	crosses_above(ema20, ema50)
+//	macd.valueMACD > macd.valueMACDSignal and abs(close - bbands(20).valueMiddleBand) < 50
+//
+// Indicators are written name(args).output[offset]. A positional argument
+// is the period, other parameters are named, e.g. bbands(20, stddev=2).
+// ema20 is short for ema(20), and open, high, low, close and volume refer to
+// the latest candle. The output defaults to value; offset n reads the value
+// n candles back. crosses_above and crosses_below compare the previous and
+// the current candle. Expressions support + - * /, comparisons, and, or,
+// not and the functions abs, min and max.
+//
+// Compiling an expression validates indicators, parameters and outputs
+// against the indicator catalog and resolves the indicator requests it
+// needs.
+package expr
+
+import (
+	"context"
+
+	"github.com/tigusigalpa/taapi-go"
+	"github.com/tigusigalpa/taapi-go/screener"
+)
+
+// Source provides indicator outputs to expressions. *screener.Results
+// implements it.
+type Source interface {
+	Value(p taapi.ParamSet, output string) (float64, error)
+}
+
+// Expression is a compiled expression
+type Expression struct {
+	src      string
+	root     node
+	requests []taapi.ParamSet
+}
+
+// Compile parses an expression
+func Compile(src string) (*Expression, error) {
+	root, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return newExpression(src, root)
+}
+
+// CrossesAbove returns the condition crosses_above(a, b) over the value
+// outputs of two indicator requests
+func CrossesAbove(a, b taapi.ParamSet) (*Expression, error) {
+	return crosses(true, a, b)
+}
+
+// CrossesBelow returns the condition crosses_below(a, b) over the value
+// outputs of two indicator requests
+func CrossesBelow(a, b taapi.ParamSet) (*Expression, error) {
+	return crosses(false, a, b)
+}
+
+// crosses builds a crossover of two indicator requests
+func crosses(above bool, a, b taapi.ParamSet) (*Expression, error) {
+	for _, p := range []taapi.ParamSet{a, b} {
+		info, ok := taapi.LookupIndicator(p.Indicator())
+		if !ok {
+			return nil, taapi.InvalidArgumentError("expr: unknown indicator " + p.Indicator().String())
+		}
+		if !hasOutput(info, "value") {
+			return nil, taapi.InvalidArgumentError("expr: " + p.Indicator().String() + " has no output value")
+		}
+	}
+	root := &cross{above: above, a: refOf(a), b: refOf(b)}
+	return newExpression(root.String(), root)
+}
+
+// refOf returns the reference to the value output of an indicator request
+func refOf(p taapi.ParamSet) *ref {
+	r := &ref{indicator: p.Indicator(), params: make(map[string]interface{}), output: "value"}
+	for k, v := range p.Params() {
+		if k == "backtrack" {
+			r.offset, _ = v.(int)
+			continue
+		}
+		r.params[k] = v
+	}
+	return r
+}
+
+// newExpression validates and collects the distinct indicator requests of
+// a parsed expression
+func newExpression(src string, root node) (*Expression, error) {
+	e := &Expression{src: src, root: root}
+	seen := make(map[string]bool)
+	for _, p := range root.requests(nil) {
+		key := screener.RequestKey(p)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		// Crossovers shift their operands a candle back, so their backtracked
+		// requests were not validated while parsing
+		if err := p.Indicator().Info().ValidateParams(p.Params()); err != nil {
+			return nil, taapi.InvalidArgumentError("expr: " + err.Error())
+		}
+		e.requests = append(e.requests, p)
+	}
+	return e, nil
+}
+
+// MustCompile is like Compile but panics on errors
+func MustCompile(src string) *Expression {
+	e, err := Compile(src)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// String returns the source of the expression
+func (e *Expression) String() string {
+	return e.src
+}
+
+// Canonical returns the expression with every reference written out in
+// full, e.g. ema20 as ema(period=20)
+func (e *Expression) Canonical() string {
+	return e.root.String()
+}
+
+// IsCondition reports whether the expression is boolean
+func (e *Expression) IsCondition() bool {
+	_, ok := e.root.(boolNode)
+	return ok
+}
+
+// Requests returns the distinct indicator requests the expression needs
+func (e *Expression) Requests() []taapi.ParamSet {
+	return append([]taapi.ParamSet(nil), e.requests...)
+}
+
+// Bool evaluates a condition
+func (e *Expression) Bool(src Source) (bool, error) {
+	b, ok := e.root.(boolNode)
+	if !ok {
+		return false, taapi.InvalidArgumentError("expr: " + e.src + " is not a condition")
+	}
+	return b.truth(src)
+}
+
+// Float evaluates a numeric expression
+func (e *Expression) Float(src Source) (float64, error) {
+	n, ok := e.root.(numNode)
+	if !ok {
+		return 0, taapi.InvalidArgumentError("expr: " + e.src + " is not numeric")
+	}
+	return n.num(src)
+}
+
+// Target is the market an expression is evaluated on
+type Target struct {
+	Type     taapi.AssetType
+	Exchange taapi.Exchange
+	Symbol   string
+	Interval taapi.Interval
+}
+
+// Resolve fetches the indicator requests of the expression for the target
+// in bulk requests. Errors taapi.io reports for an indicator are returned
+// as the error.
+func (e *Expression) Resolve(ctx context.Context, executor taapi.BulkExecutor, target Target) (*screener.Results, error) {
+	results, err := screener.New(executor).
+		Type(target.Type).
+		Exchange(target.Exchange).
+		Interval(target.Interval).
+		Symbols(target.Symbol).
+		Where(e.Condition()).
+		Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := results[0].Err(); err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// Check fetches the indicators of a condition for the target and evaluates
+// it
+func (e *Expression) Check(ctx context.Context, executor taapi.BulkExecutor, target Target) (bool, error) {
+	if !e.IsCondition() {
+		return false, taapi.InvalidArgumentError("expr: " + e.src + " is not a condition")
+	}
+	results, err := e.Resolve(ctx, executor, target)
+	if err != nil {
+		return false, err
+	}
+	return e.Bool(results)
+}
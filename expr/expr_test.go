@@ -0,0 +1,193 @@
+package expr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigusigalpa/taapi-go"
+	"github.com/tigusigalpa/taapi-go/screener"
+	"github.com/tigusigalpa/taapi-go/taapitest"
+)
+
+// value wraps a single output as a bulk item
+func value(fields map[string]interface{}) *taapi.IndicatorResponse {
+	return &taapi.IndicatorResponse{Data: map[string]interface{}{"result": fields}}
+}
+
+// crossResults scripts EMA 20 and 50 for the previous and current candle
+func crossResults(prevFast, prevSlow, fast, slow float64) *screener.Results {
+	return screener.NewResults("BTC/USDT").
+		Set(taapi.EMA().Period(20), value(map[string]interface{}{"value": fast})).
+		Set(taapi.EMA().Period(50), value(map[string]interface{}{"value": slow})).
+		Set(taapi.EMA().Period(20).Backtrack(1), value(map[string]interface{}{"value": prevFast})).
+		Set(taapi.EMA().Period(50).Backtrack(1), value(map[string]interface{}{"value": prevSlow}))
+}
+
+func TestExpressionBool(t *testing.T) {
+	results := screener.NewResults("BTC/USDT").
+		Set(taapi.RSI().Period(14), value(map[string]interface{}{"value": 25.0})).
+		Set(taapi.NewIndicatorParams(taapi.IndicatorCANDLE), value(map[string]interface{}{
+			"open": 100.0, "high": 110.0, "low": 95.0, "close": 105.0, "volume": 0.0,
+		})).
+		Set(taapi.MACD(), value(map[string]interface{}{
+			"valueMACD": 1.5, "valueMACDSignal": 1.0, "valueMACDHist": 0.5,
+		}))
+
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{"rsi(14) < 30", true},
+		{"rsi(14) >= 30", false},
+		{"rsi(14) < 30 and close > open", true},
+		{"rsi(14) > 30 or volume == 0", true},
+		{"not (close > open)", false},
+		{"(close - open) / open * 100 == 5", true},
+		{"high - low > 2 * abs(close - open)", true},
+		{"max(open, close) == 105 and min(open, close) != 105", true},
+		{"macd.valueMACD > macd.valueMACDSignal", true},
+		{"-rsi(14) < -20", true},
+	}
+	for _, tt := range tests {
+		got, err := MustCompile(tt.src).Bool(results)
+		require.NoError(t, err, tt.src)
+		assert.Equal(t, tt.want, got, tt.src)
+	}
+
+	// Short-circuiting skips missing results
+	ok, err := MustCompile("rsi(14) > 30 and ema(200) > 0").Bool(results)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = MustCompile("ema(200) > 0").Bool(results)
+	assert.Error(t, err)
+	_, err = MustCompile("close / volume > 1").Bool(results)
+	assert.EqualError(t, err, "expr: division by zero")
+	_, err = MustCompile("close").Bool(results)
+	assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+}
+
+func TestExpressionFloat(t *testing.T) {
+	results := screener.NewResults("BTC/USDT").
+		Set(taapi.NewIndicatorParams(taapi.IndicatorCANDLE), value(map[string]interface{}{"close": 120.0})).
+		Set(taapi.EMA().Period(200), value(map[string]interface{}{"value": 100.0}))
+
+	e := MustCompile("close / ema(200)")
+	assert.False(t, e.IsCondition())
+	v, err := e.Float(results)
+	require.NoError(t, err)
+	assert.Equal(t, 1.2, v)
+
+	_, err = MustCompile("close > 1").Float(results)
+	assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+}
+
+func TestExpressionCrossovers(t *testing.T) {
+	above := MustCompile("crosses_above(ema20, ema50)")
+	below := MustCompile("crosses_below(ema20, ema50)")
+	tests := []struct {
+		name                           string
+		prevFast, prevSlow, fast, slow float64
+		above, below                   bool
+	}{
+		{"cross up", 9, 10, 11, 10, true, false},
+		{"touch then up", 10, 10, 11, 10, true, false},
+		{"cross down", 11, 10, 9, 10, false, true},
+		{"stays above", 11, 10, 12, 10, false, false},
+		{"stays below", 8, 10, 9, 10, false, false},
+	}
+	for _, tt := range tests {
+		results := crossResults(tt.prevFast, tt.prevSlow, tt.fast, tt.slow)
+		got, err := above.Bool(results)
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.above, got, tt.name)
+		got, err = below.Bool(results)
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.below, got, tt.name)
+	}
+}
+
+func TestCrossesFromParamSets(t *testing.T) {
+	above, err := CrossesAbove(taapi.EMA().Period(20), taapi.EMA().Period(50))
+	require.NoError(t, err)
+	assert.Equal(t, "crosses_above(ema(period=20), ema(period=50))", above.String())
+	assert.Equal(t, MustCompile("crosses_above(ema20, ema50)").Requests(), above.Requests())
+
+	ok, err := above.Bool(crossResults(9, 10, 11, 10))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	below, err := CrossesBelow(taapi.EMA().Period(20).Backtrack(1), taapi.EMA().Period(50).Backtrack(1))
+	require.NoError(t, err)
+	assert.Equal(t, "crosses_below(ema(period=20)[1], ema(period=50)[1])", below.String())
+	assert.Equal(t, "ema(backtrack=2,period=20)", screener.RequestKey(below.Requests()[2]))
+
+	_, err = CrossesAbove(taapi.MACD(), taapi.EMA())
+	assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+	_, err = CrossesAbove(taapi.NewIndicatorParams("foo"), taapi.EMA())
+	assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+}
+
+func TestExpressionCondition(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.HandleFunc(taapi.IndicatorRSI, func(r taapitest.Request) taapitest.Response {
+		if r.Param("symbol") == "ETH/USDT" {
+			return taapitest.Value(45)
+		}
+		return taapitest.Value(25)
+	})
+
+	result, err := screener.New(server.Client()).
+		Exchange(taapi.ExchangeBinance).
+		Interval(taapi.Interval1h).
+		Symbols("BTC/USDT", "ETH/USDT").
+		Where(MustCompile("rsi(14) < 30").Condition()).
+		RankBy(MustCompile("rsi(14) * 2").Operand(), screener.Ascending).
+		Run(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, result.Matches, 1)
+	assert.Equal(t, "BTC/USDT", result.Matches[0].Symbol)
+	assert.Equal(t, 50.0, result.Matches[0].Score)
+}
+
+func TestExpressionCheck(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.HandleFunc(taapi.IndicatorEMA, func(r taapitest.Request) taapitest.Response {
+		// EMA 20 crosses above EMA 50 on the latest candle
+		fast := r.Param("period") == "20"
+		if r.Param("backtrack") == "1" {
+			if fast {
+				return taapitest.Value(99)
+			}
+			return taapitest.Value(100)
+		}
+		if fast {
+			return taapitest.Value(101)
+		}
+		return taapitest.Value(100)
+	})
+
+	target := Target{Exchange: taapi.ExchangeBinance, Symbol: "BTC/USDT", Interval: taapi.Interval4h}
+	e := MustCompile("crosses_above(ema20, ema50)")
+	ok, err := e.Check(context.Background(), server.Client(), target)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	server.AssertCalled(t, taapi.IndicatorEMA, 4)
+
+	results, err := e.Resolve(context.Background(), server.Client(), target)
+	require.NoError(t, err)
+	assert.Equal(t, "BTC/USDT", results.Symbol)
+
+	_, err = MustCompile("ema20").Check(context.Background(), server.Client(), target)
+	assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+
+	server.Handle(taapi.IndicatorRSI, taapitest.InvalidSymbol("BTC/USDT"))
+	_, err = MustCompile("rsi < 30").Check(context.Background(), server.Client(), target)
+	assert.Error(t, err)
+}
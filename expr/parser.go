@@ -0,0 +1,526 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// priceFields are the candle outputs usable as bare identifiers
+var priceFields = map[string]bool{
+	"open":   true,
+	"high":   true,
+	"low":    true,
+	"close":  true,
+	"volume": true,
+}
+
+// functions maps numeric and crossover functions to their arity
+var functions = map[string]int{
+	"abs":           1,
+	"min":           2,
+	"max":           2,
+	"crosses_above": 2,
+	"crosses_below": 2,
+}
+
+// periodShorthand matches identifiers such as ema20 or sma200
+var periodShorthand = regexp.MustCompile(`^([a-z]+?)([0-9]+)$`)
+
+// syntaxError returns an invalid parameters error pointing at an offset of
+// the source
+func syntaxError(pos int, format string, args ...interface{}) error {
+	return taapi.InvalidArgumentError(fmt.Sprintf("expr: %s at offset %d", fmt.Sprintf(format, args...), pos))
+}
+
+// parser is a recursive descent parser over the tokens of an expression
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parse parses an expression
+func parse(src string) (node, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, syntaxError(t.pos, "unexpected %s", t)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of the operators or keywords
+func (p *parser) accept(texts ...string) (token, bool) {
+	t := p.peek()
+	if t.kind != tokenOp && t.kind != tokenIdent {
+		return t, false
+	}
+	for _, text := range texts {
+		if strings.EqualFold(t.text, text) {
+			return p.next(), true
+		}
+	}
+	return t, false
+}
+
+// expect consumes an operator or fails
+func (p *parser) expect(text string) error {
+	if t, ok := p.accept(text); !ok {
+		return syntaxError(t.pos, "expected %q, found %s", text, t)
+	}
+	return nil
+}
+
+// or := and {("or" | "||") and}
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.accept("or", "||")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		a, b, err := bothBool(t, left, right)
+		if err != nil {
+			return nil, err
+		}
+		left = &logic{a: a, b: b}
+	}
+}
+
+// and := not {("and" | "&&") not}
+func (p *parser) and() (node, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.accept("and", "&&")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		a, b, err := bothBool(t, left, right)
+		if err != nil {
+			return nil, err
+		}
+		left = &logic{and: true, a: a, b: b}
+	}
+}
+
+// not := ("not" | "!") not | comparison
+func (p *parser) not() (node, error) {
+	t, ok := p.accept("not", "!")
+	if !ok {
+		return p.comparison()
+	}
+	x, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	b, ok := x.(boolNode)
+	if !ok {
+		return nil, syntaxError(t.pos, "not needs a condition, found %s", x)
+	}
+	return &not{x: b}, nil
+}
+
+// comparison := sum [op sum]
+func (p *parser) comparison() (node, error) {
+	left, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	t, ok := p.accept("<", "<=", ">", ">=", "==", "!=")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	a, b, err := bothNum(t, left, right)
+	if err != nil {
+		return nil, err
+	}
+	return &compare{op: t.text, a: a, b: b}, nil
+}
+
+// sum := product {("+" | "-") product}
+func (p *parser) sum() (node, error) {
+	left, err := p.product()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.accept("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.product()
+		if err != nil {
+			return nil, err
+		}
+		a, b, err := bothNum(t, left, right)
+		if err != nil {
+			return nil, err
+		}
+		left = &arith{op: t.text, a: a, b: b}
+	}
+}
+
+// product := unary {("*" | "/") unary}
+func (p *parser) product() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.accept("*", "/")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		a, b, err := bothNum(t, left, right)
+		if err != nil {
+			return nil, err
+		}
+		left = &arith{op: t.text, a: a, b: b}
+	}
+}
+
+// unary := "-" unary | primary
+func (p *parser) unary() (node, error) {
+	t, ok := p.accept("-")
+	if !ok {
+		return p.primary()
+	}
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	n, ok := x.(numNode)
+	if !ok {
+		return nil, syntaxError(t.pos, "cannot negate %s", x)
+	}
+	if lit, ok := n.(numLit); ok {
+		return -lit, nil
+	}
+	return &neg{x: n}, nil
+}
+
+// primary := number | "(" or ")" | function | reference
+func (p *parser) primary() (node, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenNumber:
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, syntaxError(t.pos, "invalid number %s", t)
+		}
+		return numLit(v), nil
+	case t.kind == tokenOp && t.text == "(":
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case t.kind == tokenIdent:
+		name := strings.ToLower(t.text)
+		switch name {
+		case "and", "or", "not":
+			return nil, syntaxError(t.pos, "unexpected %s", t)
+		}
+		if arity, ok := functions[name]; ok {
+			return p.function(t, name, arity)
+		}
+		return p.reference(t, name)
+	}
+	return nil, syntaxError(t.pos, "unexpected %s", t)
+}
+
+// function parses the arguments of a function call
+func (p *parser) function(t token, name string, arity int) (node, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var args []numNode
+	for {
+		x, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		n, ok := x.(numNode)
+		if !ok {
+			return nil, syntaxError(t.pos, "%s needs numeric arguments, found %s", name, x)
+		}
+		args = append(args, n)
+		more, err := p.comma()
+		if err != nil {
+			return nil, err
+		}
+		if !more {
+			break
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	if len(args) != arity {
+		return nil, syntaxError(t.pos, "%s takes %d arguments, got %d", name, arity, len(args))
+	}
+
+	switch name {
+	case "crosses_above", "crosses_below":
+		return &cross{above: name == "crosses_above", a: args[0], b: args[1]}, nil
+	}
+	return &call{name: name, args: args}, nil
+}
+
+// reference parses an indicator reference: name[(args)][.output][[offset]].
+// Bare price fields such as close refer to the latest candle, and names such
+// as ema20 are short for ema(period=20).
+func (p *parser) reference(t token, name string) (node, error) {
+	r := &ref{params: make(map[string]interface{})}
+
+	switch {
+	case priceFields[name]:
+		r.indicator, r.output = taapi.IndicatorCANDLE, name
+	case p.peek().text == "(":
+		r.indicator = taapi.Indicator(name)
+		if err := p.arguments(r); err != nil {
+			return nil, err
+		}
+	default:
+		r.indicator = taapi.Indicator(name)
+		if _, ok := taapi.LookupIndicator(r.indicator); !ok {
+			if m := periodShorthand.FindStringSubmatch(name); m != nil {
+				period, err := strconv.Atoi(m[2])
+				if err != nil {
+					return nil, syntaxError(t.pos, "invalid period in %s", t)
+				}
+				r.indicator = taapi.Indicator(m[1])
+				r.params["period"] = period
+			}
+		}
+	}
+
+	info, ok := taapi.LookupIndicator(r.indicator)
+	if !ok {
+		return nil, syntaxError(t.pos, "unknown indicator %s", t)
+	}
+	if _, positional := r.params[""]; positional {
+		if !hasParam(info, "period") {
+			return nil, syntaxError(t.pos, "%s takes no period; name the parameter", info.Indicator)
+		}
+		if _, named := r.params["period"]; named {
+			return nil, syntaxError(t.pos, "duplicate argument \"period\"")
+		}
+		r.params["period"] = r.params[""]
+		delete(r.params, "")
+	}
+
+	if _, ok := p.accept("."); ok {
+		out := p.next()
+		if out.kind != tokenIdent {
+			return nil, syntaxError(out.pos, "expected an output name, found %s", out)
+		}
+		r.output = out.text
+	}
+	if r.output == "" {
+		r.output = defaultOutput(r.indicator)
+		if r.output == "" {
+			return nil, syntaxError(t.pos, "%s has several outputs (%s); select one, e.g. %s.%s",
+				info.Indicator, strings.Join(info.Outputs, ", "), info.Indicator, info.Outputs[0])
+		}
+	}
+	if !hasOutput(info, r.output) {
+		return nil, syntaxError(t.pos, "%s has no output %s", info.Indicator, r.output)
+	}
+
+	if _, ok := p.accept("["); ok {
+		offset := p.next()
+		n, err := strconv.Atoi(offset.text)
+		if offset.kind != tokenNumber || err != nil || n < 0 {
+			return nil, syntaxError(offset.pos, "expected a candle offset, found %s", offset)
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		r.offset = n
+	}
+
+	if err := info.ValidateParams(r.paramSet().Params()); err != nil {
+		return nil, syntaxError(t.pos, "%v", err)
+	}
+	return r, nil
+}
+
+// arguments parses "(" [arg {"," arg}] ")" where arg is a value or
+// name=value. A positional value is stored under the empty name.
+func (p *parser) arguments(r *ref) error {
+	p.next()
+	if _, ok := p.accept(")"); ok {
+		return nil
+	}
+	for {
+		name := ""
+		if t := p.peek(); t.kind == tokenIdent && p.tokens[p.pos+1].text == "=" {
+			name = t.text
+			p.pos += 2
+		}
+		if _, exists := r.params[name]; exists {
+			if name == "" {
+				return syntaxError(p.peek().pos, "too many arguments to %s", r.indicator)
+			}
+			return syntaxError(p.peek().pos, "duplicate argument %q", name)
+		}
+		v, err := p.literal()
+		if err != nil {
+			return err
+		}
+		r.params[name] = v
+
+		more, err := p.comma()
+		if err != nil {
+			return err
+		}
+		if !more {
+			break
+		}
+	}
+	return p.expect(")")
+}
+
+// comma accepts the comma between two arguments and reports whether one was
+// found, rejecting a comma followed by the closing parenthesis
+func (p *parser) comma() (bool, error) {
+	comma, ok := p.accept(",")
+	if !ok {
+		return false, nil
+	}
+	if p.peek().text == ")" {
+		return false, syntaxError(comma.pos, "trailing comma in arguments")
+	}
+	return true, nil
+}
+
+// literal parses a parameter value: a number, a word or true/false
+func (p *parser) literal() (interface{}, error) {
+	sign := ""
+	if _, ok := p.accept("-"); ok {
+		sign = "-"
+	}
+	t := p.next()
+	switch {
+	case t.kind == tokenNumber:
+		if i, err := strconv.Atoi(sign + t.text); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(sign+t.text, 64)
+		if err != nil {
+			return nil, syntaxError(t.pos, "invalid number %s", t)
+		}
+		return f, nil
+	case t.kind == tokenIdent && sign == "":
+		switch strings.ToLower(t.text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return t.text, nil
+	}
+	return nil, syntaxError(t.pos, "expected a parameter value, found %s", t)
+}
+
+// bothBool checks that both operands of a logical operator are conditions
+func bothBool(t token, left, right node) (boolNode, boolNode, error) {
+	a, okA := left.(boolNode)
+	b, okB := right.(boolNode)
+	if !okA || !okB {
+		return nil, nil, syntaxError(t.pos, "%s needs conditions on both sides", t)
+	}
+	return a, b, nil
+}
+
+// bothNum checks that both operands of an operator are numbers
+func bothNum(t token, left, right node) (numNode, numNode, error) {
+	a, okA := left.(numNode)
+	b, okB := right.(numNode)
+	if !okA || !okB {
+		return nil, nil, syntaxError(t.pos, "%s needs numbers on both sides", t)
+	}
+	return a, b, nil
+}
+
+// defaultOutput returns the output used when none is selected: value, or
+// the only output of the indicator
+func defaultOutput(indicator taapi.Indicator) string {
+	info, ok := taapi.LookupIndicator(indicator)
+	if !ok {
+		return ""
+	}
+	if hasOutput(info, "value") {
+		return "value"
+	}
+	if len(info.Outputs) == 1 {
+		return info.Outputs[0]
+	}
+	return ""
+}
+
+func hasOutput(info *taapi.IndicatorInfo, output string) bool {
+	for _, o := range info.Outputs {
+		if o == output {
+			return true
+		}
+	}
+	return false
+}
+
+func hasParam(info *taapi.IndicatorInfo, name string) bool {
+	for _, p := range info.Params {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigusigalpa/taapi-go"
+	"github.com/tigusigalpa/taapi-go/screener"
+)
+
+func TestCompileCanonical(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"rsi < 30", "rsi() < 30"},
+		{"rsi(14) < 30", "rsi(period=14) < 30"},
+		{"ema20 > ema50", "ema(period=20) > ema(period=50)"},
+		{"close > ema(200)", "close > ema(period=200)"},
+		{"close[1] < open", "close[1] < open"},
+		{"bbands(20, stddev=2).valueUpperBand < high", "bbands(period=20, stddev=2).valueUpperBand < high"},
+		{"macd.valueMACD > macd.valueMACDSignal", "macd().valueMACD > macd().valueMACDSignal"},
+		{"rsi(14) < 30 and close > 1 or not volume > 0", "((rsi(period=14) < 30 and close > 1) or not volume > 0)"},
+		{"RSI(14) < 30 && close > 1 || !(volume > 0)", "((rsi(period=14) < 30 and close > 1) or not volume > 0)"},
+		{"abs(close - open) / open * 100 >= 2", "((abs((close - open)) / open) * 100) >= 2"},
+		{"-close < -1.5", "-close < -1.5"},
+		{"max(ema20, sma20) != min(high, low)", "max(ema(period=20), sma(period=20)) != min(high, low)"},
+		{"crosses_above(ema20, ema50)", "crosses_above(ema(period=20), ema(period=50))"},
+		{"crosses_below(close, ema(200)[2])", "crosses_below(close, ema(period=200)[2])"},
+	}
+	for _, tt := range tests {
+		e, err := Compile(tt.src)
+		require.NoError(t, err, tt.src)
+		assert.Equal(t, tt.want, e.Canonical(), tt.src)
+		assert.Equal(t, tt.src, e.String())
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"", "unexpected end of expression at offset 0"},
+		{"rsi < 30 $", "unexpected character '$' at offset 9"},
+		{"rsi <", "unexpected end of expression at offset 5"},
+		{"(rsi < 30", "expected \")\", found end of expression at offset 9"},
+		{"foo < 30", "unknown indicator \"foo\" at offset 0"},
+		{"rsi.upper < 30", "rsi has no output upper"},
+		{"macd > 0", "macd has several outputs (valueMACD, valueMACDSignal, valueMACDHist)"},
+		{"rsi(period=0) < 30", "period"},
+		{"rsi(14, period=14) < 30", "duplicate argument \"period\""},
+		{"rsi(14, 15) < 30", "too many arguments to rsi at offset 8"},
+		{"rsi(14,) < 30", "trailing comma in arguments at offset 6"},
+		{"abs(close,) > 1", "trailing comma in arguments at offset 9"},
+		{"crosses_above(ema20, close,)", "trailing comma in arguments at offset 26"},
+		{"rsi99999999999999999999 < 30", "invalid period in \"rsi99999999999999999999\" at offset 0"},
+		{"rsi[x] < 30", "expected a candle offset"},
+		{"rsi and close", "\"and\" needs conditions on both sides"},
+		{"rsi < 30 < 40", "unexpected \"<\""},
+		{"not close", "not needs a condition"},
+		{"-(rsi < 30)", "cannot negate"},
+		{"abs(close, open) > 1", "abs takes 1 arguments, got 2"},
+		{"crosses_above(rsi < 30, close)", "crosses_above needs numeric arguments"},
+		{"close + (rsi < 30) > 1", "\"+\" needs numbers on both sides"},
+	}
+	for _, tt := range tests {
+		_, err := Compile(tt.src)
+		require.Error(t, err, tt.src)
+		assert.ErrorIs(t, err, taapi.ErrInvalidParams, tt.src)
+		assert.Contains(t, err.Error(), tt.want, tt.src)
+	}
+}
+
+func TestCompileRequests(t *testing.T) {
+	e := MustCompile("rsi(14) < 30 and rsi(14)[1] > 30 and crosses_above(ema20, close)")
+
+	var keys []string
+	for _, p := range e.Requests() {
+		keys = append(keys, screener.RequestKey(p))
+	}
+	assert.Equal(t, []string{
+		"rsi(period=14)",
+		"rsi(backtrack=1,period=14)",
+		"ema(period=20)",
+		"candle()",
+		"ema(backtrack=1,period=20)",
+		"candle(backtrack=1)",
+	}, keys)
+}
+
+func TestMustCompilePanics(t *testing.T) {
+	assert.Panics(t, func() { MustCompile("rsi <") })
+}
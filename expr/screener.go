@@ -0,0 +1,42 @@
+package expr
+
+import (
+	"github.com/tigusigalpa/taapi-go"
+	"github.com/tigusigalpa/taapi-go/screener"
+)
+
+// condition adapts an expression to screener.Condition
+type condition struct {
+	e *Expression
+}
+
+// Condition returns the expression as a screener condition:
+//
+//	screener.New(client).Where(expr.MustCompile("rsi(14) < 30").Condition())
+func (e *Expression) Condition() screener.Condition {
+	return condition{e: e}
+}
+
+func (c condition) Requests() []taapi.ParamSet { return c.e.Requests() }
+
+func (c condition) Eval(r *screener.Results) (bool, error) { return c.e.Bool(r) }
+
+func (c condition) String() string { return c.e.String() }
+
+// operand adapts a numeric expression to screener.Operand
+type operand struct {
+	e *Expression
+}
+
+// Operand returns the expression as a screener operand, e.g. for ranking:
+//
+//	s.RankBy(expr.MustCompile("close / ema(200)").Operand(), screener.Descending)
+func (e *Expression) Operand() screener.Operand {
+	return operand{e: e}
+}
+
+func (o operand) Requests() []taapi.ParamSet { return o.e.Requests() }
+
+func (o operand) Value(r *screener.Results) (float64, error) { return o.e.Float(r) }
+
+func (o operand) String() string { return o.e.String() }
@@ -39,24 +39,79 @@ const (
 	IndicatorCANDLE      Indicator = "candle"
 )
 
+// Candlestick pattern indicators. Their value is 100 for a bullish
+// pattern, -100 for a bearish one and 0 when the pattern is absent.
+const (
+	Indicator2CROWS           Indicator = "2crows"
+	Indicator3BLACKCROWS      Indicator = "3blackcrows"
+	Indicator3INSIDE          Indicator = "3inside"
+	Indicator3LINESTRIKE      Indicator = "3linestrike"
+	Indicator3OUTSIDE         Indicator = "3outside"
+	Indicator3STARSINSOUTH    Indicator = "3starsinsouth"
+	Indicator3WHITESOLDIERS   Indicator = "3whitesoldiers"
+	IndicatorABANDONEDBABY    Indicator = "abandonedbaby"
+	IndicatorADVANCEBLOCK     Indicator = "advanceblock"
+	IndicatorBELTHOLD         Indicator = "belthold"
+	IndicatorBREAKAWAY        Indicator = "breakaway"
+	IndicatorCLOSINGMARUBOZU  Indicator = "closingmarubozu"
+	IndicatorCONCEALBABYSWALL Indicator = "concealbabyswall"
+	IndicatorCOUNTERATTACK    Indicator = "counterattack"
+	IndicatorDARKCLOUDCOVER   Indicator = "darkcloudcover"
+	IndicatorDOJI             Indicator = "doji"
+	IndicatorDOJISTAR         Indicator = "dojistar"
+	IndicatorDRAGONFLYDOJI    Indicator = "dragonflydoji"
+	IndicatorENGULFING        Indicator = "engulfing"
+	IndicatorEVENINGDOJISTAR  Indicator = "eveningdojistar"
+	IndicatorEVENINGSTAR      Indicator = "eveningstar"
+	IndicatorGAPSIDESIDEWHITE Indicator = "gapsidesidewhite"
+	IndicatorGRAVESTONEDOJI   Indicator = "gravestonedoji"
+	IndicatorHAMMER           Indicator = "hammer"
+	IndicatorHANGINGMAN       Indicator = "hangingman"
+	IndicatorHARAMI           Indicator = "harami"
+	IndicatorHARAMICROSS      Indicator = "haramicross"
+	IndicatorHIGHWAVE         Indicator = "highwave"
+	IndicatorHIKKAKE          Indicator = "hikkake"
+	IndicatorHIKKAKEMOD       Indicator = "hikkakemod"
+	IndicatorHOMINGPIGEON     Indicator = "homingpigeon"
+	IndicatorIDENTICAL3CROWS  Indicator = "identical3crows"
+	IndicatorINNECK           Indicator = "inneck"
+	IndicatorINVERTEDHAMMER   Indicator = "invertedhammer"
+	IndicatorKICKING          Indicator = "kicking"
+	IndicatorKICKINGBYLENGTH  Indicator = "kickingbylength"
+	IndicatorLADDERBOTTOM     Indicator = "ladderbottom"
+	IndicatorLONGLEGGEDDOJI   Indicator = "longleggeddoji"
+	IndicatorLONGLINE         Indicator = "longline"
+	IndicatorMARUBOZU         Indicator = "marubozu"
+	IndicatorMATCHINGLOW      Indicator = "matchinglow"
+	IndicatorMATHOLD          Indicator = "mathold"
+	IndicatorMORNINGDOJISTAR  Indicator = "morningdojistar"
+	IndicatorMORNINGSTAR      Indicator = "morningstar"
+	IndicatorONNECK           Indicator = "onneck"
+	IndicatorPIERCING         Indicator = "piercing"
+	IndicatorRICKSHAWMAN      Indicator = "rickshawman"
+	IndicatorRISEFALL3METHODS Indicator = "risefall3methods"
+	IndicatorSEPARATINGLINES  Indicator = "separatinglines"
+	IndicatorSHOOTINGSTAR     Indicator = "shootingstar"
+	IndicatorSHORTLINE        Indicator = "shortline"
+	IndicatorSPINNINGTOP      Indicator = "spinningtop"
+	IndicatorSTALLEDPATTERN   Indicator = "stalledpattern"
+	IndicatorSTICKSANDWICH    Indicator = "sticksandwich"
+	IndicatorTAKURI           Indicator = "takuri"
+	IndicatorTASUKIGAP        Indicator = "tasukigap"
+	IndicatorTHRUSTING        Indicator = "thrusting"
+	IndicatorTRISTAR          Indicator = "tristar"
+	IndicatorUNIQUE3RIVER     Indicator = "unique3river"
+	IndicatorUPSIDEGAP2CROWS  Indicator = "upsidegap2crows"
+	IndicatorXSIDEGAP3METHODS Indicator = "xsidegap3methods"
+)
+
 // String returns the string representation of the indicator
 func (i Indicator) String() string {
 	return string(i)
 }
 
-// IsValid checks if the indicator is valid
+// IsValid checks if the indicator is in the catalog
 func (i Indicator) IsValid() bool {
-	switch i {
-	case IndicatorRSI, IndicatorMACD, IndicatorEMA, IndicatorSMA,
-		IndicatorBBANDS, IndicatorSTOCH, IndicatorSTOCHRSI, IndicatorATR,
-		IndicatorADX, IndicatorCCI, IndicatorAROON, IndicatorMFI,
-		IndicatorOBV, IndicatorSAR, IndicatorSUPERTREND, IndicatorICHIMOKU,
-		IndicatorVWAP, IndicatorHMA, IndicatorWMA, IndicatorDEMA,
-		IndicatorTEMA, IndicatorWILLIAMS, IndicatorUO, IndicatorROC,
-		IndicatorBBP, IndicatorAO, IndicatorCMF, IndicatorKELTNER,
-		IndicatorDONCHIAN, IndicatorPIVOT, IndicatorFIBONACCI, IndicatorVOLUME,
-		IndicatorCANDLE:
-		return true
-	}
-	return false
+	_, ok := indicatorCatalog[i]
+	return ok
 }
@@ -0,0 +1,377 @@
+// Command gencatalog generates the indicator catalog and the typed parameter
+// builders from indicators.txt.
+//
+// Usage (from the repository root):
+//
+//	go run ./internal/gencatalog -in internal/gencatalog/indicators.txt -out catalog_gen.go -params params_gen.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type param struct {
+	name     string
+	typ      string
+	def      string
+	min, max string
+	enum     []string
+}
+
+type indicator struct {
+	id         string
+	name       string
+	category   string
+	params     []param
+	outputs    []string
+	backtracks bool
+	manual     bool
+	builder    string
+}
+
+var categories = map[string]string{
+	"momentum":   "CategoryMomentum",
+	"trend":      "CategoryTrend",
+	"overlap":    "CategoryOverlap",
+	"volatility": "CategoryVolatility",
+	"volume":     "CategoryVolume",
+	"cycle":      "CategoryCycle",
+	"price":      "CategoryPrice",
+	"support":    "CategorySupport",
+	"statistic":  "CategoryStatistic",
+	"math":       "CategoryMath",
+	"pattern":    "CategoryPattern",
+}
+
+var paramTypes = map[string]string{
+	"int":    "ParamInt",
+	"float":  "ParamFloat",
+	"string": "ParamString",
+	"bool":   "ParamBool",
+}
+
+func main() {
+	in := flag.String("in", "internal/gencatalog/indicators.txt", "catalog source file")
+	out := flag.String("out", "catalog_gen.go", "generated catalog file")
+	paramsOut := flag.String("params", "params_gen.go", "generated parameter builders file")
+	flag.Parse()
+
+	indicators, err := parseFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(indicators)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	src, err = generateParams(indicators)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*paramsOut, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseFile(path string) ([]indicator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var indicators []indicator
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		ind, err := parseLine(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if seen[ind.id] {
+			return nil, fmt.Errorf("%s:%d: duplicate indicator %q", path, line, ind.id)
+		}
+		seen[ind.id] = true
+		indicators = append(indicators, ind)
+	}
+	return indicators, scanner.Err()
+}
+
+func parseLine(text string) (indicator, error) {
+	fields := strings.Split(text, "|")
+	if len(fields) != 6 && len(fields) != 7 {
+		return indicator{}, fmt.Errorf("expected 6 or 7 fields, got %d", len(fields))
+	}
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+
+	ind := indicator{
+		id:   fields[0],
+		name: fields[1],
+	}
+	if _, ok := categories[fields[2]]; !ok {
+		return ind, fmt.Errorf("unknown category %q", fields[2])
+	}
+	ind.category = fields[2]
+
+	if fields[3] != "" {
+		for _, raw := range strings.Split(fields[3], ";") {
+			p, err := parseParam(strings.TrimSpace(raw))
+			if err != nil {
+				return ind, err
+			}
+			ind.params = append(ind.params, p)
+		}
+	}
+
+	if fields[4] != "" {
+		for _, output := range strings.Split(fields[4], ",") {
+			ind.outputs = append(ind.outputs, strings.TrimSpace(output))
+		}
+	}
+
+	for _, flag := range fields[5] {
+		switch flag {
+		case 'b':
+			ind.backtracks = true
+		case 'm':
+			ind.manual = true
+		default:
+			return ind, fmt.Errorf("unknown flag %q", flag)
+		}
+	}
+
+	if len(fields) == 7 {
+		ind.builder = fields[6]
+	}
+
+	return ind, nil
+}
+
+// parseParam parses name:type=default[min:max]{enum,values}
+func parseParam(raw string) (param, error) {
+	var p param
+
+	if i := strings.Index(raw, "{"); i >= 0 {
+		if !strings.HasSuffix(raw, "}") {
+			return p, fmt.Errorf("param %q: unterminated enum", raw)
+		}
+		for _, v := range strings.Split(raw[i+1:len(raw)-1], ",") {
+			p.enum = append(p.enum, strings.TrimSpace(v))
+		}
+		raw = raw[:i]
+	}
+
+	if i := strings.Index(raw, "["); i >= 0 {
+		if !strings.HasSuffix(raw, "]") {
+			return p, fmt.Errorf("param %q: unterminated range", raw)
+		}
+		bounds := strings.SplitN(raw[i+1:len(raw)-1], ":", 2)
+		if len(bounds) != 2 {
+			return p, fmt.Errorf("param %q: range must be [min:max]", raw)
+		}
+		p.min, p.max = bounds[0], bounds[1]
+		for _, b := range bounds {
+			if b == "" {
+				continue
+			}
+			if _, err := strconv.ParseFloat(b, 64); err != nil {
+				return p, fmt.Errorf("param %q: invalid bound %q", raw, b)
+			}
+		}
+		raw = raw[:i]
+	}
+
+	if i := strings.Index(raw, "="); i >= 0 {
+		p.def = raw[i+1:]
+		raw = raw[:i]
+	}
+
+	parts := strings.SplitN(raw, ":", 2)
+	if len(parts) != 2 {
+		return p, fmt.Errorf("param %q: missing type", raw)
+	}
+	p.name, p.typ = parts[0], parts[1]
+	if _, ok := paramTypes[p.typ]; !ok {
+		return p, fmt.Errorf("param %q: unknown type %q", raw, p.typ)
+	}
+
+	return p, nil
+}
+
+func defaultLiteral(p param) (string, error) {
+	if p.def == "" {
+		return "nil", nil
+	}
+	switch p.typ {
+	case "int":
+		if _, err := strconv.Atoi(p.def); err != nil {
+			return "", fmt.Errorf("param %s: invalid int default %q", p.name, p.def)
+		}
+		return p.def, nil
+	case "float":
+		if _, err := strconv.ParseFloat(p.def, 64); err != nil {
+			return "", fmt.Errorf("param %s: invalid float default %q", p.name, p.def)
+		}
+		return "float64(" + p.def + ")", nil
+	case "bool":
+		if _, err := strconv.ParseBool(p.def); err != nil {
+			return "", fmt.Errorf("param %s: invalid bool default %q", p.name, p.def)
+		}
+		return p.def, nil
+	default:
+		return strconv.Quote(p.def), nil
+	}
+}
+
+func generate(indicators []indicator) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gencatalog from internal/gencatalog/indicators.txt. DO NOT EDIT.\n\n")
+	buf.WriteString("package taapi\n\n")
+	buf.WriteString("// indicatorCatalog holds every indicator supported by taapi.io\n")
+	buf.WriteString("var indicatorCatalog = map[Indicator]*IndicatorInfo{\n")
+
+	for _, ind := range indicators {
+		fmt.Fprintf(&buf, "%q: {\n", ind.id)
+		fmt.Fprintf(&buf, "Indicator: %q,\n", ind.id)
+		fmt.Fprintf(&buf, "Name: %q,\n", ind.name)
+		fmt.Fprintf(&buf, "Category: %s,\n", categories[ind.category])
+
+		if len(ind.params) > 0 {
+			buf.WriteString("Params: []ParamSpec{\n")
+			for _, p := range ind.params {
+				def, err := defaultLiteral(p)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", ind.id, err)
+				}
+				fmt.Fprintf(&buf, "{Name: %q, Type: %s, Default: %s", p.name, paramTypes[p.typ], def)
+				if p.min != "" {
+					fmt.Fprintf(&buf, ", Min: bound(%s)", p.min)
+				}
+				if p.max != "" {
+					fmt.Fprintf(&buf, ", Max: bound(%s)", p.max)
+				}
+				if len(p.enum) > 0 {
+					quoted := make([]string, len(p.enum))
+					for i, v := range p.enum {
+						quoted[i] = strconv.Quote(v)
+					}
+					fmt.Fprintf(&buf, ", Enum: []string{%s}", strings.Join(quoted, ", "))
+				}
+				buf.WriteString("},\n")
+			}
+			buf.WriteString("},\n")
+		}
+
+		if len(ind.outputs) > 0 {
+			quoted := make([]string, len(ind.outputs))
+			for i, v := range ind.outputs {
+				quoted[i] = strconv.Quote(v)
+			}
+			fmt.Fprintf(&buf, "Outputs: []string{%s},\n", strings.Join(quoted, ", "))
+		}
+		if ind.backtracks {
+			buf.WriteString("Backtracks: true,\n")
+		}
+		if ind.manual {
+			buf.WriteString("Manual: true,\n")
+		}
+		buf.WriteString("},\n")
+	}
+
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+// goTypes maps param types to the Go type of their builder method argument
+var goTypes = map[string]string{
+	"int":    "int",
+	"float":  "float64",
+	"string": "string",
+	"bool":   "bool",
+}
+
+// methodNames overrides builder method names that capitalisation alone gets wrong
+var methodNames = map[string]string{
+	"stddev":    "StdDev",
+	"maType":    "MAType",
+	"atrLength": "ATRLength",
+	"rsiPeriod": "RSIPeriod",
+}
+
+// methodName derives a builder method name from a param name, dropping the
+// TA-Lib optIn prefix
+func methodName(param string) string {
+	if name, ok := methodNames[param]; ok {
+		return name
+	}
+	name := strings.TrimPrefix(param, "optIn")
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func generateParams(indicators []indicator) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gencatalog from internal/gencatalog/indicators.txt. DO NOT EDIT.\n\n")
+	buf.WriteString("package taapi\n")
+
+	for _, ind := range indicators {
+		if ind.builder == "" {
+			continue
+		}
+		typ := ind.builder + "Params"
+
+		fmt.Fprintf(&buf, "\n// %s holds the parameters of the %s indicator\n", typ, ind.name)
+		fmt.Fprintf(&buf, "type %s struct {\nparamSet\n}\n\n", typ)
+		fmt.Fprintf(&buf, "// %s returns a parameter builder for %s\n", ind.builder, ind.name)
+		fmt.Fprintf(&buf, "func %s() *%s {\nreturn &%s{newParamSet(%q)}\n}\n", ind.builder, typ, typ, ind.id)
+
+		methods := make(map[string]bool)
+		for _, p := range ind.params {
+			method := methodName(p.name)
+			if methods[method] {
+				return nil, fmt.Errorf("%s: duplicate builder method %s", ind.id, method)
+			}
+			methods[method] = true
+
+			fmt.Fprintf(&buf, "\n// %s sets the %s parameter", method, p.name)
+			if p.def != "" {
+				fmt.Fprintf(&buf, " (default %s)", p.def)
+			}
+			fmt.Fprintf(&buf, "\nfunc (p *%s) %s(v %s) *%s {\np.set(%q, v)\nreturn p\n}\n", typ, method, goTypes[p.typ], typ, p.name)
+		}
+
+		if ind.backtracks {
+			fmt.Fprintf(&buf, "\n// Backtrack sets the backtrack parameter\n")
+			fmt.Fprintf(&buf, "func (p *%s) Backtrack(v int) *%s {\np.set(\"backtrack\", v)\nreturn p\n}\n", typ, typ)
+			fmt.Fprintf(&buf, "\n// Backtracks sets the backtracks parameter\n")
+			fmt.Fprintf(&buf, "func (p *%s) Backtracks(v int) *%s {\np.set(\"backtracks\", v)\nreturn p\n}\n", typ, typ)
+		}
+		fmt.Fprintf(&buf, "\n// ID sets the id returned with bulk results\n")
+		fmt.Fprintf(&buf, "func (p *%s) ID(v string) *%s {\np.set(\"id\", v)\nreturn p\n}\n", typ, typ)
+	}
+
+	return format.Source(buf.Bytes())
+}
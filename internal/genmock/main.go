@@ -0,0 +1,243 @@
+// Command genmock generates the taapimock methods implementing taapi.API
+// from the interface declarations in api.go.
+//
+// Every API method takes a context and one request parameter. The mock
+// records the request in the Call field named after the parameter, which
+// methods with the same parameter name and type share, and answers with the
+// Expectation method named after the result type, e.g. indicatorResponse for
+// *IndicatorResponse.
+//
+// Usage (from the taapimock directory):
+//
+//	go run ../internal/genmock -api ../api.go -out mock_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// method is an API method with a single request parameter
+type method struct {
+	name      string
+	iface     string
+	param     string
+	paramType string
+	variadic  bool
+	result    string
+}
+
+func main() {
+	api := flag.String("api", "../api.go", "file declaring the API interface")
+	out := flag.String("out", "mock_gen.go", "generated mock file")
+	flag.Parse()
+
+	methods, err := parseAPI(*api)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(methods)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseAPI collects the methods of the API interface and of the interfaces
+// it embeds, in declaration order
+func parseAPI(path string) ([]method, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces := make(map[string]*ast.InterfaceType)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if it, ok := ts.Type.(*ast.InterfaceType); ok {
+				interfaces[ts.Name.Name] = it
+			}
+		}
+	}
+
+	api, ok := interfaces["API"]
+	if !ok {
+		return nil, fmt.Errorf("%s: no API interface", path)
+	}
+
+	var methods []method
+	var collect func(name string, it *ast.InterfaceType) error
+	collect = func(name string, it *ast.InterfaceType) error {
+		for _, field := range it.Methods.List {
+			if len(field.Names) == 0 {
+				embedded, ok := field.Type.(*ast.Ident)
+				if !ok || interfaces[embedded.Name] == nil {
+					return fmt.Errorf("%s: unsupported embedded type %s", name, expr(field.Type))
+				}
+				if err := collect(embedded.Name, interfaces[embedded.Name]); err != nil {
+					return err
+				}
+				continue
+			}
+			m, err := parseMethod(name, field.Names[0].Name, field.Type.(*ast.FuncType))
+			if err != nil {
+				return err
+			}
+			methods = append(methods, m)
+		}
+		return nil
+	}
+	if err := collect("API", api); err != nil {
+		return nil, err
+	}
+	return methods, nil
+}
+
+// parseMethod checks the method signature: (ctx context.Context, request)
+// (result, error)
+func parseMethod(iface, name string, fn *ast.FuncType) (method, error) {
+	m := method{name: name, iface: iface}
+	invalid := func(reason string) (method, error) {
+		return m, fmt.Errorf("%s.%s: %s", iface, name, reason)
+	}
+
+	type param struct {
+		name string
+		typ  ast.Expr
+	}
+	var params []param
+	for _, field := range fn.Params.List {
+		for _, ident := range field.Names {
+			params = append(params, param{ident.Name, field.Type})
+		}
+	}
+	if len(params) != 2 || expr(params[0].typ) != "context.Context" {
+		return invalid("want a context and one request parameter")
+	}
+	m.param = params[1].name
+	typ := params[1].typ
+	if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+		m.variadic = true
+		typ = ellipsis.Elt
+	}
+	m.paramType = expr(typ)
+
+	if fn.Results == nil || len(fn.Results.List) != 2 || expr(fn.Results.List[1].Type) != "error" {
+		return invalid("want a result and an error")
+	}
+	m.result = expr(fn.Results.List[0].Type)
+	return m, nil
+}
+
+// expr renders a type expression
+func expr(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return expr(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + expr(t.X)
+	case *ast.ArrayType:
+		return "[]" + expr(t.Elt)
+	case *ast.Ellipsis:
+		return "..." + expr(t.Elt)
+	}
+	return fmt.Sprintf("%T", e)
+}
+
+// qualify prefixes an exported taapi type with the package name, e.g.
+// *IndicatorResponse becomes *taapi.IndicatorResponse
+func qualify(typ string) string {
+	i := strings.IndexFunc(typ, unicode.IsLetter)
+	if i < 0 || strings.Contains(typ, ".") || !unicode.IsUpper(rune(typ[i])) {
+		return typ
+	}
+	return typ[:i] + "taapi." + typ[i:]
+}
+
+// responder returns the Expectation method building a result of the type,
+// e.g. indicatorResponse for *IndicatorResponse and indicatorResponses for
+// []*IndicatorResponse
+func responder(result string) string {
+	name := strings.TrimLeft(result, "[]*")
+	name = strings.ToLower(name[:1]) + name[1:]
+	if strings.HasPrefix(result, "[]") {
+		name += "s"
+	}
+	return name
+}
+
+// exported returns the identifier with an upper-case first letter
+func exported(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func generate(methods []method) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by genmock from api.go. DO NOT EDIT.\n\n")
+	buf.WriteString("package taapimock\n\n")
+	buf.WriteString("import (\n\"context\"\n\ntaapi \"github.com/tigusigalpa/taapi-go\"\n)\n\n")
+
+	buf.WriteString("// Method identifies the interface method of a call\ntype Method string\n\n")
+	buf.WriteString("const (\n")
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "Method%s Method = %q\n", m.name, m.name)
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("// Call is a recorded call. Only the request field of its method is set.\n")
+	buf.WriteString("type Call struct {\nMethod Method\n")
+	fields := make(map[string]string)
+	for _, m := range methods {
+		typ := qualify(m.paramType)
+		if m.variadic {
+			typ = "[]" + typ
+		}
+		field := exported(m.param)
+		if existing, ok := fields[field]; ok {
+			if existing != typ {
+				return nil, fmt.Errorf("%s.%s: parameter %s is %s elsewhere", m.iface, m.name, m.param, existing)
+			}
+			continue
+		}
+		fields[field] = typ
+		fmt.Fprintf(&buf, "%s %s\n", field, typ)
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("var _ taapi.API = (*Client)(nil)\n")
+	for _, m := range methods {
+		paramType := qualify(m.paramType)
+		if m.variadic {
+			paramType = "..." + paramType
+		}
+		fmt.Fprintf(&buf, "\n// %s implements taapi.%s\n", m.name, m.iface)
+		fmt.Fprintf(&buf, "func (m *Client) %s(ctx context.Context, %s %s) (%s, error) {\n",
+			m.name, m.param, paramType, qualify(m.result))
+		fmt.Fprintf(&buf, "call := Call{Method: Method%s, %s: %s}\n", m.name, exported(m.param), m.param)
+		buf.WriteString("e, err := m.call(ctx, call)\nif err != nil {\nreturn nil, err\n}\n")
+		fmt.Fprintf(&buf, "return e.%s(call), nil\n}\n", responder(m.result))
+	}
+
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedMockIsCurrent(t *testing.T) {
+	methods, err := parseAPI("../../api.go")
+	require.NoError(t, err)
+
+	src, err := generate(methods)
+	require.NoError(t, err)
+
+	current, err := os.ReadFile("../../taapimock/mock_gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(src), string(current), "run go generate in taapimock")
+}
+
+func TestParseAPIRejectsUnsupportedMethods(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.go")
+	require.NoError(t, os.WriteFile(path, []byte(`package taapi
+
+import "context"
+
+type API interface {
+	Fetch(ctx context.Context, a, b string) (*Response, error)
+}
+`), 0o644))
+
+	_, err := parseAPI(path)
+	assert.EqualError(t, err, "API.Fetch: want a context and one request parameter")
+}
+
+func TestGenerateRejectsConflictingParameters(t *testing.T) {
+	_, err := generate([]method{
+		{name: "FetchIndicator", iface: "IndicatorFetcher", param: "direct", paramType: "DirectRequest", result: "*IndicatorResponse"},
+		{name: "FetchOther", iface: "OtherFetcher", param: "direct", paramType: "ManualRequest", result: "*IndicatorResponse"},
+	})
+	assert.EqualError(t, err, "OtherFetcher.FetchOther: parameter direct is taapi.DirectRequest elsewhere")
+}
@@ -7,15 +7,19 @@ type Interval string
 
 const (
 	Interval1m  Interval = "1m"
+	Interval3m  Interval = "3m"
 	Interval5m  Interval = "5m"
 	Interval15m Interval = "15m"
 	Interval30m Interval = "30m"
 	Interval1h  Interval = "1h"
 	Interval2h  Interval = "2h"
 	Interval4h  Interval = "4h"
+	Interval6h  Interval = "6h"
 	Interval12h Interval = "12h"
 	Interval1d  Interval = "1d"
+	Interval3d  Interval = "3d"
 	Interval1w  Interval = "1w"
+	Interval1M  Interval = "1M"
 )
 
 // String returns the string representation of the interval
@@ -23,22 +27,20 @@ func (i Interval) String() string {
 	return string(i)
 }
 
-// IsValid checks if the interval is valid
+// IsValid checks if the bundled market snapshot lists the interval for any
+// exchange. Builders check it against the request's exchange.
 func (i Interval) IsValid() bool {
-	switch i {
-	case Interval1m, Interval5m, Interval15m, Interval30m,
-		Interval1h, Interval2h, Interval4h, Interval12h,
-		Interval1d, Interval1w:
-		return true
-	}
-	return false
+	return DefaultMarkets().HasInterval(i)
 }
 
-// Duration returns the time.Duration representation of the interval
+// Duration returns the time.Duration representation of the interval.
+// Calendar intervals of variable length such as 1M return 0.
 func (i Interval) Duration() time.Duration {
 	switch i {
 	case Interval1m:
 		return time.Minute
+	case Interval3m:
+		return 3 * time.Minute
 	case Interval5m:
 		return 5 * time.Minute
 	case Interval15m:
@@ -51,13 +53,32 @@ func (i Interval) Duration() time.Duration {
 		return 2 * time.Hour
 	case Interval4h:
 		return 4 * time.Hour
+	case Interval6h:
+		return 6 * time.Hour
 	case Interval12h:
 		return 12 * time.Hour
 	case Interval1d:
 		return 24 * time.Hour
+	case Interval3d:
+		return 3 * 24 * time.Hour
 	case Interval1w:
 		return 7 * 24 * time.Hour
 	default:
 		return 0
 	}
 }
+
+// CandleStart returns the open time of the candle containing t, using UTC
+// boundaries with weeks starting on Monday and months on the first. Unknown
+// intervals return t unchanged.
+func (i Interval) CandleStart(t time.Time) time.Time {
+	t = t.UTC()
+	switch {
+	case i == Interval1M:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case i.Duration() == 0:
+		return t
+	}
+	start, _ := bucketBounds(t, i, false)
+	return start
+}
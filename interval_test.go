@@ -43,3 +43,41 @@ func TestIntervalDuration(t *testing.T) {
 		assert.Equal(t, tt.expected, tt.interval.Duration())
 	}
 }
+
+func TestIntervalExtended(t *testing.T) {
+	assert.Equal(t, 3*time.Minute, Interval3m.Duration())
+	assert.Equal(t, 6*time.Hour, Interval6h.Duration())
+	assert.Equal(t, 3*24*time.Hour, Interval3d.Duration())
+	assert.Equal(t, time.Duration(0), Interval1M.Duration())
+
+	// Offered by the Binance exchanges only
+	for _, interval := range []Interval{Interval3m, Interval6h, Interval3d, Interval1M} {
+		assert.True(t, interval.IsValid(), interval)
+		assert.NoError(t, DefaultMarkets().Validate(ExchangeBinance, "", interval), interval)
+		assert.Error(t, DefaultMarkets().Validate(ExchangeKraken, "", interval), interval)
+	}
+}
+
+func TestIntervalCandleStart(t *testing.T) {
+	at := time.Date(2024, 3, 14, 13, 47, 12, 0, time.UTC) // a Thursday
+	tests := []struct {
+		interval Interval
+		expected time.Time
+	}{
+		{Interval1m, time.Date(2024, 3, 14, 13, 47, 0, 0, time.UTC)},
+		{Interval15m, time.Date(2024, 3, 14, 13, 45, 0, 0, time.UTC)},
+		{Interval4h, time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)},
+		{Interval1d, time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)},
+		{Interval1w, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{Interval1M, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Interval("7x"), at},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tt.interval.CandleStart(at), tt.interval)
+	}
+
+	// Other time zones are converted to UTC
+	local := at.In(time.FixedZone("UTC+3", 3*3600))
+	assert.Equal(t, time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC), Interval1d.CandleStart(local))
+}
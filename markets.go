@@ -0,0 +1,372 @@
+package taapi
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// marketsSnapshot is the bundled list of exchanges and intervals. taapi.io
+// has no endpoint listing them, so they are static: the exchanges are those
+// taapi.io supports and the intervals are the timeframes its documentation
+// lists for every exchange, overridden for the Binance exchanges, which also
+// offer 3m, 6h, 3d and 1M candles. Only symbols are fetched from the API.
+//
+//go:embed markets.json
+var marketsSnapshot []byte
+
+// ExchangeInfo describes the intervals and symbols an exchange supports
+type ExchangeInfo struct {
+	Exchange Exchange `json:"exchange"`
+	// Intervals overrides the intervals of the catalog for the exchange; when
+	// empty the exchange offers the catalog intervals
+	Intervals []Interval `json:"intervals,omitempty"`
+	// Symbols is empty until fetched from the API; an empty list disables
+	// symbol validation for the exchange
+	Symbols []string `json:"symbols,omitempty"`
+}
+
+// MarketCatalog holds the supported exchanges, intervals and symbols. It is
+// safe for concurrent use.
+type MarketCatalog struct {
+	mu        sync.RWMutex
+	exchanges map[Exchange]*ExchangeInfo
+	intervals []Interval
+	updated   time.Time
+}
+
+// marketsFile is the JSON layout of a market snapshot
+type marketsFile struct {
+	Updated   time.Time      `json:"updated"`
+	Intervals []Interval     `json:"intervals,omitempty"`
+	Exchanges []ExchangeInfo `json:"exchanges"`
+}
+
+var (
+	defaultMarkets     *MarketCatalog
+	defaultMarketsOnce sync.Once
+)
+
+// DefaultMarkets returns the market catalog bundled with the library. It is
+// shared; use Clone before modifying it.
+func DefaultMarkets() *MarketCatalog {
+	defaultMarketsOnce.Do(func() {
+		catalog, err := ReadMarketCatalog(bytes.NewReader(marketsSnapshot))
+		if err != nil {
+			panic(fmt.Sprintf("taapi: invalid bundled market snapshot: %v", err))
+		}
+		defaultMarkets = catalog
+	})
+	return defaultMarkets
+}
+
+// NewMarketCatalog creates a market catalog from exchange descriptions
+func NewMarketCatalog(exchanges ...ExchangeInfo) *MarketCatalog {
+	catalog := &MarketCatalog{exchanges: make(map[Exchange]*ExchangeInfo, len(exchanges))}
+	for _, info := range exchanges {
+		catalog.SetExchange(info)
+	}
+	return catalog
+}
+
+// ReadMarketCatalog reads a market catalog snapshot written by WriteJSON
+func ReadMarketCatalog(r io.Reader) (*MarketCatalog, error) {
+	var file marketsFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, DecodeError("failed to decode market catalog", err)
+	}
+
+	catalog := NewMarketCatalog(file.Exchanges...)
+	catalog.intervals = append([]Interval(nil), file.Intervals...)
+	catalog.updated = file.Updated
+	return catalog, nil
+}
+
+// WriteJSON writes the catalog as a snapshot readable by ReadMarketCatalog
+func (m *MarketCatalog) WriteJSON(w io.Writer) error {
+	m.mu.RLock()
+	file := marketsFile{Updated: m.updated, Intervals: m.intervals}
+	for _, info := range m.exchanges {
+		file.Exchanges = append(file.Exchanges, *info.clone())
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(file.Exchanges, func(i, j int) bool {
+		return file.Exchanges[i].Exchange < file.Exchanges[j].Exchange
+	})
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(file); err != nil {
+		return fmt.Errorf("taapi: write market catalog: %w", err)
+	}
+	return nil
+}
+
+// Clone returns a deep copy of the catalog
+func (m *MarketCatalog) Clone() *MarketCatalog {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	clone := &MarketCatalog{
+		exchanges: make(map[Exchange]*ExchangeInfo, len(m.exchanges)),
+		intervals: append([]Interval(nil), m.intervals...),
+		updated:   m.updated,
+	}
+	for exchange, info := range m.exchanges {
+		clone.exchanges[exchange] = info.clone()
+	}
+	return clone
+}
+
+// Updated returns when the catalog was last refreshed
+func (m *MarketCatalog) Updated() time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.updated
+}
+
+// Exchanges returns the supported exchanges sorted by name
+func (m *MarketCatalog) Exchanges() []Exchange {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	exchanges := make([]Exchange, 0, len(m.exchanges))
+	for exchange := range m.exchanges {
+		exchanges = append(exchanges, exchange)
+	}
+	sort.Slice(exchanges, func(i, j int) bool {
+		return exchanges[i] < exchanges[j]
+	})
+	return exchanges
+}
+
+// Exchange returns a copy of the description of an exchange, with the
+// catalog intervals when the exchange does not override them
+func (m *MarketCatalog) Exchange(exchange Exchange) (ExchangeInfo, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	info, ok := m.exchanges[exchange]
+	if !ok {
+		return ExchangeInfo{}, false
+	}
+	clone := info.clone()
+	clone.Intervals = append([]Interval(nil), m.exchangeIntervals(info)...)
+	return *clone, true
+}
+
+// Intervals returns the intervals offered by exchanges without an override
+func (m *MarketCatalog) Intervals() []Interval {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]Interval(nil), m.intervals...)
+}
+
+// SetIntervals replaces the intervals offered by exchanges without an
+// override, e.g. to allow an interval missing from the bundled snapshot
+func (m *MarketCatalog) SetIntervals(intervals ...Interval) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.intervals = append([]Interval(nil), intervals...)
+}
+
+// HasExchange reports whether the exchange is supported
+func (m *MarketCatalog) HasExchange(exchange Exchange) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.exchanges[exchange]
+	return ok
+}
+
+// HasInterval reports whether any exchange supports the interval
+func (m *MarketCatalog) HasInterval(interval Interval) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if containsInterval(m.intervals, interval) {
+		return true
+	}
+	for _, info := range m.exchanges {
+		if containsInterval(info.Intervals, interval) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetExchange adds or replaces an exchange
+func (m *MarketCatalog) SetExchange(info ExchangeInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.exchanges == nil {
+		m.exchanges = make(map[Exchange]*ExchangeInfo)
+	}
+	m.exchanges[info.Exchange] = info.clone()
+}
+
+// SetSymbols replaces the symbols of an exchange. Unknown exchanges are added
+// with the catalog intervals.
+func (m *MarketCatalog) SetSymbols(exchange Exchange, symbols []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.exchanges == nil {
+		m.exchanges = make(map[Exchange]*ExchangeInfo)
+	}
+	info, ok := m.exchanges[exchange]
+	if !ok {
+		info = &ExchangeInfo{Exchange: exchange}
+		m.exchanges[exchange] = info
+	}
+	info.Symbols = append([]string(nil), symbols...)
+	sort.Strings(info.Symbols)
+	m.updated = time.Now().UTC()
+}
+
+// Validate checks that the exchange is supported, that it offers the
+// interval and, once its symbols are known, that it lists the symbol. Empty
+// arguments are not checked, nor are intervals when the catalog lists none
+// for the exchange.
+func (m *MarketCatalog) Validate(exchange Exchange, symbol string, interval Interval) error {
+	if exchange == "" {
+		return nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	info, ok := m.exchanges[exchange]
+	if !ok {
+		return &Error{
+			Message: fmt.Sprintf("exchange %s is not supported", exchange),
+			Kind:    ErrUnsupportedExchange,
+		}
+	}
+	intervals := m.exchangeIntervals(info)
+	if interval != "" && len(intervals) > 0 && !containsInterval(intervals, interval) {
+		return InvalidArgumentError(fmt.Sprintf("interval %s is not supported by %s", interval, exchange))
+	}
+	if symbol != "" && len(info.Symbols) > 0 {
+		i := sort.SearchStrings(info.Symbols, symbol)
+		if i == len(info.Symbols) || info.Symbols[i] != symbol {
+			return &Error{
+				Message: fmt.Sprintf("symbol %s is not listed on %s", symbol, exchange),
+				Kind:    ErrInvalidSymbol,
+			}
+		}
+	}
+	return nil
+}
+
+// exchangeIntervals returns the intervals the exchange offers
+func (m *MarketCatalog) exchangeIntervals(info *ExchangeInfo) []Interval {
+	if len(info.Intervals) > 0 {
+		return info.Intervals
+	}
+	return m.intervals
+}
+
+// containsInterval reports whether the list contains the interval
+func containsInterval(intervals []Interval, interval Interval) bool {
+	for _, i := range intervals {
+		if i == interval {
+			return true
+		}
+	}
+	return false
+}
+
+// clone returns a deep copy of the exchange description with sorted symbols
+func (info *ExchangeInfo) clone() *ExchangeInfo {
+	clone := &ExchangeInfo{
+		Exchange:  info.Exchange,
+		Intervals: append([]Interval(nil), info.Intervals...),
+		Symbols:   append([]string(nil), info.Symbols...),
+	}
+	sort.Strings(clone.Symbols)
+	return clone
+}
+
+// Markets returns the market catalog used to validate builder inputs
+func (c *Client) Markets() *MarketCatalog {
+	return c.markets.Load()
+}
+
+// SetMarkets replaces the market catalog, e.g. with a cached snapshot. A nil
+// catalog disables market validation.
+func (c *Client) SetMarkets(markets *MarketCatalog) *Client {
+	c.markets.Store(markets)
+	return c
+}
+
+// RefreshMarkets fetches the symbols of the given exchanges, or of every
+// exchange in the catalog, from the API. Exchanges and intervals are not
+// refreshed, as the API does not list them. It is safe to call while
+// requests are being built.
+func (c *Client) RefreshMarkets(ctx context.Context, exchanges ...Exchange) error {
+	markets := c.markets.Load()
+	if markets == nil {
+		c.markets.CompareAndSwap(nil, DefaultMarkets().Clone())
+		markets = c.markets.Load()
+	}
+	if len(exchanges) == 0 {
+		exchanges = markets.Exchanges()
+	}
+
+	for _, exchange := range exchanges {
+		symbols, err := c.ExchangeSymbols(ctx, exchange)
+		if err != nil {
+			return err
+		}
+		markets.SetSymbols(exchange, symbols)
+	}
+	return nil
+}
+
+// ExchangeSymbols fetches the symbols listed on an exchange
+func (c *Client) ExchangeSymbols(ctx context.Context, exchange Exchange) ([]string, error) {
+	if exchange == "" {
+		return nil, InvalidArgumentError("exchange is required")
+	}
+
+	req, info, err := c.newGetRequest(ctx, "/exchange-symbols", map[string]interface{}{
+		"exchange": exchange.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, body, meta, err := c.send(req, info)
+	if err != nil {
+		return nil, err
+	}
+
+	var symbols []string
+	if err := json.Unmarshal(body, &symbols); err != nil {
+		decodeErr := newDecodeError("failed to decode exchange symbols", err, resp, body).withRequest(info)
+		decodeErr.Meta = meta
+		return nil, decodeErr
+	}
+	return symbols, nil
+}
+
+// validateMarket checks builder inputs against the client's market catalog
+func (c *Client) validateMarket(exchange, symbol, interval string) error {
+	if c == nil {
+		return nil
+	}
+	markets := c.markets.Load()
+	if markets == nil {
+		return nil
+	}
+	return markets.Validate(Exchange(exchange), symbol, Interval(interval))
+}
@@ -0,0 +1,226 @@
+package taapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultMarkets(t *testing.T) {
+	markets := DefaultMarkets()
+
+	assert.Len(t, markets.Exchanges(), 18)
+	assert.True(t, markets.HasExchange(ExchangeBinance))
+	assert.False(t, markets.HasExchange("unknown"))
+
+	// The documented taapi.io timeframes, shared by every exchange but the
+	// Binance ones, which add 3m, 6h, 3d and 1M
+	assert.Equal(t, []Interval{
+		Interval1m, Interval5m, Interval15m, Interval30m, Interval1h,
+		Interval2h, Interval4h, Interval12h, Interval1d, Interval1w,
+	}, markets.Intervals())
+	binance := []Interval{
+		Interval1m, Interval3m, Interval5m, Interval15m, Interval30m, Interval1h, Interval2h,
+		Interval4h, Interval6h, Interval12h, Interval1d, Interval3d, Interval1w, Interval1M,
+	}
+	for _, exchange := range markets.Exchanges() {
+		info, _ := markets.Exchange(exchange)
+		switch exchange {
+		case ExchangeBinance, ExchangeBinanceUS, ExchangeBinanceUSDM:
+			assert.Equal(t, binance, info.Intervals, exchange)
+		default:
+			assert.Equal(t, markets.Intervals(), info.Intervals, exchange)
+		}
+	}
+	assert.True(t, markets.HasInterval(Interval12h))
+	assert.True(t, markets.HasInterval(Interval3m))
+	assert.False(t, markets.HasInterval("7m"))
+	assert.False(t, markets.Updated().IsZero())
+}
+
+func TestMarketCatalogSetIntervals(t *testing.T) {
+	markets := DefaultMarkets().Clone()
+	assert.Error(t, markets.Validate(ExchangeKraken, "", Interval6h))
+
+	markets.SetIntervals(append(markets.Intervals(), Interval6h)...)
+	assert.NoError(t, markets.Validate(ExchangeKraken, "", Interval6h))
+	assert.Error(t, DefaultMarkets().Validate(ExchangeKraken, "", Interval6h))
+
+	// An exchange override takes precedence over the catalog intervals
+	markets.SetExchange(ExchangeInfo{Exchange: ExchangeKraken, Intervals: []Interval{Interval1d}})
+	assert.Error(t, markets.Validate(ExchangeKraken, "", Interval1h))
+	assert.NoError(t, markets.Validate(ExchangeBinance, "", Interval1h))
+
+	// Without any known interval the interval is not checked
+	assert.NoError(t, NewMarketCatalog(ExchangeInfo{Exchange: "x"}).Validate("x", "", "7m"))
+}
+
+func TestMarketCatalogValidate(t *testing.T) {
+	markets := NewMarketCatalog(ExchangeInfo{
+		Exchange:  "newexchange",
+		Intervals: []Interval{Interval1h, Interval1d},
+	})
+
+	assert.NoError(t, markets.Validate("newexchange", "BTC/USDT", Interval1h))
+	assert.NoError(t, markets.Validate("", "", ""))
+
+	err := markets.Validate(ExchangeBinance, "BTC/USDT", Interval1h)
+	assert.True(t, errors.Is(err, ErrUnsupportedExchange))
+
+	err = markets.Validate("newexchange", "BTC/USDT", Interval5m)
+	assert.True(t, errors.Is(err, ErrInvalidParams))
+
+	markets.SetSymbols("newexchange", []string{"ETH/USDT", "BTC/USDT"})
+	assert.NoError(t, markets.Validate("newexchange", "BTC/USDT", Interval1h))
+	err = markets.Validate("newexchange", "DOGE/USDT", Interval1h)
+	assert.True(t, errors.Is(err, ErrInvalidSymbol))
+
+	info, ok := markets.Exchange("newexchange")
+	require.True(t, ok)
+	assert.Equal(t, []string{"BTC/USDT", "ETH/USDT"}, info.Symbols)
+}
+
+func TestMarketCatalogSetSymbolsAddsExchange(t *testing.T) {
+	markets := NewMarketCatalog(ExchangeInfo{Exchange: "a", Intervals: []Interval{Interval1h}})
+	markets.SetIntervals(Interval1d, Interval1w)
+
+	markets.SetSymbols("c", []string{"BTC/USDT"})
+	info, ok := markets.Exchange("c")
+	require.True(t, ok)
+	assert.Equal(t, []Interval{Interval1d, Interval1w}, info.Intervals)
+}
+
+func TestMarketCatalogJSONRoundTrip(t *testing.T) {
+	markets := DefaultMarkets().Clone()
+	markets.SetSymbols(ExchangeKraken, []string{"BTC/USD"})
+
+	var buf bytes.Buffer
+	require.NoError(t, markets.WriteJSON(&buf))
+
+	restored, err := ReadMarketCatalog(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, markets.Exchanges(), restored.Exchanges())
+	assert.True(t, restored.Updated().Equal(markets.Updated()))
+	assert.Equal(t, markets.Intervals(), restored.Intervals())
+
+	info, _ := restored.Exchange(ExchangeKraken)
+	assert.Equal(t, []string{"BTC/USD"}, info.Symbols)
+
+	_, err = ReadMarketCatalog(bytes.NewBufferString("{"))
+	assert.True(t, errors.Is(err, ErrDecode))
+}
+
+func TestMarketCatalogCloneIsIndependent(t *testing.T) {
+	clone := DefaultMarkets().Clone()
+	clone.SetSymbols(ExchangeBinance, []string{"BTC/USDT"})
+
+	info, _ := DefaultMarkets().Exchange(ExchangeBinance)
+	assert.Empty(t, info.Symbols)
+}
+
+func TestClientRefreshMarkets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/exchange-symbols", r.URL.Path)
+		assert.Equal(t, "binance", r.URL.Query().Get("exchange"))
+		w.Write([]byte(`["BTC/USDT","ETH/USDT"]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL)
+	require.NoError(t, client.RefreshMarkets(context.Background(), ExchangeBinance))
+
+	info, _ := client.Markets().Exchange(ExchangeBinance)
+	assert.Equal(t, []string{"BTC/USDT", "ETH/USDT"}, info.Symbols)
+
+	err := client.Direct().
+		Exchange(ExchangeBinance).
+		Symbol("XYZ/USDT").
+		Interval(Interval1h).
+		Indicator(IndicatorRSI).
+		validate()
+	assert.True(t, errors.Is(err, ErrInvalidSymbol))
+}
+
+func TestClientRefreshMarketsConcurrentWithRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/exchange-symbols" {
+			w.Write([]byte(`["BTC/USDT","ETH/USDT"]`))
+			return
+		}
+		w.Write([]byte(`{"value": 50}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL).SetMarkets(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.RefreshMarkets(context.Background(), ExchangeBinance))
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := client.Direct().
+				Exchange(ExchangeBinance).
+				Symbol("BTC/USDT").
+				Interval(Interval1h).
+				Indicator(IndicatorRSI).
+				Get()
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	info, _ := client.Markets().Exchange(ExchangeBinance)
+	assert.Equal(t, []string{"BTC/USDT", "ETH/USDT"}, info.Symbols)
+}
+
+func TestBuildersValidateMarkets(t *testing.T) {
+	client := NewClient("test_secret")
+
+	err := client.Direct().
+		Exchange("unknown").
+		Symbol("BTC/USDT").
+		Interval(Interval1h).
+		Indicator(IndicatorRSI).
+		validate()
+	assert.True(t, errors.Is(err, ErrUnsupportedExchange))
+
+	_, err = client.Construct("unknown", "BTC/USDT", Interval1h).
+		AddIndicator(IndicatorRSI, nil).
+		ToMap()
+	assert.True(t, errors.Is(err, ErrUnsupportedExchange))
+
+	_, err = client.Candles(ExchangeBinance, "BTC/USDT", "7m").Get()
+	assert.True(t, errors.Is(err, ErrInvalidParams))
+
+	// Intervals only some exchanges offer
+	err = client.Direct().
+		Exchange(ExchangeBinance).
+		Symbol("BTC/USDT").
+		Interval(Interval1M).
+		Indicator(IndicatorRSI).
+		validate()
+	assert.NoError(t, err)
+
+	_, err = client.Candles(ExchangeKraken, "BTC/USD", Interval3m).Get()
+	assert.True(t, errors.Is(err, ErrInvalidParams))
+
+	client.SetMarkets(nil)
+	err = client.Direct().
+		Exchange("unknown").
+		Symbol("BTC/USDT").
+		Interval(Interval1h).
+		Indicator(IndicatorRSI).
+		validate()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,118 @@
+package taapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResponseMeta holds HTTP metadata about a completed request
+type ResponseMeta struct {
+	StatusCode int
+	Header     http.Header
+	// Latency is the time from sending the request to reading the full body
+	Latency time.Duration
+	// Attempts is the number of HTTP attempts made for the request; the
+	// client sends each request once
+	Attempts int
+	// CacheHit reports whether the response was served from a cache
+	CacheHit bool
+	Quota    QuotaInfo
+}
+
+// QuotaInfo holds rate-limit and quota information reported by the API
+type QuotaInfo struct {
+	Limit      int
+	Remaining  int
+	Reset      time.Time
+	RetryAfter time.Duration
+	// Known reports whether any quota header was present
+	Known bool
+}
+
+// newResponseMeta builds response metadata from an HTTP response
+func newResponseMeta(resp *http.Response, latency time.Duration, attempts int) *ResponseMeta {
+	return &ResponseMeta{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Latency:    latency,
+		Attempts:   attempts,
+		CacheHit:   isCacheHit(resp.Header),
+		Quota:      parseQuota(resp.Header, time.Now()),
+	}
+}
+
+// isCacheHit checks the common cache status headers
+func isCacheHit(header http.Header) bool {
+	for _, key := range []string{"X-Cache", "CF-Cache-Status", "X-Cache-Status"} {
+		if strings.Contains(strings.ToUpper(header.Get(key)), "HIT") {
+			return true
+		}
+	}
+	if age, err := strconv.Atoi(header.Get("Age")); err == nil && age > 0 {
+		return true
+	}
+	return false
+}
+
+// parseQuota reads X-RateLimit-* and RateLimit-* headers
+func parseQuota(header http.Header, now time.Time) QuotaInfo {
+	var quota QuotaInfo
+
+	headerInt := func(names ...string) (int, bool) {
+		for _, name := range names {
+			if v := header.Get(name); v != "" {
+				if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+					return n, true
+				}
+			}
+		}
+		return 0, false
+	}
+
+	if n, ok := headerInt("X-RateLimit-Limit", "RateLimit-Limit"); ok {
+		quota.Limit = n
+		quota.Known = true
+	}
+	if n, ok := headerInt("X-RateLimit-Remaining", "RateLimit-Remaining"); ok {
+		quota.Remaining = n
+		quota.Known = true
+	}
+	if n, ok := headerInt("X-RateLimit-Reset", "RateLimit-Reset"); ok {
+		// Large values are absolute Unix timestamps, small ones are deltas
+		if n > 1_000_000_000 {
+			quota.Reset = time.Unix(int64(n), 0)
+		} else {
+			quota.Reset = now.Add(time.Duration(n) * time.Second)
+		}
+		quota.Known = true
+	}
+	if d, ok := parseRetryAfter(header.Get("Retry-After"), now); ok {
+		quota.RetryAfter = d
+		quota.Known = true
+	}
+
+	return quota
+}
+
+// parseRetryAfter reads a Retry-After value in delay-seconds or HTTP-date form
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if n, err := strconv.Atoi(value); err == nil {
+		if n < 0 {
+			n = 0
+		}
+		return time.Duration(n) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,123 @@
+package taapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuota(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "100")
+	header.Set("X-RateLimit-Remaining", "42")
+	header.Set("X-RateLimit-Reset", "30")
+
+	quota := parseQuota(header, now)
+	assert.True(t, quota.Known)
+	assert.Equal(t, 100, quota.Limit)
+	assert.Equal(t, 42, quota.Remaining)
+	assert.Equal(t, now.Add(30*time.Second), quota.Reset)
+
+	header.Set("X-RateLimit-Reset", "1700000600")
+	quota = parseQuota(header, now)
+	assert.Equal(t, time.Unix(1700000600, 0), quota.Reset)
+
+	assert.False(t, parseQuota(http.Header{}, now).Known)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	d, ok := parseRetryAfter("15", now)
+	assert.True(t, ok)
+	assert.Equal(t, 15*time.Second, d)
+
+	d, ok = parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Second, d)
+
+	d, ok = parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Zero(t, d)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("", now)
+	assert.False(t, ok)
+}
+
+func TestIsCacheHit(t *testing.T) {
+	assert.True(t, isCacheHit(http.Header{"X-Cache": {"HIT from edge"}}))
+	assert.True(t, isCacheHit(http.Header{"Cf-Cache-Status": {"HIT"}}))
+	assert.True(t, isCacheHit(http.Header{"Age": {"12"}}))
+	assert.False(t, isCacheHit(http.Header{"X-Cache": {"MISS"}}))
+}
+
+func TestResponseMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "7")
+		w.Header().Set("X-Cache", "HIT")
+		if r.URL.Path == "/bulk" {
+			w.Write([]byte(`[{"indicator":"rsi","value":50}]`))
+			return
+		}
+		w.Write([]byte(`{"value":50}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL)
+	resp, err := client.Direct().
+		Exchange(ExchangeBinance).
+		Symbol("BTC/USDT").
+		Interval(Interval1h).
+		Indicator(IndicatorRSI).
+		Get()
+
+	require.NoError(t, err)
+	require.NotNil(t, resp.Meta)
+	assert.Equal(t, http.StatusOK, resp.Meta.StatusCode)
+	assert.Equal(t, 1, resp.Meta.Attempts)
+	assert.True(t, resp.Meta.CacheHit)
+	assert.Equal(t, 7, resp.Meta.Quota.Remaining)
+	assert.Greater(t, int64(resp.Meta.Latency), int64(0))
+
+	bulk, err := client.Bulk().
+		AddConstruct(client.Construct(ExchangeBinance, "BTC/USDT", Interval1h).
+			AddIndicator(IndicatorRSI, nil)).
+		Execute()
+
+	require.NoError(t, err)
+	require.NotNil(t, bulk.Meta)
+	assert.Equal(t, "7", bulk.Meta.Header.Get("X-RateLimit-Remaining"))
+}
+
+func TestRateLimitErrorMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "15")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL)
+	_, err := client.Direct().
+		Exchange(ExchangeBinance).
+		Symbol("BTC/USDT").
+		Interval(Interval1h).
+		Indicator(IndicatorRSI).
+		Get()
+
+	rateLimitErr, ok := err.(*RateLimitError)
+	require.True(t, ok)
+	assert.Equal(t, 15, rateLimitErr.RetryAfter)
+	require.NotNil(t, rateLimitErr.Meta)
+	assert.Equal(t, 1, rateLimitErr.Meta.Attempts)
+	assert.Equal(t, 15*time.Second, rateLimitErr.Meta.Quota.RetryAfter)
+	assert.Equal(t, 0, rateLimitErr.Meta.Quota.Remaining)
+}
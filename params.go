@@ -0,0 +1,58 @@
+package taapi
+
+// ParamSet is an indicator together with its parameters. The typed builders
+// such as MACD() and BBands(), and IndicatorParams, implement it.
+type ParamSet interface {
+	Indicator() Indicator
+	Params() map[string]interface{}
+}
+
+// paramSet is the shared implementation of the parameter builders
+type paramSet struct {
+	indicator Indicator
+	params    map[string]interface{}
+}
+
+// newParamSet creates an empty parameter set for an indicator
+func newParamSet(indicator Indicator) paramSet {
+	return paramSet{
+		indicator: indicator,
+		params:    make(map[string]interface{}),
+	}
+}
+
+// Indicator returns the indicator the parameters belong to
+func (p *paramSet) Indicator() Indicator {
+	return p.indicator
+}
+
+// Params returns a copy of the parameters
+func (p *paramSet) Params() map[string]interface{} {
+	params := make(map[string]interface{}, len(p.params))
+	for k, v := range p.params {
+		params[k] = v
+	}
+	return params
+}
+
+// set stores a single parameter
+func (p *paramSet) set(key string, value interface{}) {
+	p.params[key] = value
+}
+
+// IndicatorParams is an untyped parameter builder for indicators without a
+// typed builder
+type IndicatorParams struct {
+	paramSet
+}
+
+// NewIndicatorParams creates an untyped parameter builder
+func NewIndicatorParams(indicator Indicator) *IndicatorParams {
+	return &IndicatorParams{newParamSet(indicator)}
+}
+
+// Set sets a single parameter
+func (p *IndicatorParams) Set(key string, value interface{}) *IndicatorParams {
+	p.set(key, value)
+	return p
+}
@@ -0,0 +1,1887 @@
+// Code generated by gencatalog from internal/gencatalog/indicators.txt. DO NOT EDIT.
+
+package taapi
+
+// AOParams holds the parameters of the Awesome Oscillator indicator
+type AOParams struct {
+	paramSet
+}
+
+// AO returns a parameter builder for Awesome Oscillator
+func AO() *AOParams {
+	return &AOParams{newParamSet("ao")}
+}
+
+// FastPeriod sets the fastPeriod parameter (default 5)
+func (p *AOParams) FastPeriod(v int) *AOParams {
+	p.set("fastPeriod", v)
+	return p
+}
+
+// SlowPeriod sets the slowPeriod parameter (default 34)
+func (p *AOParams) SlowPeriod(v int) *AOParams {
+	p.set("slowPeriod", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *AOParams) Backtrack(v int) *AOParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *AOParams) Backtracks(v int) *AOParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *AOParams) ID(v string) *AOParams {
+	p.set("id", v)
+	return p
+}
+
+// AroonParams holds the parameters of the Aroon indicator
+type AroonParams struct {
+	paramSet
+}
+
+// Aroon returns a parameter builder for Aroon
+func Aroon() *AroonParams {
+	return &AroonParams{newParamSet("aroon")}
+}
+
+// Period sets the period parameter (default 14)
+func (p *AroonParams) Period(v int) *AroonParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *AroonParams) Backtrack(v int) *AroonParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *AroonParams) Backtracks(v int) *AroonParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *AroonParams) ID(v string) *AroonParams {
+	p.set("id", v)
+	return p
+}
+
+// CCIParams holds the parameters of the Commodity Channel Index indicator
+type CCIParams struct {
+	paramSet
+}
+
+// CCI returns a parameter builder for Commodity Channel Index
+func CCI() *CCIParams {
+	return &CCIParams{newParamSet("cci")}
+}
+
+// Period sets the period parameter (default 20)
+func (p *CCIParams) Period(v int) *CCIParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *CCIParams) Backtrack(v int) *CCIParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *CCIParams) Backtracks(v int) *CCIParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *CCIParams) ID(v string) *CCIParams {
+	p.set("id", v)
+	return p
+}
+
+// CMOParams holds the parameters of the Chande Momentum Oscillator indicator
+type CMOParams struct {
+	paramSet
+}
+
+// CMO returns a parameter builder for Chande Momentum Oscillator
+func CMO() *CMOParams {
+	return &CMOParams{newParamSet("cmo")}
+}
+
+// Period sets the period parameter (default 14)
+func (p *CMOParams) Period(v int) *CMOParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *CMOParams) Backtrack(v int) *CMOParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *CMOParams) Backtracks(v int) *CMOParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *CMOParams) ID(v string) *CMOParams {
+	p.set("id", v)
+	return p
+}
+
+// DMIParams holds the parameters of the Directional Movement Index indicator
+type DMIParams struct {
+	paramSet
+}
+
+// DMI returns a parameter builder for Directional Movement Index
+func DMI() *DMIParams {
+	return &DMIParams{newParamSet("dmi")}
+}
+
+// Period sets the period parameter (default 14)
+func (p *DMIParams) Period(v int) *DMIParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *DMIParams) Backtrack(v int) *DMIParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *DMIParams) Backtracks(v int) *DMIParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *DMIParams) ID(v string) *DMIParams {
+	p.set("id", v)
+	return p
+}
+
+// FisherParams holds the parameters of the Fisher Transform indicator
+type FisherParams struct {
+	paramSet
+}
+
+// Fisher returns a parameter builder for Fisher Transform
+func Fisher() *FisherParams {
+	return &FisherParams{newParamSet("fisher")}
+}
+
+// Period sets the period parameter (default 9)
+func (p *FisherParams) Period(v int) *FisherParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *FisherParams) Backtrack(v int) *FisherParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *FisherParams) Backtracks(v int) *FisherParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *FisherParams) ID(v string) *FisherParams {
+	p.set("id", v)
+	return p
+}
+
+// KDJParams holds the parameters of the KDJ indicator
+type KDJParams struct {
+	paramSet
+}
+
+// KDJ returns a parameter builder for KDJ
+func KDJ() *KDJParams {
+	return &KDJParams{newParamSet("kdj")}
+}
+
+// Period sets the period parameter (default 9)
+func (p *KDJParams) Period(v int) *KDJParams {
+	p.set("period", v)
+	return p
+}
+
+// Signal sets the signal parameter (default 3)
+func (p *KDJParams) Signal(v int) *KDJParams {
+	p.set("signal", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *KDJParams) Backtrack(v int) *KDJParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *KDJParams) Backtracks(v int) *KDJParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *KDJParams) ID(v string) *KDJParams {
+	p.set("id", v)
+	return p
+}
+
+// MACDParams holds the parameters of the Moving Average Convergence Divergence indicator
+type MACDParams struct {
+	paramSet
+}
+
+// MACD returns a parameter builder for Moving Average Convergence Divergence
+func MACD() *MACDParams {
+	return &MACDParams{newParamSet("macd")}
+}
+
+// FastPeriod sets the optInFastPeriod parameter (default 12)
+func (p *MACDParams) FastPeriod(v int) *MACDParams {
+	p.set("optInFastPeriod", v)
+	return p
+}
+
+// SlowPeriod sets the optInSlowPeriod parameter (default 26)
+func (p *MACDParams) SlowPeriod(v int) *MACDParams {
+	p.set("optInSlowPeriod", v)
+	return p
+}
+
+// SignalPeriod sets the optInSignalPeriod parameter (default 9)
+func (p *MACDParams) SignalPeriod(v int) *MACDParams {
+	p.set("optInSignalPeriod", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *MACDParams) Backtrack(v int) *MACDParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *MACDParams) Backtracks(v int) *MACDParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *MACDParams) ID(v string) *MACDParams {
+	p.set("id", v)
+	return p
+}
+
+// MFIParams holds the parameters of the Money Flow Index indicator
+type MFIParams struct {
+	paramSet
+}
+
+// MFI returns a parameter builder for Money Flow Index
+func MFI() *MFIParams {
+	return &MFIParams{newParamSet("mfi")}
+}
+
+// Period sets the period parameter (default 14)
+func (p *MFIParams) Period(v int) *MFIParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *MFIParams) Backtrack(v int) *MFIParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *MFIParams) Backtracks(v int) *MFIParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *MFIParams) ID(v string) *MFIParams {
+	p.set("id", v)
+	return p
+}
+
+// MomentumParams holds the parameters of the Momentum indicator
+type MomentumParams struct {
+	paramSet
+}
+
+// Momentum returns a parameter builder for Momentum
+func Momentum() *MomentumParams {
+	return &MomentumParams{newParamSet("mom")}
+}
+
+// Period sets the period parameter (default 10)
+func (p *MomentumParams) Period(v int) *MomentumParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *MomentumParams) Backtrack(v int) *MomentumParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *MomentumParams) Backtracks(v int) *MomentumParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *MomentumParams) ID(v string) *MomentumParams {
+	p.set("id", v)
+	return p
+}
+
+// PPOParams holds the parameters of the Percentage Price Oscillator indicator
+type PPOParams struct {
+	paramSet
+}
+
+// PPO returns a parameter builder for Percentage Price Oscillator
+func PPO() *PPOParams {
+	return &PPOParams{newParamSet("ppo")}
+}
+
+// FastPeriod sets the optInFastPeriod parameter (default 12)
+func (p *PPOParams) FastPeriod(v int) *PPOParams {
+	p.set("optInFastPeriod", v)
+	return p
+}
+
+// SlowPeriod sets the optInSlowPeriod parameter (default 26)
+func (p *PPOParams) SlowPeriod(v int) *PPOParams {
+	p.set("optInSlowPeriod", v)
+	return p
+}
+
+// MAType sets the optInMAType parameter (default 0)
+func (p *PPOParams) MAType(v int) *PPOParams {
+	p.set("optInMAType", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *PPOParams) Backtrack(v int) *PPOParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *PPOParams) Backtracks(v int) *PPOParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *PPOParams) ID(v string) *PPOParams {
+	p.set("id", v)
+	return p
+}
+
+// ROCParams holds the parameters of the Rate of Change indicator
+type ROCParams struct {
+	paramSet
+}
+
+// ROC returns a parameter builder for Rate of Change
+func ROC() *ROCParams {
+	return &ROCParams{newParamSet("roc")}
+}
+
+// Period sets the period parameter (default 10)
+func (p *ROCParams) Period(v int) *ROCParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *ROCParams) Backtrack(v int) *ROCParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *ROCParams) Backtracks(v int) *ROCParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *ROCParams) ID(v string) *ROCParams {
+	p.set("id", v)
+	return p
+}
+
+// RSIParams holds the parameters of the Relative Strength Index indicator
+type RSIParams struct {
+	paramSet
+}
+
+// RSI returns a parameter builder for Relative Strength Index
+func RSI() *RSIParams {
+	return &RSIParams{newParamSet("rsi")}
+}
+
+// Period sets the period parameter (default 14)
+func (p *RSIParams) Period(v int) *RSIParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *RSIParams) Backtrack(v int) *RSIParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *RSIParams) Backtracks(v int) *RSIParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *RSIParams) ID(v string) *RSIParams {
+	p.set("id", v)
+	return p
+}
+
+// SqueezeParams holds the parameters of the Squeeze Momentum indicator
+type SqueezeParams struct {
+	paramSet
+}
+
+// Squeeze returns a parameter builder for Squeeze Momentum
+func Squeeze() *SqueezeParams {
+	return &SqueezeParams{newParamSet("squeeze")}
+}
+
+// Backtrack sets the backtrack parameter
+func (p *SqueezeParams) Backtrack(v int) *SqueezeParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *SqueezeParams) Backtracks(v int) *SqueezeParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *SqueezeParams) ID(v string) *SqueezeParams {
+	p.set("id", v)
+	return p
+}
+
+// StochParams holds the parameters of the Stochastic indicator
+type StochParams struct {
+	paramSet
+}
+
+// Stoch returns a parameter builder for Stochastic
+func Stoch() *StochParams {
+	return &StochParams{newParamSet("stoch")}
+}
+
+// KPeriod sets the kPeriod parameter (default 14)
+func (p *StochParams) KPeriod(v int) *StochParams {
+	p.set("kPeriod", v)
+	return p
+}
+
+// KSmooth sets the kSmooth parameter (default 3)
+func (p *StochParams) KSmooth(v int) *StochParams {
+	p.set("kSmooth", v)
+	return p
+}
+
+// DPeriod sets the dPeriod parameter (default 3)
+func (p *StochParams) DPeriod(v int) *StochParams {
+	p.set("dPeriod", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *StochParams) Backtrack(v int) *StochParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *StochParams) Backtracks(v int) *StochParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *StochParams) ID(v string) *StochParams {
+	p.set("id", v)
+	return p
+}
+
+// StochRSIParams holds the parameters of the Stochastic RSI indicator
+type StochRSIParams struct {
+	paramSet
+}
+
+// StochRSI returns a parameter builder for Stochastic RSI
+func StochRSI() *StochRSIParams {
+	return &StochRSIParams{newParamSet("stochrsi")}
+}
+
+// KPeriod sets the kPeriod parameter (default 3)
+func (p *StochRSIParams) KPeriod(v int) *StochRSIParams {
+	p.set("kPeriod", v)
+	return p
+}
+
+// DPeriod sets the dPeriod parameter (default 3)
+func (p *StochRSIParams) DPeriod(v int) *StochRSIParams {
+	p.set("dPeriod", v)
+	return p
+}
+
+// RSIPeriod sets the rsiPeriod parameter (default 14)
+func (p *StochRSIParams) RSIPeriod(v int) *StochRSIParams {
+	p.set("rsiPeriod", v)
+	return p
+}
+
+// StochasticPeriod sets the stochasticPeriod parameter (default 14)
+func (p *StochRSIParams) StochasticPeriod(v int) *StochRSIParams {
+	p.set("stochasticPeriod", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *StochRSIParams) Backtrack(v int) *StochRSIParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *StochRSIParams) Backtracks(v int) *StochRSIParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *StochRSIParams) ID(v string) *StochRSIParams {
+	p.set("id", v)
+	return p
+}
+
+// TRIXParams holds the parameters of the Triple Exponential Average indicator
+type TRIXParams struct {
+	paramSet
+}
+
+// TRIX returns a parameter builder for Triple Exponential Average
+func TRIX() *TRIXParams {
+	return &TRIXParams{newParamSet("trix")}
+}
+
+// TimePeriod sets the optInTimePeriod parameter (default 30)
+func (p *TRIXParams) TimePeriod(v int) *TRIXParams {
+	p.set("optInTimePeriod", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *TRIXParams) Backtrack(v int) *TRIXParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *TRIXParams) Backtracks(v int) *TRIXParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *TRIXParams) ID(v string) *TRIXParams {
+	p.set("id", v)
+	return p
+}
+
+// UOParams holds the parameters of the Ultimate Oscillator indicator
+type UOParams struct {
+	paramSet
+}
+
+// UO returns a parameter builder for Ultimate Oscillator
+func UO() *UOParams {
+	return &UOParams{newParamSet("uo")}
+}
+
+// Period1 sets the period1 parameter (default 7)
+func (p *UOParams) Period1(v int) *UOParams {
+	p.set("period1", v)
+	return p
+}
+
+// Period2 sets the period2 parameter (default 14)
+func (p *UOParams) Period2(v int) *UOParams {
+	p.set("period2", v)
+	return p
+}
+
+// Period3 sets the period3 parameter (default 28)
+func (p *UOParams) Period3(v int) *UOParams {
+	p.set("period3", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *UOParams) Backtrack(v int) *UOParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *UOParams) Backtracks(v int) *UOParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *UOParams) ID(v string) *UOParams {
+	p.set("id", v)
+	return p
+}
+
+// WilliamsParams holds the parameters of the Williams %R indicator
+type WilliamsParams struct {
+	paramSet
+}
+
+// Williams returns a parameter builder for Williams %R
+func Williams() *WilliamsParams {
+	return &WilliamsParams{newParamSet("williams")}
+}
+
+// Period sets the period parameter (default 14)
+func (p *WilliamsParams) Period(v int) *WilliamsParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *WilliamsParams) Backtrack(v int) *WilliamsParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *WilliamsParams) Backtracks(v int) *WilliamsParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *WilliamsParams) ID(v string) *WilliamsParams {
+	p.set("id", v)
+	return p
+}
+
+// WaveTrendParams holds the parameters of the WaveTrend Oscillator indicator
+type WaveTrendParams struct {
+	paramSet
+}
+
+// WaveTrend returns a parameter builder for WaveTrend Oscillator
+func WaveTrend() *WaveTrendParams {
+	return &WaveTrendParams{newParamSet("wavetrend")}
+}
+
+// ChannelLength sets the channelLength parameter (default 10)
+func (p *WaveTrendParams) ChannelLength(v int) *WaveTrendParams {
+	p.set("channelLength", v)
+	return p
+}
+
+// AverageLength sets the averageLength parameter (default 21)
+func (p *WaveTrendParams) AverageLength(v int) *WaveTrendParams {
+	p.set("averageLength", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *WaveTrendParams) Backtrack(v int) *WaveTrendParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *WaveTrendParams) Backtracks(v int) *WaveTrendParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *WaveTrendParams) ID(v string) *WaveTrendParams {
+	p.set("id", v)
+	return p
+}
+
+// ADXParams holds the parameters of the Average Directional Index indicator
+type ADXParams struct {
+	paramSet
+}
+
+// ADX returns a parameter builder for Average Directional Index
+func ADX() *ADXParams {
+	return &ADXParams{newParamSet("adx")}
+}
+
+// Period sets the period parameter (default 14)
+func (p *ADXParams) Period(v int) *ADXParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *ADXParams) Backtrack(v int) *ADXParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *ADXParams) Backtracks(v int) *ADXParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *ADXParams) ID(v string) *ADXParams {
+	p.set("id", v)
+	return p
+}
+
+// ChopParams holds the parameters of the Choppiness Index indicator
+type ChopParams struct {
+	paramSet
+}
+
+// Chop returns a parameter builder for Choppiness Index
+func Chop() *ChopParams {
+	return &ChopParams{newParamSet("chop")}
+}
+
+// Period sets the period parameter (default 14)
+func (p *ChopParams) Period(v int) *ChopParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *ChopParams) Backtrack(v int) *ChopParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *ChopParams) Backtracks(v int) *ChopParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *ChopParams) ID(v string) *ChopParams {
+	p.set("id", v)
+	return p
+}
+
+// IchimokuParams holds the parameters of the Ichimoku Cloud indicator
+type IchimokuParams struct {
+	paramSet
+}
+
+// Ichimoku returns a parameter builder for Ichimoku Cloud
+func Ichimoku() *IchimokuParams {
+	return &IchimokuParams{newParamSet("ichimoku")}
+}
+
+// ConversionPeriod sets the conversionPeriod parameter (default 9)
+func (p *IchimokuParams) ConversionPeriod(v int) *IchimokuParams {
+	p.set("conversionPeriod", v)
+	return p
+}
+
+// BasePeriod sets the basePeriod parameter (default 26)
+func (p *IchimokuParams) BasePeriod(v int) *IchimokuParams {
+	p.set("basePeriod", v)
+	return p
+}
+
+// SpanPeriod sets the spanPeriod parameter (default 52)
+func (p *IchimokuParams) SpanPeriod(v int) *IchimokuParams {
+	p.set("spanPeriod", v)
+	return p
+}
+
+// Displacement sets the displacement parameter (default 26)
+func (p *IchimokuParams) Displacement(v int) *IchimokuParams {
+	p.set("displacement", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *IchimokuParams) Backtrack(v int) *IchimokuParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *IchimokuParams) Backtracks(v int) *IchimokuParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *IchimokuParams) ID(v string) *IchimokuParams {
+	p.set("id", v)
+	return p
+}
+
+// PSARParams holds the parameters of the Parabolic SAR indicator
+type PSARParams struct {
+	paramSet
+}
+
+// PSAR returns a parameter builder for Parabolic SAR
+func PSAR() *PSARParams {
+	return &PSARParams{newParamSet("psar")}
+}
+
+// Start sets the start parameter (default 0.02)
+func (p *PSARParams) Start(v float64) *PSARParams {
+	p.set("start", v)
+	return p
+}
+
+// Increment sets the increment parameter (default 0.02)
+func (p *PSARParams) Increment(v float64) *PSARParams {
+	p.set("increment", v)
+	return p
+}
+
+// Maximum sets the maximum parameter (default 0.2)
+func (p *PSARParams) Maximum(v float64) *PSARParams {
+	p.set("maximum", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *PSARParams) Backtrack(v int) *PSARParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *PSARParams) Backtracks(v int) *PSARParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *PSARParams) ID(v string) *PSARParams {
+	p.set("id", v)
+	return p
+}
+
+// SARParams holds the parameters of the Parabolic SAR (TA-Lib) indicator
+type SARParams struct {
+	paramSet
+}
+
+// SAR returns a parameter builder for Parabolic SAR (TA-Lib)
+func SAR() *SARParams {
+	return &SARParams{newParamSet("sar")}
+}
+
+// Acceleration sets the optInAcceleration parameter (default 0.02)
+func (p *SARParams) Acceleration(v float64) *SARParams {
+	p.set("optInAcceleration", v)
+	return p
+}
+
+// Maximum sets the optInMaximum parameter (default 0.2)
+func (p *SARParams) Maximum(v float64) *SARParams {
+	p.set("optInMaximum", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *SARParams) Backtrack(v int) *SARParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *SARParams) Backtracks(v int) *SARParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *SARParams) ID(v string) *SARParams {
+	p.set("id", v)
+	return p
+}
+
+// SupertrendParams holds the parameters of the Supertrend indicator
+type SupertrendParams struct {
+	paramSet
+}
+
+// Supertrend returns a parameter builder for Supertrend
+func Supertrend() *SupertrendParams {
+	return &SupertrendParams{newParamSet("supertrend")}
+}
+
+// Period sets the period parameter (default 7)
+func (p *SupertrendParams) Period(v int) *SupertrendParams {
+	p.set("period", v)
+	return p
+}
+
+// Multiplier sets the multiplier parameter (default 3)
+func (p *SupertrendParams) Multiplier(v float64) *SupertrendParams {
+	p.set("multiplier", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *SupertrendParams) Backtrack(v int) *SupertrendParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *SupertrendParams) Backtracks(v int) *SupertrendParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *SupertrendParams) ID(v string) *SupertrendParams {
+	p.set("id", v)
+	return p
+}
+
+// VOSCParams holds the parameters of the Volume Oscillator indicator
+type VOSCParams struct {
+	paramSet
+}
+
+// VOSC returns a parameter builder for Volume Oscillator
+func VOSC() *VOSCParams {
+	return &VOSCParams{newParamSet("vosc")}
+}
+
+// ShortPeriod sets the shortPeriod parameter (default 5)
+func (p *VOSCParams) ShortPeriod(v int) *VOSCParams {
+	p.set("shortPeriod", v)
+	return p
+}
+
+// LongPeriod sets the longPeriod parameter (default 10)
+func (p *VOSCParams) LongPeriod(v int) *VOSCParams {
+	p.set("longPeriod", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *VOSCParams) Backtrack(v int) *VOSCParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *VOSCParams) Backtracks(v int) *VOSCParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *VOSCParams) ID(v string) *VOSCParams {
+	p.set("id", v)
+	return p
+}
+
+// BBandsParams holds the parameters of the Bollinger Bands indicator
+type BBandsParams struct {
+	paramSet
+}
+
+// BBands returns a parameter builder for Bollinger Bands
+func BBands() *BBandsParams {
+	return &BBandsParams{newParamSet("bbands")}
+}
+
+// Period sets the period parameter (default 20)
+func (p *BBandsParams) Period(v int) *BBandsParams {
+	p.set("period", v)
+	return p
+}
+
+// StdDev sets the stddev parameter (default 2)
+func (p *BBandsParams) StdDev(v float64) *BBandsParams {
+	p.set("stddev", v)
+	return p
+}
+
+// MAType sets the maType parameter (default 0)
+func (p *BBandsParams) MAType(v int) *BBandsParams {
+	p.set("maType", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *BBandsParams) Backtrack(v int) *BBandsParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *BBandsParams) Backtracks(v int) *BBandsParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *BBandsParams) ID(v string) *BBandsParams {
+	p.set("id", v)
+	return p
+}
+
+// BBPParams holds the parameters of the Bollinger Bands %B indicator
+type BBPParams struct {
+	paramSet
+}
+
+// BBP returns a parameter builder for Bollinger Bands %B
+func BBP() *BBPParams {
+	return &BBPParams{newParamSet("bbp")}
+}
+
+// Period sets the period parameter (default 20)
+func (p *BBPParams) Period(v int) *BBPParams {
+	p.set("period", v)
+	return p
+}
+
+// StdDev sets the stddev parameter (default 2)
+func (p *BBPParams) StdDev(v float64) *BBPParams {
+	p.set("stddev", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *BBPParams) Backtrack(v int) *BBPParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *BBPParams) Backtracks(v int) *BBPParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *BBPParams) ID(v string) *BBPParams {
+	p.set("id", v)
+	return p
+}
+
+// DEMAParams holds the parameters of the Double Exponential Moving Average indicator
+type DEMAParams struct {
+	paramSet
+}
+
+// DEMA returns a parameter builder for Double Exponential Moving Average
+func DEMA() *DEMAParams {
+	return &DEMAParams{newParamSet("dema")}
+}
+
+// Period sets the period parameter (default 30)
+func (p *DEMAParams) Period(v int) *DEMAParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *DEMAParams) Backtrack(v int) *DEMAParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *DEMAParams) Backtracks(v int) *DEMAParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *DEMAParams) ID(v string) *DEMAParams {
+	p.set("id", v)
+	return p
+}
+
+// DonchianParams holds the parameters of the Donchian Channels indicator
+type DonchianParams struct {
+	paramSet
+}
+
+// Donchian returns a parameter builder for Donchian Channels
+func Donchian() *DonchianParams {
+	return &DonchianParams{newParamSet("donchian")}
+}
+
+// Period sets the period parameter (default 20)
+func (p *DonchianParams) Period(v int) *DonchianParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *DonchianParams) Backtrack(v int) *DonchianParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *DonchianParams) Backtracks(v int) *DonchianParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *DonchianParams) ID(v string) *DonchianParams {
+	p.set("id", v)
+	return p
+}
+
+// EMAParams holds the parameters of the Exponential Moving Average indicator
+type EMAParams struct {
+	paramSet
+}
+
+// EMA returns a parameter builder for Exponential Moving Average
+func EMA() *EMAParams {
+	return &EMAParams{newParamSet("ema")}
+}
+
+// Period sets the period parameter (default 30)
+func (p *EMAParams) Period(v int) *EMAParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *EMAParams) Backtrack(v int) *EMAParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *EMAParams) Backtracks(v int) *EMAParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *EMAParams) ID(v string) *EMAParams {
+	p.set("id", v)
+	return p
+}
+
+// HMAParams holds the parameters of the Hull Moving Average indicator
+type HMAParams struct {
+	paramSet
+}
+
+// HMA returns a parameter builder for Hull Moving Average
+func HMA() *HMAParams {
+	return &HMAParams{newParamSet("hma")}
+}
+
+// Period sets the period parameter (default 9)
+func (p *HMAParams) Period(v int) *HMAParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *HMAParams) Backtrack(v int) *HMAParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *HMAParams) Backtracks(v int) *HMAParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *HMAParams) ID(v string) *HMAParams {
+	p.set("id", v)
+	return p
+}
+
+// KAMAParams holds the parameters of the Kaufman Adaptive Moving Average indicator
+type KAMAParams struct {
+	paramSet
+}
+
+// KAMA returns a parameter builder for Kaufman Adaptive Moving Average
+func KAMA() *KAMAParams {
+	return &KAMAParams{newParamSet("kama")}
+}
+
+// Period sets the period parameter (default 30)
+func (p *KAMAParams) Period(v int) *KAMAParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *KAMAParams) Backtrack(v int) *KAMAParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *KAMAParams) Backtracks(v int) *KAMAParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *KAMAParams) ID(v string) *KAMAParams {
+	p.set("id", v)
+	return p
+}
+
+// KeltnerParams holds the parameters of the Keltner Channels indicator
+type KeltnerParams struct {
+	paramSet
+}
+
+// Keltner returns a parameter builder for Keltner Channels
+func Keltner() *KeltnerParams {
+	return &KeltnerParams{newParamSet("keltner")}
+}
+
+// Period sets the period parameter (default 20)
+func (p *KeltnerParams) Period(v int) *KeltnerParams {
+	p.set("period", v)
+	return p
+}
+
+// Multiplier sets the multiplier parameter (default 2)
+func (p *KeltnerParams) Multiplier(v float64) *KeltnerParams {
+	p.set("multiplier", v)
+	return p
+}
+
+// ATRLength sets the atrLength parameter (default 10)
+func (p *KeltnerParams) ATRLength(v int) *KeltnerParams {
+	p.set("atrLength", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *KeltnerParams) Backtrack(v int) *KeltnerParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *KeltnerParams) Backtracks(v int) *KeltnerParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *KeltnerParams) ID(v string) *KeltnerParams {
+	p.set("id", v)
+	return p
+}
+
+// SMAParams holds the parameters of the Simple Moving Average indicator
+type SMAParams struct {
+	paramSet
+}
+
+// SMA returns a parameter builder for Simple Moving Average
+func SMA() *SMAParams {
+	return &SMAParams{newParamSet("sma")}
+}
+
+// Period sets the period parameter (default 30)
+func (p *SMAParams) Period(v int) *SMAParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *SMAParams) Backtrack(v int) *SMAParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *SMAParams) Backtracks(v int) *SMAParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *SMAParams) ID(v string) *SMAParams {
+	p.set("id", v)
+	return p
+}
+
+// TEMAParams holds the parameters of the Triple Exponential Moving Average indicator
+type TEMAParams struct {
+	paramSet
+}
+
+// TEMA returns a parameter builder for Triple Exponential Moving Average
+func TEMA() *TEMAParams {
+	return &TEMAParams{newParamSet("tema")}
+}
+
+// Period sets the period parameter (default 30)
+func (p *TEMAParams) Period(v int) *TEMAParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *TEMAParams) Backtrack(v int) *TEMAParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *TEMAParams) Backtracks(v int) *TEMAParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *TEMAParams) ID(v string) *TEMAParams {
+	p.set("id", v)
+	return p
+}
+
+// VWAPParams holds the parameters of the Volume Weighted Average Price indicator
+type VWAPParams struct {
+	paramSet
+}
+
+// VWAP returns a parameter builder for Volume Weighted Average Price
+func VWAP() *VWAPParams {
+	return &VWAPParams{newParamSet("vwap")}
+}
+
+// AnchorPeriod sets the anchorPeriod parameter (default session)
+func (p *VWAPParams) AnchorPeriod(v string) *VWAPParams {
+	p.set("anchorPeriod", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *VWAPParams) Backtrack(v int) *VWAPParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *VWAPParams) Backtracks(v int) *VWAPParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *VWAPParams) ID(v string) *VWAPParams {
+	p.set("id", v)
+	return p
+}
+
+// VWMAParams holds the parameters of the Volume Weighted Moving Average indicator
+type VWMAParams struct {
+	paramSet
+}
+
+// VWMA returns a parameter builder for Volume Weighted Moving Average
+func VWMA() *VWMAParams {
+	return &VWMAParams{newParamSet("vwma")}
+}
+
+// Period sets the period parameter (default 20)
+func (p *VWMAParams) Period(v int) *VWMAParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *VWMAParams) Backtrack(v int) *VWMAParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *VWMAParams) Backtracks(v int) *VWMAParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *VWMAParams) ID(v string) *VWMAParams {
+	p.set("id", v)
+	return p
+}
+
+// WMAParams holds the parameters of the Weighted Moving Average indicator
+type WMAParams struct {
+	paramSet
+}
+
+// WMA returns a parameter builder for Weighted Moving Average
+func WMA() *WMAParams {
+	return &WMAParams{newParamSet("wma")}
+}
+
+// Period sets the period parameter (default 30)
+func (p *WMAParams) Period(v int) *WMAParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *WMAParams) Backtrack(v int) *WMAParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *WMAParams) Backtracks(v int) *WMAParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *WMAParams) ID(v string) *WMAParams {
+	p.set("id", v)
+	return p
+}
+
+// ATRParams holds the parameters of the Average True Range indicator
+type ATRParams struct {
+	paramSet
+}
+
+// ATR returns a parameter builder for Average True Range
+func ATR() *ATRParams {
+	return &ATRParams{newParamSet("atr")}
+}
+
+// Period sets the period parameter (default 14)
+func (p *ATRParams) Period(v int) *ATRParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *ATRParams) Backtrack(v int) *ATRParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *ATRParams) Backtracks(v int) *ATRParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *ATRParams) ID(v string) *ATRParams {
+	p.set("id", v)
+	return p
+}
+
+// NATRParams holds the parameters of the Normalized Average True Range indicator
+type NATRParams struct {
+	paramSet
+}
+
+// NATR returns a parameter builder for Normalized Average True Range
+func NATR() *NATRParams {
+	return &NATRParams{newParamSet("natr")}
+}
+
+// Period sets the period parameter (default 14)
+func (p *NATRParams) Period(v int) *NATRParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *NATRParams) Backtrack(v int) *NATRParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *NATRParams) Backtracks(v int) *NATRParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *NATRParams) ID(v string) *NATRParams {
+	p.set("id", v)
+	return p
+}
+
+// StdDevParams holds the parameters of the Standard Deviation indicator
+type StdDevParams struct {
+	paramSet
+}
+
+// StdDev returns a parameter builder for Standard Deviation
+func StdDev() *StdDevParams {
+	return &StdDevParams{newParamSet("stddev")}
+}
+
+// Period sets the period parameter (default 5)
+func (p *StdDevParams) Period(v int) *StdDevParams {
+	p.set("period", v)
+	return p
+}
+
+// StdDev sets the stddev parameter (default 1)
+func (p *StdDevParams) StdDev(v float64) *StdDevParams {
+	p.set("stddev", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *StdDevParams) Backtrack(v int) *StdDevParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *StdDevParams) Backtracks(v int) *StdDevParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *StdDevParams) ID(v string) *StdDevParams {
+	p.set("id", v)
+	return p
+}
+
+// ADParams holds the parameters of the Chaikin A/D Line indicator
+type ADParams struct {
+	paramSet
+}
+
+// AD returns a parameter builder for Chaikin A/D Line
+func AD() *ADParams {
+	return &ADParams{newParamSet("ad")}
+}
+
+// Backtrack sets the backtrack parameter
+func (p *ADParams) Backtrack(v int) *ADParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *ADParams) Backtracks(v int) *ADParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *ADParams) ID(v string) *ADParams {
+	p.set("id", v)
+	return p
+}
+
+// ADOSCParams holds the parameters of the Chaikin A/D Oscillator indicator
+type ADOSCParams struct {
+	paramSet
+}
+
+// ADOSC returns a parameter builder for Chaikin A/D Oscillator
+func ADOSC() *ADOSCParams {
+	return &ADOSCParams{newParamSet("adosc")}
+}
+
+// FastPeriod sets the optInFastPeriod parameter (default 3)
+func (p *ADOSCParams) FastPeriod(v int) *ADOSCParams {
+	p.set("optInFastPeriod", v)
+	return p
+}
+
+// SlowPeriod sets the optInSlowPeriod parameter (default 10)
+func (p *ADOSCParams) SlowPeriod(v int) *ADOSCParams {
+	p.set("optInSlowPeriod", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *ADOSCParams) Backtrack(v int) *ADOSCParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *ADOSCParams) Backtracks(v int) *ADOSCParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *ADOSCParams) ID(v string) *ADOSCParams {
+	p.set("id", v)
+	return p
+}
+
+// CMFParams holds the parameters of the Chaikin Money Flow indicator
+type CMFParams struct {
+	paramSet
+}
+
+// CMF returns a parameter builder for Chaikin Money Flow
+func CMF() *CMFParams {
+	return &CMFParams{newParamSet("cmf")}
+}
+
+// Period sets the period parameter (default 20)
+func (p *CMFParams) Period(v int) *CMFParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *CMFParams) Backtrack(v int) *CMFParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *CMFParams) Backtracks(v int) *CMFParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *CMFParams) ID(v string) *CMFParams {
+	p.set("id", v)
+	return p
+}
+
+// OBVParams holds the parameters of the On Balance Volume indicator
+type OBVParams struct {
+	paramSet
+}
+
+// OBV returns a parameter builder for On Balance Volume
+func OBV() *OBVParams {
+	return &OBVParams{newParamSet("obv")}
+}
+
+// Backtrack sets the backtrack parameter
+func (p *OBVParams) Backtrack(v int) *OBVParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *OBVParams) Backtracks(v int) *OBVParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *OBVParams) ID(v string) *OBVParams {
+	p.set("id", v)
+	return p
+}
+
+// VolumeParams holds the parameters of the Volume indicator
+type VolumeParams struct {
+	paramSet
+}
+
+// Volume returns a parameter builder for Volume
+func Volume() *VolumeParams {
+	return &VolumeParams{newParamSet("volume")}
+}
+
+// Backtrack sets the backtrack parameter
+func (p *VolumeParams) Backtrack(v int) *VolumeParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *VolumeParams) Backtracks(v int) *VolumeParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *VolumeParams) ID(v string) *VolumeParams {
+	p.set("id", v)
+	return p
+}
+
+// FibonacciParams holds the parameters of the Fibonacci Retracement indicator
+type FibonacciParams struct {
+	paramSet
+}
+
+// Fibonacci returns a parameter builder for Fibonacci Retracement
+func Fibonacci() *FibonacciParams {
+	return &FibonacciParams{newParamSet("fibonacci")}
+}
+
+// Period sets the period parameter (default 21)
+func (p *FibonacciParams) Period(v int) *FibonacciParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *FibonacciParams) Backtrack(v int) *FibonacciParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *FibonacciParams) Backtracks(v int) *FibonacciParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *FibonacciParams) ID(v string) *FibonacciParams {
+	p.set("id", v)
+	return p
+}
+
+// PivotParams holds the parameters of the Pivot Points indicator
+type PivotParams struct {
+	paramSet
+}
+
+// Pivot returns a parameter builder for Pivot Points
+func Pivot() *PivotParams {
+	return &PivotParams{newParamSet("pivot")}
+}
+
+// Type sets the type parameter (default classic)
+func (p *PivotParams) Type(v string) *PivotParams {
+	p.set("type", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *PivotParams) Backtrack(v int) *PivotParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *PivotParams) Backtracks(v int) *PivotParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *PivotParams) ID(v string) *PivotParams {
+	p.set("id", v)
+	return p
+}
+
+// ZScoreParams holds the parameters of the Z-Score indicator
+type ZScoreParams struct {
+	paramSet
+}
+
+// ZScore returns a parameter builder for Z-Score
+func ZScore() *ZScoreParams {
+	return &ZScoreParams{newParamSet("zscore")}
+}
+
+// Period sets the period parameter (default 20)
+func (p *ZScoreParams) Period(v int) *ZScoreParams {
+	p.set("period", v)
+	return p
+}
+
+// Backtrack sets the backtrack parameter
+func (p *ZScoreParams) Backtrack(v int) *ZScoreParams {
+	p.set("backtrack", v)
+	return p
+}
+
+// Backtracks sets the backtracks parameter
+func (p *ZScoreParams) Backtracks(v int) *ZScoreParams {
+	p.set("backtracks", v)
+	return p
+}
+
+// ID sets the id returned with bulk results
+func (p *ZScoreParams) ID(v string) *ZScoreParams {
+	p.set("id", v)
+	return p
+}
@@ -0,0 +1,92 @@
+package taapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedParams(t *testing.T) {
+	macd := MACD().FastPeriod(12).SlowPeriod(26).SignalPeriod(9).Backtrack(1)
+	assert.Equal(t, IndicatorMACD, macd.Indicator())
+	assert.Equal(t, map[string]interface{}{
+		"optInFastPeriod":   12,
+		"optInSlowPeriod":   26,
+		"optInSignalPeriod": 9,
+		"backtrack":         1,
+	}, macd.Params())
+
+	bbands := BBands().Period(20).StdDev(2).MAType(1)
+	assert.Equal(t, IndicatorBBANDS, bbands.Indicator())
+	assert.Equal(t, map[string]interface{}{"period": 20, "stddev": 2.0, "maType": 1}, bbands.Params())
+
+	assert.Equal(t, Indicator("vwap"), VWAP().AnchorPeriod("week").Indicator())
+}
+
+func TestTypedParamsMatchCatalog(t *testing.T) {
+	for _, p := range []ParamSet{RSI(), MACD(), BBands(), Stoch(), StochRSI(), Supertrend(), Ichimoku(), Keltner()} {
+		assert.True(t, p.Indicator().IsValid(), p.Indicator())
+	}
+}
+
+func TestParamsReturnsCopy(t *testing.T) {
+	rsi := RSI().Period(14)
+	params := rsi.Params()
+	params["period"] = 99
+
+	assert.Equal(t, 14, rsi.Params()["period"])
+}
+
+func TestIndicatorParams(t *testing.T) {
+	p := NewIndicatorParams("newindicator").Set("length", 10)
+	assert.Equal(t, Indicator("newindicator"), p.Indicator())
+	assert.Equal(t, map[string]interface{}{"length": 10}, p.Params())
+}
+
+func TestBuildersUseParamSet(t *testing.T) {
+	client := NewClient("test_secret")
+
+	direct := client.Direct().Use(MACD().FastPeriod(8))
+	assert.Equal(t, "macd", direct.indicator)
+	assert.Equal(t, 8, direct.params["optInFastPeriod"])
+
+	construct := client.Construct(ExchangeBinance, "BTC/USDT", Interval1h).
+		Add(RSI().Period(21).ID("rsi21"))
+	m, err := construct.ToMap()
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"indicator": "rsi", "period": 21, "id": "rsi21"},
+	}, m["indicators"])
+
+	manual := client.Manual(IndicatorBBANDS).Use(BBands().Period(10))
+	assert.NoError(t, manual.err)
+	assert.Equal(t, "bbands", manual.indicator)
+	assert.Equal(t, 10, manual.params["period"])
+}
+
+func TestBuildersUseMismatchedParamSet(t *testing.T) {
+	client := NewClient("test_secret")
+
+	_, err := client.Indicator(IndicatorRSI).
+		Exchange(ExchangeBinance).
+		Symbol("BTC/USDT").
+		Interval(Interval1h).
+		Use(EMA().Period(20)).
+		Get()
+	assert.ErrorIs(t, err, ErrInvalidParams)
+	assert.ErrorContains(t, err, "parameters for ema cannot be used with indicator rsi")
+
+	_, err = client.Manual(IndicatorEMA).Use(BBands().Period(10)).Execute()
+	assert.ErrorIs(t, err, ErrInvalidParams)
+}
+
+func TestBuildersUseInvalidParamSet(t *testing.T) {
+	client := NewClient("test_secret")
+
+	_, err := client.Construct(ExchangeBinance, "BTC/USDT", Interval1h).
+		Add(RSI().Period(1)).
+		ToMap()
+	assert.True(t, errors.Is(err, ErrInvalidParams))
+}
@@ -0,0 +1,180 @@
+package taapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// MaxConstructIndicators is the number of indicators taapi.io accepts in a
+// single bulk construct
+const MaxConstructIndicators = 20
+
+// PatternSignal is the direction of a detected candlestick pattern
+type PatternSignal int
+
+const (
+	// PatternNone means the pattern is absent
+	PatternNone PatternSignal = iota
+	// PatternBullish means a bullish pattern (value 100)
+	PatternBullish
+	// PatternBearish means a bearish pattern (value -100)
+	PatternBearish
+)
+
+// String returns the string representation of the signal
+func (s PatternSignal) String() string {
+	switch s {
+	case PatternBullish:
+		return "bullish"
+	case PatternBearish:
+		return "bearish"
+	default:
+		return "none"
+	}
+}
+
+// PatternResult is the decoded value of a candlestick pattern indicator
+type PatternResult struct {
+	Indicator Indicator
+	Signal    PatternSignal
+	// Value is the raw API value: 100, -100 or 0
+	Value float64
+}
+
+// NewPatternResult decodes a raw pattern value. Positive values are bullish,
+// negative values bearish and zero means no pattern.
+func NewPatternResult(indicator Indicator, value float64) PatternResult {
+	result := PatternResult{Indicator: indicator, Value: value}
+	switch {
+	case value > 0:
+		result.Signal = PatternBullish
+	case value < 0:
+		result.Signal = PatternBearish
+	}
+	return result
+}
+
+// Detected reports whether the pattern is present
+func (r PatternResult) Detected() bool {
+	return r.Signal != PatternNone
+}
+
+// IsPattern reports whether the indicator is a candlestick pattern
+func (i Indicator) IsPattern() bool {
+	info := i.Info()
+	return info != nil && info.Category == CategoryPattern
+}
+
+// Patterns returns every candlestick pattern indicator sorted by identifier
+func Patterns() []Indicator {
+	infos := IndicatorsByCategory(CategoryPattern)
+	patterns := make([]Indicator, len(infos))
+	for i, info := range infos {
+		patterns[i] = info.Indicator
+	}
+	return patterns
+}
+
+// Pattern decodes the response of a candlestick pattern indicator. Bulk
+// responses nesting the value under "result" are supported.
+func (r *IndicatorResponse) Pattern() (PatternResult, error) {
+	data := r.Data
+	if result, ok := data["result"].(map[string]interface{}); ok {
+		data = result
+	}
+
+	value, ok := toFloat(data["value"])
+	if !ok {
+		return PatternResult{}, DecodeError(fmt.Sprintf("pattern %s has no numeric value", r.Indicator), nil)
+	}
+	return NewPatternResult(Indicator(r.Indicator), value), nil
+}
+
+// PatternScanBuilder scans a symbol for candlestick patterns in one bulk request
+type PatternScanBuilder struct {
+	client    *Client
+	exchange  Exchange
+	symbol    string
+	interval  Interval
+	patterns  []Indicator
+	backtrack int
+}
+
+// Patterns restricts the scan to the given patterns; by default all are scanned
+func (b *PatternScanBuilder) Patterns(patterns ...Indicator) *PatternScanBuilder {
+	b.patterns = patterns
+	return b
+}
+
+// Backtrack scans the candle the given number of candles back
+func (b *PatternScanBuilder) Backtrack(backtrack int) *PatternScanBuilder {
+	b.backtrack = backtrack
+	return b
+}
+
+// Get executes the scan and returns one result per pattern in scan order
+func (b *PatternScanBuilder) Get() ([]PatternResult, error) {
+	return b.GetContext(context.Background())
+}
+
+// GetContext executes the scan with the given context
+func (b *PatternScanBuilder) GetContext(ctx context.Context) ([]PatternResult, error) {
+	patterns := b.patterns
+	if len(patterns) == 0 {
+		patterns = Patterns()
+	}
+	for _, pattern := range patterns {
+		if !pattern.IsPattern() {
+			return nil, InvalidArgumentError(fmt.Sprintf("%s is not a candlestick pattern", pattern))
+		}
+	}
+
+	bulk := b.client.Bulk()
+	for start := 0; start < len(patterns); start += MaxConstructIndicators {
+		end := start + MaxConstructIndicators
+		if end > len(patterns) {
+			end = len(patterns)
+		}
+
+		construct := b.client.Construct(b.exchange, b.symbol, b.interval)
+		for _, pattern := range patterns[start:end] {
+			params := map[string]interface{}{"id": pattern.String()}
+			if b.backtrack > 0 {
+				params["backtrack"] = b.backtrack
+			}
+			construct.AddIndicator(pattern, params)
+		}
+		bulk.AddConstruct(construct)
+	}
+
+	resp, err := bulk.ExecuteContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]PatternResult, 0, len(patterns))
+	for _, pattern := range patterns {
+		item := resp.FindByID(pattern.String())
+		if item == nil {
+			return nil, DecodeError(fmt.Sprintf("bulk response is missing pattern %s", pattern), nil)
+		}
+		result, err := item.Pattern()
+		if err != nil {
+			return nil, err
+		}
+		result.Indicator = pattern
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// DetectedPatterns returns the results whose pattern is present
+func DetectedPatterns(results []PatternResult) []PatternResult {
+	var detected []PatternResult
+	for _, result := range results {
+		if result.Detected() {
+			detected = append(detected, result)
+		}
+	}
+	return detected
+}
@@ -0,0 +1,143 @@
+package taapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPatternResult(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  PatternSignal
+	}{
+		{100, PatternBullish},
+		{-100, PatternBearish},
+		{0, PatternNone},
+	}
+
+	for _, tt := range tests {
+		result := NewPatternResult(IndicatorDOJI, tt.value)
+		assert.Equal(t, tt.want, result.Signal)
+		assert.Equal(t, tt.want != PatternNone, result.Detected())
+	}
+
+	assert.Equal(t, "bullish", PatternBullish.String())
+	assert.Equal(t, "bearish", PatternBearish.String())
+	assert.Equal(t, "none", PatternNone.String())
+}
+
+func TestPatterns(t *testing.T) {
+	patterns := Patterns()
+	assert.Len(t, patterns, 61)
+	assert.Contains(t, patterns, IndicatorENGULFING)
+	assert.Contains(t, patterns, IndicatorMORNINGSTAR)
+
+	for _, pattern := range patterns {
+		assert.True(t, pattern.IsPattern(), pattern)
+	}
+	assert.False(t, IndicatorRSI.IsPattern())
+}
+
+func TestIndicatorResponsePattern(t *testing.T) {
+	var direct IndicatorResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"indicator":"hammer","value":100}`), &direct))
+	result, err := direct.Pattern()
+	require.NoError(t, err)
+	assert.Equal(t, IndicatorHAMMER, result.Indicator)
+	assert.Equal(t, PatternBullish, result.Signal)
+
+	var bulk IndicatorResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"x","result":{"value":-100},"errors":[]}`), &bulk))
+	result, err = bulk.Pattern()
+	require.NoError(t, err)
+	assert.Equal(t, PatternBearish, result.Signal)
+
+	var missing IndicatorResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"indicator":"doji"}`), &missing))
+	_, err = missing.Pattern()
+	assert.True(t, errors.Is(err, ErrDecode))
+}
+
+func TestPatternScan(t *testing.T) {
+	var constructs []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/bulk", r.URL.Path)
+
+		var payload struct {
+			Construct []map[string]interface{} `json:"construct"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		constructs = payload.Construct
+
+		var items []map[string]interface{}
+		for _, construct := range payload.Construct {
+			for _, indicator := range construct["indicators"].([]interface{}) {
+				id := indicator.(map[string]interface{})["id"].(string)
+				value := 0
+				switch id {
+				case "engulfing":
+					value = 100
+				case "shootingstar":
+					value = -100
+				}
+				items = append(items, map[string]interface{}{
+					"id":     id,
+					"result": map[string]interface{}{"value": value},
+					"errors": []string{},
+				})
+			}
+		}
+		json.NewEncoder(w).Encode(items)
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL)
+	results, err := client.PatternScan(ExchangeBinance, "BTC/USDT", Interval1h).Get()
+
+	require.NoError(t, err)
+	assert.Len(t, results, 61)
+	assert.Len(t, constructs, 4)
+	for _, construct := range constructs {
+		assert.LessOrEqual(t, len(construct["indicators"].([]interface{})), MaxConstructIndicators)
+	}
+
+	detected := DetectedPatterns(results)
+	require.Len(t, detected, 2)
+	assert.Equal(t, PatternResult{Indicator: IndicatorENGULFING, Signal: PatternBullish, Value: 100}, detected[0])
+	assert.Equal(t, PatternResult{Indicator: IndicatorSHOOTINGSTAR, Signal: PatternBearish, Value: -100}, detected[1])
+}
+
+func TestPatternScanSubset(t *testing.T) {
+	var indicators []interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Construct []map[string]interface{} `json:"construct"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		indicators = payload.Construct[0]["indicators"].([]interface{})
+		w.Write([]byte(`[{"id":"doji","result":{"value":0}}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL)
+	results, err := client.PatternScan(ExchangeBinance, "BTC/USDT", Interval1h).
+		Patterns(IndicatorDOJI).
+		Backtrack(1).
+		Get()
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, map[string]interface{}{"indicator": "doji", "id": "doji", "backtrack": float64(1)}, indicators[0])
+}
+
+func TestPatternScanRejectsNonPattern(t *testing.T) {
+	client := NewClient("test_secret")
+	_, err := client.PatternScan(ExchangeBinance, "BTC/USDT", Interval1h).Patterns(IndicatorRSI).Get()
+	assert.True(t, errors.Is(err, ErrInvalidParams))
+}
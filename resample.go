@@ -0,0 +1,159 @@
+package taapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// PartialBarPolicy selects what Resample does with buckets that are not
+// covered by a full set of source candles
+type PartialBarPolicy int
+
+const (
+	// PartialDropEdges drops incomplete first and last buckets, which are
+	// usually cut by the start of the data or a still-forming bar
+	PartialDropEdges PartialBarPolicy = iota
+	// PartialKeep keeps every bucket
+	PartialKeep
+	// PartialDrop drops every incomplete bucket
+	PartialDrop
+)
+
+// ResampleOptions configures Resample
+type ResampleOptions struct {
+	// Location sets the timezone of daily and weekly boundaries, and of
+	// intraday buckets which are aligned to local midnight. Defaults to UTC.
+	Location *time.Location
+	// WeekStartsSunday starts weekly buckets on Sunday instead of Monday
+	WeekStartsSunday bool
+	// Partial selects how incomplete buckets are handled
+	Partial PartialBarPolicy
+}
+
+// Resample aggregates candles of interval from into candles of the larger
+// interval to: open of the first, highest high, lowest low, close of the
+// last and summed volume. Candles must be ordered by timestamp. A nil opts
+// uses UTC boundaries, Monday weeks and PartialDropEdges.
+func Resample(candles []*Candle, from, to Interval, opts *ResampleOptions) ([]*Candle, error) {
+	if opts == nil {
+		opts = &ResampleOptions{}
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	fromStep, toStep := from.Duration(), to.Duration()
+	if fromStep <= 0 || toStep <= 0 {
+		return nil, InvalidArgumentError(fmt.Sprintf("cannot resample from %s to %s", from, to))
+	}
+	if toStep <= fromStep || toStep%fromStep != 0 {
+		return nil, InvalidArgumentError(fmt.Sprintf("%s is not a multiple of %s", to, from))
+	}
+
+	type bucket struct {
+		candle   *Candle
+		expected int
+		count    int
+	}
+
+	var buckets []*bucket
+	var current *bucket
+	var last int64
+
+	for i, candle := range candles {
+		if candle == nil {
+			return nil, InvalidArgumentError(fmt.Sprintf("candle %d is nil", i))
+		}
+		if i > 0 && candle.Timestamp <= last {
+			return nil, InvalidArgumentError(fmt.Sprintf("candle %d is not after the previous candle", i))
+		}
+		last = candle.Timestamp
+
+		start, end := bucketBounds(time.Unix(candle.Timestamp, 0).In(loc), to, opts.WeekStartsSunday)
+		if current == nil || current.candle.Timestamp != start.Unix() {
+			current = &bucket{
+				candle: &Candle{
+					Timestamp: start.Unix(),
+					Open:      candle.Open,
+					High:      candle.High,
+					Low:       candle.Low,
+				},
+				expected: int(end.Sub(start) / fromStep),
+			}
+			buckets = append(buckets, current)
+		}
+
+		c := current.candle
+		if candle.High > c.High {
+			c.High = candle.High
+		}
+		if candle.Low < c.Low {
+			c.Low = candle.Low
+		}
+		c.Close = candle.Close
+		c.Volume += candle.Volume
+		current.count++
+	}
+
+	result := make([]*Candle, 0, len(buckets))
+	for i, b := range buckets {
+		complete := b.count >= b.expected
+		switch opts.Partial {
+		case PartialDrop:
+			if !complete {
+				continue
+			}
+		case PartialDropEdges:
+			if !complete && (i == 0 || i == len(buckets)-1) {
+				continue
+			}
+		}
+		result = append(result, b.candle)
+	}
+
+	return result, nil
+}
+
+// Resample aggregates the series into a series of a larger interval
+func (s *CandleSeries) Resample(to Interval, opts *ResampleOptions) (*CandleSeries, error) {
+	candles, err := Resample(s.candles, s.interval, to, opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewCandleSeries(to, candles), nil
+}
+
+// bucketBounds returns the start and end of the interval bucket containing t.
+// Buckets of a day or longer start at local midnight; weekly buckets start
+// on Monday (or Sunday); intraday buckets are aligned to local midnight.
+func bucketBounds(t time.Time, interval Interval, weekStartsSunday bool) (time.Time, time.Time) {
+	step := interval.Duration()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	const day = 24 * time.Hour
+
+	switch {
+	case interval == Interval1w:
+		weekStart := time.Monday
+		if weekStartsSunday {
+			weekStart = time.Sunday
+		}
+		offset := (int(t.Weekday()) - int(weekStart) + 7) % 7
+		start := midnight.AddDate(0, 0, -offset)
+		return start, start.AddDate(0, 0, 7)
+	case step%day == 0:
+		days := int(step / day)
+		// Count calendar days since the epoch in the bucket's timezone
+		dayNumber := int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / int64(day/time.Second))
+		start := midnight.AddDate(0, 0, -(dayNumber % days))
+		return start, start.AddDate(0, 0, days)
+	default:
+		sinceMidnight := t.Sub(midnight)
+		start := midnight.Add(sinceMidnight / step * step)
+		end := start.Add(step)
+		if next := midnight.AddDate(0, 0, 1); end.After(next) {
+			end = next
+		}
+		return start, end
+	}
+}
@@ -0,0 +1,120 @@
+package taapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hourlyCandles builds n hourly candles starting at start with close = index
+func hourlyCandles(start int64, n int) []*Candle {
+	candles := make([]*Candle, n)
+	for i := range candles {
+		price := float64(i)
+		candles[i] = &Candle{
+			Timestamp: start + int64(i)*3600,
+			Open:      price,
+			High:      price + 0.5,
+			Low:       price - 0.5,
+			Close:     price + 0.25,
+			Volume:    1,
+		}
+	}
+	return candles
+}
+
+func TestResampleAggregates(t *testing.T) {
+	// 2021-01-01 00:00 UTC, 8 hourly candles into two 4h candles
+	candles := hourlyCandles(1609459200, 8)
+
+	result, err := Resample(candles, Interval1h, Interval4h, nil)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+
+	assert.Equal(t, &Candle{Timestamp: 1609459200, Open: 0, High: 3.5, Low: -0.5, Close: 3.25, Volume: 4}, result[0])
+	assert.Equal(t, int64(1609459200+4*3600), result[1].Timestamp)
+	assert.Equal(t, 4.0, result[1].Open)
+}
+
+func TestResamplePartialPolicies(t *testing.T) {
+	// Starts at 02:00 and ends at 13:00: first and last 4h buckets are partial
+	candles := hourlyCandles(1609459200+2*3600, 12)
+
+	edges, err := Resample(candles, Interval1h, Interval4h, nil)
+	require.NoError(t, err)
+	assert.Len(t, edges, 2)
+	assert.Equal(t, int64(1609459200+4*3600), edges[0].Timestamp)
+
+	kept, err := Resample(candles, Interval1h, Interval4h, &ResampleOptions{Partial: PartialKeep})
+	require.NoError(t, err)
+	assert.Len(t, kept, 4)
+	assert.Equal(t, int64(1609459200), kept[0].Timestamp)
+
+	// Remove a candle in the middle: PartialDrop also drops that bucket
+	withGap := append(append([]*Candle{}, candles[:4]...), candles[5:]...)
+	dropped, err := Resample(withGap, Interval1h, Interval4h, &ResampleOptions{Partial: PartialDrop})
+	require.NoError(t, err)
+	assert.Len(t, dropped, 1)
+}
+
+func TestResampleDailyTimezone(t *testing.T) {
+	// 48 hourly candles from 2021-01-01 00:00 UTC
+	candles := hourlyCandles(1609459200, 48)
+
+	utc, err := Resample(candles, Interval1h, Interval1d, nil)
+	require.NoError(t, err)
+	require.Len(t, utc, 2)
+	assert.Equal(t, int64(1609459200), utc[0].Timestamp)
+
+	// In UTC+3 days start at 21:00 UTC, so only one complete day fits
+	loc := time.FixedZone("UTC+3", 3*3600)
+	local, err := Resample(candles, Interval1h, Interval1d, &ResampleOptions{Location: loc})
+	require.NoError(t, err)
+	require.Len(t, local, 1)
+	assert.Equal(t, int64(1609459200+21*3600), local[0].Timestamp)
+	assert.Equal(t, 21.0, local[0].Open)
+}
+
+func TestResampleWeekly(t *testing.T) {
+	// 14 daily candles from Friday 2021-01-01
+	daily := make([]*Candle, 14)
+	for i := range daily {
+		daily[i] = flatCandle(1609459200+int64(i)*86400, float64(i))
+	}
+
+	weeks, err := Resample(daily, Interval1d, Interval1w, &ResampleOptions{Partial: PartialKeep})
+	require.NoError(t, err)
+	require.Len(t, weeks, 3)
+	// 2021-01-04 was a Monday
+	assert.Equal(t, int64(1609718400), weeks[1].Timestamp)
+	assert.True(t, Interval1w.isAligned(weeks[1].Timestamp))
+
+	sundays, err := Resample(daily, Interval1d, Interval1w, &ResampleOptions{Partial: PartialKeep, WeekStartsSunday: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1609632000), sundays[1].Timestamp)
+}
+
+func TestResampleInvalid(t *testing.T) {
+	_, err := Resample(nil, Interval4h, Interval1h, nil)
+	assert.ErrorIs(t, err, ErrInvalidParams)
+
+	_, err = Resample(nil, Interval2h, Interval5m, nil)
+	assert.ErrorIs(t, err, ErrInvalidParams)
+
+	candles := hourlyCandles(1609459200, 2)
+	candles[0], candles[1] = candles[1], candles[0]
+	_, err = Resample(candles, Interval1h, Interval4h, nil)
+	assert.ErrorIs(t, err, ErrInvalidParams)
+}
+
+func TestCandleSeriesResample(t *testing.T) {
+	series := NewCandleSeries(Interval1h, hourlyCandles(1609459200, 24))
+
+	resampled, err := series.Resample(Interval12h, nil)
+	require.NoError(t, err)
+	assert.Equal(t, Interval12h, resampled.Interval())
+	assert.Equal(t, 2, resampled.Len())
+	assert.NoError(t, resampled.Validate())
+}
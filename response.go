@@ -7,6 +7,10 @@ type IndicatorResponse struct {
 	Indicator string                 `json:"indicator,omitempty"`
 	ID        string                 `json:"id,omitempty"`
 	Data      map[string]interface{} `json:"-"`
+	// Meta holds HTTP metadata; it is only set on top-level responses
+	Meta *ResponseMeta `json:"-"`
+
+	raw []byte
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling
@@ -16,6 +20,8 @@ func (r *IndicatorResponse) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	r.raw = append([]byte(nil), data...)
+
 	if indicator, ok := raw["indicator"].(string); ok {
 		r.Indicator = indicator
 		delete(raw, "indicator")
@@ -49,6 +55,11 @@ func (r *IndicatorResponse) MarshalJSON() ([]byte, error) {
 	return json.Marshal(result)
 }
 
+// Raw returns the raw JSON the response was decoded from
+func (r *IndicatorResponse) Raw() []byte {
+	return r.raw
+}
+
 // GetValue returns the main value from the response
 func (r *IndicatorResponse) GetValue() interface{} {
 	if val, ok := r.Data["value"]; ok {
@@ -92,6 +103,9 @@ func (r *IndicatorResponse) Has(key string) bool {
 // BulkResponse represents a response for bulk requests
 type BulkResponse struct {
 	Responses []*IndicatorResponse
+	Meta      *ResponseMeta
+
+	raw []byte
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling for bulk responses
@@ -101,6 +115,8 @@ func (b *BulkResponse) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	b.raw = append([]byte(nil), data...)
+
 	b.Responses = make([]*IndicatorResponse, 0, len(raw))
 	for _, item := range raw {
 		itemData, err := json.Marshal(item)
@@ -119,6 +135,11 @@ func (b *BulkResponse) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Raw returns the raw JSON the bulk response was decoded from
+func (b *BulkResponse) Raw() []byte {
+	return b.raw
+}
+
 // FindByID finds a response by its ID
 func (b *BulkResponse) FindByID(id string) *IndicatorResponse {
 	for _, response := range b.Responses {
@@ -144,18 +165,3 @@ func (b *BulkResponse) FilterByIndicator(indicator string) []*IndicatorResponse
 func (b *BulkResponse) Count() int {
 	return len(b.Responses)
 }
-
-// Candle represents OHLCV candle data
-type Candle struct {
-	Timestamp int64   `json:"timestamp"`
-	Open      float64 `json:"open"`
-	High      float64 `json:"high"`
-	Low       float64 `json:"low"`
-	Close     float64 `json:"close"`
-	Volume    float64 `json:"volume"`
-}
-
-// ToArray converts a candle to an array format [timestamp, open, high, low, close, volume]
-func (c *Candle) ToArray() []interface{} {
-	return []interface{}{c.Timestamp, c.Open, c.High, c.Low, c.Close, c.Volume}
-}
@@ -20,6 +20,19 @@ func TestIndicatorResponseUnmarshal(t *testing.T) {
 	assert.Equal(t, 65.5, response.Data["value"])
 }
 
+func TestIndicatorResponseRaw(t *testing.T) {
+	jsonData := `{"indicator":"rsi","value":65.5}`
+
+	var response IndicatorResponse
+	require.NoError(t, json.Unmarshal([]byte(jsonData), &response))
+	assert.JSONEq(t, jsonData, string(response.Raw()))
+
+	var bulk BulkResponse
+	require.NoError(t, json.Unmarshal([]byte("["+jsonData+"]"), &bulk))
+	assert.JSONEq(t, "["+jsonData+"]", string(bulk.Raw()))
+	assert.JSONEq(t, jsonData, string(bulk.Responses[0].Raw()))
+}
+
 func TestIndicatorResponseGetValue(t *testing.T) {
 	response := &IndicatorResponse{
 		Indicator: "rsi",
@@ -131,19 +144,3 @@ func TestBulkResponseFilterByIndicator(t *testing.T) {
 	filtered := response.FilterByIndicator("rsi")
 	assert.Equal(t, 2, len(filtered))
 }
-
-func TestCandleToArray(t *testing.T) {
-	candle := &Candle{
-		Timestamp: 1609459200,
-		Open:      28923.63,
-		High:      28923.63,
-		Low:       28923.63,
-		Close:     28923.63,
-		Volume:    0.0,
-	}
-	
-	array := candle.ToArray()
-	assert.Equal(t, 6, len(array))
-	assert.Equal(t, int64(1609459200), array[0])
-	assert.Equal(t, 28923.63, array[1])
-}
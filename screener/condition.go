@@ -0,0 +1,250 @@
+package screener
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// Operand is a numeric input of a condition, such as an indicator output,
+// a price or a constant
+type Operand interface {
+	// Requests returns the indicator requests the operand needs
+	Requests() []taapi.ParamSet
+	// Value returns the operand's value for one symbol
+	Value(r *Results) (float64, error)
+	String() string
+}
+
+// Condition is a filter evaluated per symbol
+type Condition interface {
+	// Requests returns the indicator requests the condition needs
+	Requests() []taapi.ParamSet
+	// Eval reports whether the symbol matches
+	Eval(r *Results) (bool, error)
+	String() string
+}
+
+// IndicatorOperand is an output of an indicator, "value" by default
+type IndicatorOperand struct {
+	params taapi.ParamSet
+	output string
+}
+
+// Indicator returns an operand for the "value" output of an indicator, e.g.
+// Indicator(taapi.RSI().Period(14))
+func Indicator(p taapi.ParamSet) *IndicatorOperand {
+	return &IndicatorOperand{params: p, output: "value"}
+}
+
+// Output selects another output of the indicator, e.g. "valueMACDSignal"
+func (o *IndicatorOperand) Output(name string) *IndicatorOperand {
+	return &IndicatorOperand{params: o.params, output: name}
+}
+
+// Requests implements Operand
+func (o *IndicatorOperand) Requests() []taapi.ParamSet {
+	return []taapi.ParamSet{o.params}
+}
+
+// Value implements Operand
+func (o *IndicatorOperand) Value(r *Results) (float64, error) {
+	return r.Value(o.params, o.output)
+}
+
+// String returns the operand's label, e.g. rsi(period=14)
+func (o *IndicatorOperand) String() string {
+	return label(o.params, o.output)
+}
+
+// candle requests the latest candle for the price operands
+func candle(output string) *IndicatorOperand {
+	return Indicator(taapi.NewIndicatorParams(taapi.IndicatorCANDLE)).Output(output)
+}
+
+// Open returns the open price of the latest candle
+func Open() *IndicatorOperand { return candle("open") }
+
+// High returns the high price of the latest candle
+func High() *IndicatorOperand { return candle("high") }
+
+// Low returns the low price of the latest candle
+func Low() *IndicatorOperand { return candle("low") }
+
+// Close returns the close price of the latest candle
+func Close() *IndicatorOperand { return candle("close") }
+
+// Volume returns the volume of the latest candle
+func Volume() *IndicatorOperand { return candle("volume") }
+
+// constOperand is a constant operand
+type constOperand float64
+
+// Const returns a constant operand
+func Const(v float64) Operand {
+	return constOperand(v)
+}
+
+func (c constOperand) Requests() []taapi.ParamSet { return nil }
+
+func (c constOperand) Value(*Results) (float64, error) { return float64(c), nil }
+
+func (c constOperand) String() string {
+	return strconv.FormatFloat(float64(c), 'f', -1, 64)
+}
+
+// comparison compares two operands
+type comparison struct {
+	a, b Operand
+	op   string
+	cmp  func(a, b float64) bool
+}
+
+func (c *comparison) Requests() []taapi.ParamSet {
+	return append(c.a.Requests(), c.b.Requests()...)
+}
+
+func (c *comparison) Eval(r *Results) (bool, error) {
+	a, err := c.a.Value(r)
+	if err != nil {
+		return false, err
+	}
+	b, err := c.b.Value(r)
+	if err != nil {
+		return false, err
+	}
+	return c.cmp(a, b), nil
+}
+
+func (c *comparison) String() string {
+	return fmt.Sprintf("%s %s %s", c.a, c.op, c.b)
+}
+
+// LessThan matches when a < b
+func LessThan(a, b Operand) Condition {
+	return &comparison{a: a, b: b, op: "<", cmp: func(a, b float64) bool { return a < b }}
+}
+
+// LessOrEqual matches when a <= b
+func LessOrEqual(a, b Operand) Condition {
+	return &comparison{a: a, b: b, op: "<=", cmp: func(a, b float64) bool { return a <= b }}
+}
+
+// GreaterThan matches when a > b
+func GreaterThan(a, b Operand) Condition {
+	return &comparison{a: a, b: b, op: ">", cmp: func(a, b float64) bool { return a > b }}
+}
+
+// GreaterOrEqual matches when a >= b
+func GreaterOrEqual(a, b Operand) Condition {
+	return &comparison{a: a, b: b, op: ">=", cmp: func(a, b float64) bool { return a >= b }}
+}
+
+// Between matches when lo <= x <= hi
+func Between(x, lo, hi Operand) Condition {
+	return And(GreaterOrEqual(x, lo), LessOrEqual(x, hi))
+}
+
+// logical combines conditions with AND or OR
+type logical struct {
+	conds []Condition
+	and   bool
+}
+
+// And matches when every condition matches
+func And(conds ...Condition) Condition {
+	return &logical{conds: conds, and: true}
+}
+
+// Or matches when any condition matches
+func Or(conds ...Condition) Condition {
+	return &logical{conds: conds}
+}
+
+func (l *logical) Requests() []taapi.ParamSet {
+	var requests []taapi.ParamSet
+	for _, c := range l.conds {
+		requests = append(requests, c.Requests()...)
+	}
+	return requests
+}
+
+func (l *logical) Eval(r *Results) (bool, error) {
+	for _, c := range l.conds {
+		ok, err := c.Eval(r)
+		if err != nil {
+			return false, err
+		}
+		if ok != l.and {
+			return ok, nil
+		}
+	}
+	return l.and, nil
+}
+
+func (l *logical) String() string {
+	op := " OR "
+	if l.and {
+		op = " AND "
+	}
+	parts := make([]string, len(l.conds))
+	for i, c := range l.conds {
+		parts[i] = c.String()
+		if _, nested := c.(*logical); nested {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, op)
+}
+
+// not negates a condition
+type not struct {
+	cond Condition
+}
+
+// Not matches when the condition does not match
+func Not(c Condition) Condition {
+	return &not{cond: c}
+}
+
+func (n *not) Requests() []taapi.ParamSet { return n.cond.Requests() }
+
+func (n *not) Eval(r *Results) (bool, error) {
+	ok, err := n.cond.Eval(r)
+	return !ok, err
+}
+
+func (n *not) String() string {
+	return "NOT (" + n.cond.String() + ")"
+}
+
+// RequestKey identifies an indicator request by its indicator and
+// parameters, ignoring the id, e.g. rsi(period=14)
+func RequestKey(p taapi.ParamSet) string {
+	params := p.Params()
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		if k != "id" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%v", k, params[k])
+	}
+	return fmt.Sprintf("%s(%s)", p.Indicator(), strings.Join(parts, ","))
+}
+
+// label names an indicator output, e.g. rsi(period=14) for the value output
+// or macd().valueMACDSignal
+func label(p taapi.ParamSet, output string) string {
+	if output == "value" {
+		return RequestKey(p)
+	}
+	return RequestKey(p) + "." + output
+}
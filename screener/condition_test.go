@@ -0,0 +1,54 @@
+package screener
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+func TestConditions(t *testing.T) {
+	rsi := taapi.RSI().Period(14)
+	macd := taapi.MACD()
+	r := NewResults("BTC/USDT").
+		Set(rsi, &taapi.IndicatorResponse{Data: map[string]interface{}{"value": 25.0}}).
+		Set(macd, &taapi.IndicatorResponse{Data: map[string]interface{}{
+			"result": map[string]interface{}{"valueMACD": 1.5, "valueMACDSignal": 1.0},
+		}})
+
+	tests := []struct {
+		cond Condition
+		want bool
+		str  string
+	}{
+		{LessThan(Indicator(rsi), Const(30)), true, "rsi(period=14) < 30"},
+		{GreaterThan(Indicator(macd).Output("valueMACD"), Indicator(macd).Output("valueMACDSignal")), true,
+			"macd().valueMACD > macd().valueMACDSignal"},
+		{Between(Indicator(rsi), Const(30), Const(70)), false, "rsi(period=14) >= 30 AND rsi(period=14) <= 70"},
+		{Or(LessOrEqual(Indicator(rsi), Const(20)), Not(GreaterOrEqual(Indicator(rsi), Const(25)))), false,
+			"rsi(period=14) <= 20 OR NOT (rsi(period=14) >= 25)"},
+		{And(LessThan(Indicator(rsi), Const(30)), Or(LessThan(Const(1), Const(0)), GreaterThan(Const(1), Const(0)))), true,
+			"rsi(period=14) < 30 AND (1 < 0 OR 1 > 0)"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.cond.Eval(r)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.str)
+		assert.Equal(t, tt.str, tt.cond.String())
+	}
+
+	_, err := LessThan(Indicator(taapi.EMA()), Const(1)).Eval(r)
+	assert.Error(t, err)
+
+	_, err = LessThan(Indicator(macd).Output("missing"), Const(1)).Eval(r)
+	assert.Error(t, err)
+}
+
+func TestRequestKey(t *testing.T) {
+	assert.Equal(t, "bbands(period=20,stddev=2)", RequestKey(taapi.BBands().StdDev(2).Period(20).ID("x")))
+	assert.Equal(t, "candle()", RequestKey(taapi.NewIndicatorParams(taapi.IndicatorCANDLE)))
+	assert.Equal(t, "candle().close", Close().String())
+}
@@ -0,0 +1,115 @@
+package screener
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// Results holds the indicator responses of one symbol, keyed by request
+type Results struct {
+	Symbol    string
+	responses map[string]*taapi.IndicatorResponse
+	// err is the error of a failed bulk request for the symbol
+	err error
+}
+
+// NewResults creates empty results, e.g. to evaluate conditions against
+// responses fetched without a screener
+func NewResults(symbol string) *Results {
+	return newResults(symbol)
+}
+
+func newResults(symbol string) *Results {
+	return &Results{
+		Symbol:    symbol,
+		responses: make(map[string]*taapi.IndicatorResponse),
+	}
+}
+
+// Set stores the response of an indicator request. Both bulk items, with a
+// "result" object, and direct responses are accepted.
+func (r *Results) Set(p taapi.ParamSet, resp *taapi.IndicatorResponse) *Results {
+	r.add(RequestKey(p), resp)
+	return r
+}
+
+// add stores a response by request key
+func (r *Results) add(key string, resp *taapi.IndicatorResponse) {
+	r.responses[key] = resp
+}
+
+// Response returns the response of an indicator request
+func (r *Results) Response(p taapi.ParamSet) (*taapi.IndicatorResponse, bool) {
+	resp, ok := r.responses[RequestKey(p)]
+	return resp, ok
+}
+
+// Value returns a numeric output of an indicator request
+func (r *Results) Value(p taapi.ParamSet, output string) (float64, error) {
+	resp, ok := r.Response(p)
+	if !ok {
+		return 0, fmt.Errorf("screener: no result for %s", RequestKey(p))
+	}
+	v, ok := result(resp)[output].(float64)
+	if !ok {
+		return 0, fmt.Errorf("screener: %s has no numeric output %s", RequestKey(p), output)
+	}
+	return v, nil
+}
+
+// Err returns the error of a failed bulk request for the symbol, or else
+// the first error taapi.io reported for it, by request key
+func (r *Results) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	keys := make([]string, 0, len(r.responses))
+	for key := range r.responses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		errs, _ := r.responses[key].Data["errors"].([]interface{})
+		if len(errs) == 0 {
+			continue
+		}
+		messages := make([]string, len(errs))
+		for i, e := range errs {
+			messages[i] = fmt.Sprint(e)
+		}
+		return fmt.Errorf("screener: %s: %s", key, strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// values returns every numeric output keyed by label
+func (r *Results) values() map[string]float64 {
+	values := make(map[string]float64)
+	for key, resp := range r.responses {
+		for output, v := range result(resp) {
+			f, ok := v.(float64)
+			if !ok {
+				continue
+			}
+			if output == "value" {
+				values[key] = f
+			} else {
+				values[key+"."+output] = f
+			}
+		}
+	}
+	return values
+}
+
+// result returns the output fields of a bulk item or direct response
+func result(resp *taapi.IndicatorResponse) map[string]interface{} {
+	if fields, ok := resp.Data["result"].(map[string]interface{}); ok {
+		return fields
+	}
+	return resp.Data
+}
@@ -0,0 +1,332 @@
+// Package screener runs indicator conditions across a symbol universe using
+// bulk requests and returns the matching symbols ranked with their values:
+//
+//	s := screener.New(client).
+//		Exchange(taapi.ExchangeBinance).
+//		Interval(taapi.Interval1h).
+//		Symbols("BTC/USDT", "ETH/USDT", "SOL/USDT").
+//		Where(screener.And(
+//			screener.LessThan(screener.Indicator(taapi.RSI().Period(14)), screener.Const(30)),
+//			screener.GreaterThan(screener.Close(), screener.Indicator(taapi.EMA().Period(200))),
+//		)).
+//		RankBy(screener.Indicator(taapi.RSI().Period(14)), screener.Ascending)
+//
+//	result, err := s.Run(ctx)
+//
+// Every indicator a condition needs is requested once per symbol. Symbols are
+// packed into bulk requests of at most MaxConstructs constructs, with at
+// most taapi.MaxConstructIndicators indicators per construct.
+package screener
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// DefaultMaxConstructs is the default number of constructs per bulk request
+const DefaultMaxConstructs = 10
+
+// Order is the sort order of ranked matches
+type Order int
+
+const (
+	// Descending ranks the highest values first
+	Descending Order = iota
+	// Ascending ranks the lowest values first
+	Ascending
+)
+
+// Screener filters a symbol universe by a condition
+type Screener struct {
+	executor      taapi.BulkExecutor
+	asset         taapi.AssetType
+	exchange      taapi.Exchange
+	interval      taapi.Interval
+	symbols       []string
+	condition     Condition
+	rank          Operand
+	order         Order
+	limit         int
+	maxConstructs int
+}
+
+// Match is a symbol satisfying the condition
+type Match struct {
+	Symbol string
+	// Score is the value of the ranking operand; zero without RankBy
+	Score float64
+	// Values holds every numeric indicator output fetched for the symbol,
+	// keyed by label such as rsi(period=14) or candle().close
+	Values map[string]float64
+}
+
+// Result is the outcome of a screen
+type Result struct {
+	// Matches are the matching symbols, ranked
+	Matches []Match
+	// Failures holds the symbols that could not be evaluated, e.g. because
+	// taapi.io returned an error for one of their indicators or their bulk
+	// request failed
+	Failures map[string]error
+}
+
+// New creates a screener executing bulk requests with the executor, usually
+// a *taapi.Client
+func New(executor taapi.BulkExecutor) *Screener {
+	return &Screener{
+		executor:      executor,
+		maxConstructs: DefaultMaxConstructs,
+	}
+}
+
+// Type sets the asset class of the symbols
+func (s *Screener) Type(asset taapi.AssetType) *Screener {
+	s.asset = asset
+	return s
+}
+
+// Exchange sets the exchange
+func (s *Screener) Exchange(exchange taapi.Exchange) *Screener {
+	s.exchange = exchange
+	return s
+}
+
+// Interval sets the interval
+func (s *Screener) Interval(interval taapi.Interval) *Screener {
+	s.interval = interval
+	return s
+}
+
+// Symbols adds symbols to the universe
+func (s *Screener) Symbols(symbols ...string) *Screener {
+	s.symbols = append(s.symbols, symbols...)
+	return s
+}
+
+// Where sets the condition symbols must match
+func (s *Screener) Where(c Condition) *Screener {
+	s.condition = c
+	return s
+}
+
+// RankBy orders the matches by the value of an operand. Without it matches
+// keep the order of the universe.
+func (s *Screener) RankBy(op Operand, order Order) *Screener {
+	s.rank = op
+	s.order = order
+	return s
+}
+
+// Limit keeps only the first n ranked matches
+func (s *Screener) Limit(n int) *Screener {
+	s.limit = n
+	return s
+}
+
+// MaxConstructs sets the number of constructs per bulk request, which
+// depends on the taapi.io plan
+func (s *Screener) MaxConstructs(n int) *Screener {
+	s.maxConstructs = n
+	return s
+}
+
+// request is an indicator request of one symbol
+type request struct {
+	symbol int
+	key    string
+}
+
+// Fetch executes the bulk requests of the screen without evaluating the
+// condition and returns the results of every symbol, in order. When a bulk
+// request fails, the results of its symbols carry the error (see
+// Results.Err) and the other requests proceed; Fetch only fails when the
+// context ends or every request fails.
+func (s *Screener) Fetch(ctx context.Context) ([]*Results, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	requests := s.requests()
+	constructs, ids := s.constructs(requests)
+
+	results := make([]*Results, len(s.symbols))
+	for i, symbol := range s.symbols {
+		results[i] = newResults(symbol)
+	}
+
+	var firstErr error
+	chunks, failed := 0, 0
+	for start := 0; start < len(constructs); start += s.maxConstructs {
+		end := start + s.maxConstructs
+		if end > len(constructs) {
+			end = len(constructs)
+		}
+		chunks++
+		resp, err := s.executor.ExecuteBulk(ctx, constructs[start:end]...)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+			for _, construct := range constructs[start:end] {
+				req := ids[constructID(construct)]
+				if results[req.symbol].err == nil {
+					results[req.symbol].err = fmt.Errorf("screener: bulk request failed: %w", err)
+				}
+			}
+			continue
+		}
+		for _, item := range resp.Responses {
+			req, ok := ids[item.ID]
+			if !ok {
+				continue
+			}
+			results[req.symbol].add(req.key, item)
+		}
+	}
+	if failed > 0 && failed == chunks {
+		return nil, firstErr
+	}
+	return results, nil
+}
+
+// constructID returns the id of the first indicator of a construct
+func constructID(construct taapi.BulkConstruct) string {
+	id, _ := construct.Indicators[0].Params()["id"].(string)
+	return id
+}
+
+// Run executes the screen
+func (s *Screener) Run(ctx context.Context) (*Result, error) {
+	results, err := s.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Failures: make(map[string]error)}
+	for _, r := range results {
+		match, ok, err := s.evaluate(r)
+		if err != nil {
+			result.Failures[r.Symbol] = err
+			continue
+		}
+		if ok {
+			result.Matches = append(result.Matches, match)
+		}
+	}
+
+	if s.rank != nil {
+		sort.SliceStable(result.Matches, func(i, j int) bool {
+			if s.order == Ascending {
+				return result.Matches[i].Score < result.Matches[j].Score
+			}
+			return result.Matches[i].Score > result.Matches[j].Score
+		})
+	}
+	if s.limit > 0 && len(result.Matches) > s.limit {
+		result.Matches = result.Matches[:s.limit]
+	}
+	return result, nil
+}
+
+// validate checks the screen definition
+func (s *Screener) validate() error {
+	switch {
+	case s.exchange == "" && s.asset.RequiresExchange():
+		return taapi.InvalidArgumentError("screener: exchange is required")
+	case s.interval == "":
+		return taapi.InvalidArgumentError("screener: interval is required")
+	case len(s.symbols) == 0:
+		return taapi.InvalidArgumentError("screener: at least one symbol is required")
+	case s.condition == nil:
+		return taapi.InvalidArgumentError("screener: a condition is required")
+	case s.maxConstructs < 1:
+		return taapi.InvalidArgumentError("screener: max constructs must be positive")
+	}
+	return nil
+}
+
+// requests returns the distinct indicator requests of the condition and the
+// ranking operand
+func (s *Screener) requests() []taapi.ParamSet {
+	all := s.condition.Requests()
+	if s.rank != nil {
+		all = append(all, s.rank.Requests()...)
+	}
+
+	seen := make(map[string]bool)
+	var requests []taapi.ParamSet
+	for _, p := range all {
+		key := RequestKey(p)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		requests = append(requests, p)
+	}
+	return requests
+}
+
+// constructs builds the bulk constructs of every symbol and maps the ids it
+// assigns back to the requests
+func (s *Screener) constructs(requests []taapi.ParamSet) ([]taapi.BulkConstruct, map[string]request) {
+	ids := make(map[string]request)
+	var constructs []taapi.BulkConstruct
+
+	for i, symbol := range s.symbols {
+		for start := 0; start < len(requests); start += taapi.MaxConstructIndicators {
+			end := start + taapi.MaxConstructIndicators
+			if end > len(requests) {
+				end = len(requests)
+			}
+
+			construct := taapi.BulkConstruct{
+				Type:     s.asset,
+				Exchange: s.exchange,
+				Symbol:   symbol,
+				Interval: s.interval,
+			}
+			for j, p := range requests[start:end] {
+				id := fmt.Sprintf("s%d_r%d", i, start+j)
+				ids[id] = request{symbol: i, key: RequestKey(p)}
+				construct.Indicators = append(construct.Indicators, withID(p, id))
+			}
+			constructs = append(constructs, construct)
+		}
+	}
+	return constructs, ids
+}
+
+// withID returns a copy of the parameter set with the id set
+func withID(p taapi.ParamSet, id string) taapi.ParamSet {
+	params := taapi.NewIndicatorParams(p.Indicator())
+	for k, v := range p.Params() {
+		params.Set(k, v)
+	}
+	return params.Set("id", id)
+}
+
+// evaluate applies the condition and ranking to the results of a symbol
+func (s *Screener) evaluate(r *Results) (Match, bool, error) {
+	if err := r.Err(); err != nil {
+		return Match{}, false, err
+	}
+	ok, err := s.condition.Eval(r)
+	if err != nil || !ok {
+		return Match{}, false, err
+	}
+
+	match := Match{Symbol: r.Symbol, Values: r.values()}
+	if s.rank != nil {
+		if match.Score, err = s.rank.Value(r); err != nil {
+			return Match{}, false, err
+		}
+	}
+	return match, true, nil
+}
@@ -0,0 +1,204 @@
+package screener
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigusigalpa/taapi-go"
+	"github.com/tigusigalpa/taapi-go/taapitest"
+)
+
+// market scripts RSI, EMA and close per symbol
+func market(server *taapitest.Server, rsi, ema, close map[string]float64) {
+	server.HandleFunc(taapi.IndicatorRSI, func(r taapitest.Request) taapitest.Response {
+		if r.Param("symbol") == "DOGE/USDT" {
+			return taapitest.InvalidSymbol("DOGE/USDT")
+		}
+		return taapitest.Value(rsi[r.Param("symbol")])
+	})
+	server.HandleFunc(taapi.IndicatorEMA, func(r taapitest.Request) taapitest.Response {
+		return taapitest.Value(ema[r.Param("symbol")])
+	})
+	server.HandleFunc(taapi.IndicatorCANDLE, func(r taapitest.Request) taapitest.Response {
+		return taapitest.Values(map[string]float64{"close": close[r.Param("symbol")], "open": 1})
+	})
+}
+
+func TestScreenerRun(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	market(server,
+		map[string]float64{"BTC/USDT": 25, "ETH/USDT": 45, "SOL/USDT": 20, "ADA/USDT": 28},
+		map[string]float64{"BTC/USDT": 100, "ETH/USDT": 100, "SOL/USDT": 100, "ADA/USDT": 100},
+		map[string]float64{"BTC/USDT": 120, "ETH/USDT": 120, "SOL/USDT": 130, "ADA/USDT": 90},
+	)
+
+	rsi := Indicator(taapi.RSI().Period(14))
+	result, err := New(server.Client()).
+		Exchange(taapi.ExchangeBinance).
+		Interval(taapi.Interval1h).
+		Symbols("BTC/USDT", "ETH/USDT", "SOL/USDT", "ADA/USDT", "DOGE/USDT").
+		Where(And(
+			LessThan(rsi, Const(30)),
+			GreaterThan(Close(), Indicator(taapi.EMA().Period(200))),
+		)).
+		RankBy(rsi, Ascending).
+		MaxConstructs(2).
+		Run(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, result.Matches, 2)
+	assert.Equal(t, "SOL/USDT", result.Matches[0].Symbol)
+	assert.Equal(t, 20.0, result.Matches[0].Score)
+	assert.Equal(t, "BTC/USDT", result.Matches[1].Symbol)
+	assert.Equal(t, map[string]float64{
+		"rsi(period=14)":  25,
+		"ema(period=200)": 100,
+		"candle().close":  120,
+		"candle().open":   1,
+	}, result.Matches[1].Values)
+
+	require.Contains(t, result.Failures, "DOGE/USDT")
+	assert.Contains(t, result.Failures["DOGE/USDT"].Error(), "Invalid symbol")
+
+	// 5 symbols in chunks of 2 constructs, each indicator requested once per symbol
+	server.AssertCalled(t, taapi.IndicatorRSI, 5)
+	assert.Len(t, server.Requests(), 15)
+}
+
+func TestScreenerLimitAndOrder(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	market(server, map[string]float64{"BTC/USDT": 60, "ETH/USDT": 70, "SOL/USDT": 80}, nil, nil)
+
+	rsi := Indicator(taapi.RSI())
+	result, err := New(server.Client()).
+		Exchange(taapi.ExchangeBinance).
+		Interval(taapi.Interval4h).
+		Symbols("BTC/USDT", "ETH/USDT", "SOL/USDT").
+		Where(GreaterOrEqual(rsi, Const(50))).
+		RankBy(rsi, Descending).
+		Limit(2).
+		Run(context.Background())
+	require.NoError(t, err)
+	require.Len(t, result.Matches, 2)
+	assert.Equal(t, "SOL/USDT", result.Matches[0].Symbol)
+	assert.Equal(t, "ETH/USDT", result.Matches[1].Symbol)
+	assert.Empty(t, result.Failures)
+}
+
+func TestScreenerFetch(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	market(server,
+		map[string]float64{"BTC/USDT": 25},
+		map[string]float64{"BTC/USDT": 100},
+		map[string]float64{"BTC/USDT": 120},
+	)
+
+	results, err := New(server.Client()).
+		Exchange(taapi.ExchangeBinance).
+		Interval(taapi.Interval1h).
+		Symbols("BTC/USDT", "DOGE/USDT").
+		Where(LessThan(Indicator(taapi.RSI().Period(14)), Close())).
+		Fetch(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	assert.Equal(t, "BTC/USDT", results[0].Symbol)
+	assert.NoError(t, results[0].Err())
+	v, err := results[0].Value(taapi.RSI().Period(14), "value")
+	require.NoError(t, err)
+	assert.Equal(t, 25.0, v)
+	assert.Error(t, results[1].Err())
+}
+
+// failingExecutor fails the bulk requests containing a symbol
+type failingExecutor struct {
+	taapi.BulkExecutor
+	symbol string
+	err    error
+}
+
+func (e failingExecutor) ExecuteBulk(ctx context.Context, constructs ...taapi.BulkConstruct) (*taapi.BulkResponse, error) {
+	for _, c := range constructs {
+		if c.Symbol == e.symbol {
+			return nil, e.err
+		}
+	}
+	return e.BulkExecutor.ExecuteBulk(ctx, constructs...)
+}
+
+func TestScreenerKeepsResultsOfFailedChunk(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	market(server, map[string]float64{"BTC/USDT": 20, "ETH/USDT": 25, "SOL/USDT": 28}, nil, nil)
+
+	failure := errors.New("connection reset")
+	screen := func(symbols ...string) *Screener {
+		return New(failingExecutor{BulkExecutor: server.Client(), symbol: "ETH/USDT", err: failure}).
+			Exchange(taapi.ExchangeBinance).
+			Interval(taapi.Interval1h).
+			Symbols(symbols...).
+			Where(LessThan(Indicator(taapi.RSI()), Const(30))).
+			MaxConstructs(1)
+	}
+
+	result, err := screen("BTC/USDT", "ETH/USDT", "SOL/USDT").Run(context.Background())
+	require.NoError(t, err)
+	require.Len(t, result.Matches, 2)
+	assert.Equal(t, "BTC/USDT", result.Matches[0].Symbol)
+	assert.Equal(t, "SOL/USDT", result.Matches[1].Symbol)
+	require.Contains(t, result.Failures, "ETH/USDT")
+	assert.ErrorIs(t, result.Failures["ETH/USDT"], failure)
+
+	// Without any successful request the error is returned
+	_, err = screen("ETH/USDT").Run(context.Background())
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestResultsErrIsDeterministic(t *testing.T) {
+	r := NewResults("BTC/USDT")
+	for _, key := range []string{"sma(period=9)", "ema(period=20)", "rsi(period=14)", "adx(period=14)"} {
+		r.add(key, &taapi.IndicatorResponse{Data: map[string]interface{}{
+			"errors": []interface{}{"failed " + key},
+		}})
+	}
+	for i := 0; i < 20; i++ {
+		assert.EqualError(t, r.Err(), "screener: adx(period=14): failed adx(period=14)")
+	}
+}
+
+func TestScreenerChunksIndicators(t *testing.T) {
+	var conds []Condition
+	for period := 1; period <= 25; period++ {
+		conds = append(conds, GreaterThan(Indicator(taapi.SMA().Period(period)), Const(0)))
+	}
+
+	s := New(nil).Exchange(taapi.ExchangeBinance).Interval(taapi.Interval1h).
+		Symbols("BTC/USDT", "ETH/USDT").Where(And(conds...))
+	constructs, ids := s.constructs(s.requests())
+	require.Len(t, constructs, 4)
+	assert.Len(t, constructs[0].Indicators, taapi.MaxConstructIndicators)
+	assert.Len(t, constructs[1].Indicators, 5)
+	assert.Equal(t, "ETH/USDT", constructs[2].Symbol)
+	assert.Len(t, ids, 50)
+}
+
+func TestScreenerValidation(t *testing.T) {
+	cond := LessThan(Indicator(taapi.RSI()), Const(30))
+	tests := []*Screener{
+		New(nil).Interval(taapi.Interval1h).Symbols("BTC/USDT").Where(cond),
+		New(nil).Exchange(taapi.ExchangeBinance).Symbols("BTC/USDT").Where(cond),
+		New(nil).Exchange(taapi.ExchangeBinance).Interval(taapi.Interval1h).Where(cond),
+		New(nil).Exchange(taapi.ExchangeBinance).Interval(taapi.Interval1h).Symbols("BTC/USDT"),
+	}
+	for _, s := range tests {
+		_, err := s.Run(context.Background())
+		assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+	}
+}
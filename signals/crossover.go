@@ -0,0 +1,81 @@
+package signals
+
+import "time"
+
+// Direction is the direction of a crossover
+type Direction int
+
+const (
+	// CrossAbove means the first series moved above the second
+	CrossAbove Direction = iota
+	// CrossBelow means the first series moved below the second
+	CrossBelow
+)
+
+// String returns the string representation of the direction
+func (d Direction) String() string {
+	if d == CrossBelow {
+		return "below"
+	}
+	return "above"
+}
+
+// Crossover is a candle where one series crossed another
+type Crossover struct {
+	Direction Direction
+	// Index is the position of the candle in the aligned series
+	Index int
+	// Time is the candle open time; zero for series without times
+	Time time.Time
+	// A and B are the values of both series at the candle
+	A, B float64
+}
+
+// Crossovers returns the candles where a crosses b, oldest first. The
+// series are aligned first. Candles where both are equal do not count as a
+// side, so touching b and turning back is not a crossover.
+func Crossovers(a, b Series) []Crossover {
+	aligned := Align(a, b)
+	a, b = aligned[0], aligned[1]
+
+	var crosses []Crossover
+	side := 0
+	for i := range a {
+		var current int
+		switch {
+		case a[i].Value > b[i].Value:
+			current = 1
+		case a[i].Value < b[i].Value:
+			current = -1
+		default:
+			continue
+		}
+		if side != 0 && current != side {
+			c := Crossover{Direction: CrossAbove, Index: i, Time: a[i].Time, A: a[i].Value, B: b[i].Value}
+			if current < 0 {
+				c.Direction = CrossBelow
+			}
+			crosses = append(crosses, c)
+		}
+		side = current
+	}
+	return crosses
+}
+
+// ThresholdCrossings returns the candles where the series crosses a level,
+// e.g. RSI leaving the oversold zone. B of every crossing is the level.
+func ThresholdCrossings(s Series, level float64) []Crossover {
+	levels := make(Series, len(s))
+	for i, p := range s {
+		levels[i] = Point{Time: p.Time, Value: level}
+	}
+	return Crossovers(s, levels)
+}
+
+// Last returns the latest crossover, if any
+func Last(crosses []Crossover) (Crossover, bool) {
+	if len(crosses) == 0 {
+		return Crossover{}, false
+	}
+	return crosses[len(crosses)-1], true
+}
@@ -0,0 +1,42 @@
+package signals
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrossovers(t *testing.T) {
+	fast := timed(0, 9, 10, 11, 12, 10, 9, 10, 11)
+	slow := timed(0, 10, 10, 10, 10, 10, 10, 10, 10)
+
+	assert.Equal(t, []Crossover{
+		{Direction: CrossAbove, Index: 2, Time: hour(2), A: 11, B: 10},
+		{Direction: CrossBelow, Index: 5, Time: hour(5), A: 9, B: 10},
+		{Direction: CrossAbove, Index: 7, Time: hour(7), A: 11, B: 10},
+	}, Crossovers(fast, slow))
+
+	// Touching and turning back is not a crossover
+	assert.Empty(t, Crossovers(FromValues(11, 10, 11), FromValues(10, 10, 10)))
+	assert.Empty(t, Crossovers(FromValues(1, 2), FromValues(3)))
+	assert.Empty(t, Crossovers(nil, nil))
+}
+
+func TestThresholdCrossings(t *testing.T) {
+	rsi := FromValues(35, 28, 25, 31, 45, 72, 68)
+
+	crosses := ThresholdCrossings(rsi, 30)
+	assert.Equal(t, []Crossover{
+		{Direction: CrossBelow, Index: 1, A: 28, B: 30},
+		{Direction: CrossAbove, Index: 3, A: 31, B: 30},
+	}, crosses)
+
+	last, ok := Last(ThresholdCrossings(rsi, 70))
+	assert.True(t, ok)
+	assert.Equal(t, Crossover{Direction: CrossBelow, Index: 6, A: 68, B: 70}, last)
+	assert.Equal(t, "below", last.Direction.String())
+	assert.Equal(t, "above", CrossAbove.String())
+
+	_, ok = Last(nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,174 @@
+package signals
+
+import (
+	"sort"
+	"time"
+)
+
+// DivergenceKind classifies a divergence between price and an oscillator
+type DivergenceKind int
+
+const (
+	// RegularBullish is a lower price low with a higher oscillator low,
+	// signalling a possible reversal up
+	RegularBullish DivergenceKind = iota
+	// RegularBearish is a higher price high with a lower oscillator high,
+	// signalling a possible reversal down
+	RegularBearish
+	// HiddenBullish is a higher price low with a lower oscillator low,
+	// signalling a continuation of an uptrend
+	HiddenBullish
+	// HiddenBearish is a lower price high with a higher oscillator high,
+	// signalling a continuation of a downtrend
+	HiddenBearish
+)
+
+// String returns the string representation of the kind
+func (k DivergenceKind) String() string {
+	switch k {
+	case RegularBearish:
+		return "regular bearish"
+	case HiddenBullish:
+		return "hidden bullish"
+	case HiddenBearish:
+		return "hidden bearish"
+	default:
+		return "regular bullish"
+	}
+}
+
+// Bullish reports whether the divergence is bullish
+func (k DivergenceKind) Bullish() bool {
+	return k == RegularBullish || k == HiddenBullish
+}
+
+// Hidden reports whether the divergence is hidden
+func (k DivergenceKind) Hidden() bool {
+	return k == HiddenBullish || k == HiddenBearish
+}
+
+// Pivot is a swing high or low of the price with the oscillator value at
+// the same candle
+type Pivot struct {
+	// Index is the position of the candle in the aligned series
+	Index int
+	// Time is the candle open time; zero for series without times
+	Time       time.Time
+	Price      float64
+	Oscillator float64
+}
+
+// Divergence is a disagreement between two price pivots and the oscillator
+type Divergence struct {
+	Kind DivergenceKind
+	// From and To are the earlier and the later pivot
+	From, To Pivot
+}
+
+// DivergenceOptions configures divergence detection
+type DivergenceOptions struct {
+	// PivotLeft and PivotRight are the candles before and after a pivot that
+	// must not exceed it; 5 and 2 by default. A pivot is confirmed PivotRight
+	// candles after it formed, so the latest candles cannot be pivots.
+	PivotLeft, PivotRight int
+	// MinDistance and MaxDistance bound the candles between the two pivots;
+	// 5 and 60 by default
+	MinDistance, MaxDistance int
+	// Hidden also reports hidden divergences; only regular ones by default
+	Hidden bool
+}
+
+// withDefaults fills unset options
+func (o *DivergenceOptions) withDefaults() DivergenceOptions {
+	opts := DivergenceOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.PivotLeft <= 0 {
+		opts.PivotLeft = 5
+	}
+	if opts.PivotRight <= 0 {
+		opts.PivotRight = 2
+	}
+	if opts.MinDistance <= 0 {
+		opts.MinDistance = 5
+	}
+	if opts.MaxDistance <= 0 {
+		opts.MaxDistance = 60
+	}
+	return opts
+}
+
+// Divergences finds divergences between the price and an oscillator such as
+// RSI, oldest first. Lows of the price are compared on the lows series and
+// highs on the highs series; pass the closes twice to use close prices. The
+// series are aligned first. Consecutive pivots within the distance bounds
+// are compared.
+func Divergences(highs, lows, oscillator Series, opts *DivergenceOptions) []Divergence {
+	o := opts.withDefaults()
+	aligned := Align(highs, lows, oscillator)
+	highs, lows, oscillator = aligned[0], aligned[1], aligned[2]
+
+	var divergences []Divergence
+	pairs := func(pivots []Pivot, fn func(from, to Pivot)) {
+		for i := 1; i < len(pivots); i++ {
+			distance := pivots[i].Index - pivots[i-1].Index
+			if distance >= o.MinDistance && distance <= o.MaxDistance {
+				fn(pivots[i-1], pivots[i])
+			}
+		}
+	}
+
+	lowPivots := pivots(lows, oscillator, o, func(a, b float64) bool { return a < b })
+	highPivots := pivots(highs, oscillator, o, func(a, b float64) bool { return a > b })
+
+	pairs(lowPivots, func(from, to Pivot) {
+		switch {
+		case to.Price < from.Price && to.Oscillator > from.Oscillator:
+			divergences = append(divergences, Divergence{Kind: RegularBullish, From: from, To: to})
+		case o.Hidden && to.Price > from.Price && to.Oscillator < from.Oscillator:
+			divergences = append(divergences, Divergence{Kind: HiddenBullish, From: from, To: to})
+		}
+	})
+	pairs(highPivots, func(from, to Pivot) {
+		switch {
+		case to.Price > from.Price && to.Oscillator < from.Oscillator:
+			divergences = append(divergences, Divergence{Kind: RegularBearish, From: from, To: to})
+		case o.Hidden && to.Price < from.Price && to.Oscillator > from.Oscillator:
+			divergences = append(divergences, Divergence{Kind: HiddenBearish, From: from, To: to})
+		}
+	})
+
+	sort.SliceStable(divergences, func(i, j int) bool {
+		return divergences[i].To.Index < divergences[j].To.Index
+	})
+	return divergences
+}
+
+// pivots returns the candles whose price beats the PivotLeft candles before
+// and the PivotRight candles after; beats is < for lows and > for highs
+func pivots(price, oscillator Series, o DivergenceOptions, beats func(a, b float64) bool) []Pivot {
+	var found []Pivot
+	for i := o.PivotLeft; i+o.PivotRight < len(price); i++ {
+		pivot := true
+		for j := i - o.PivotLeft; j <= i+o.PivotRight && pivot; j++ {
+			// Earlier equal prices block a pivot, later ones do not, so a
+			// flat bottom yields its first candle
+			if j < i && !beats(price[i].Value, price[j].Value) {
+				pivot = false
+			}
+			if j > i && beats(price[j].Value, price[i].Value) {
+				pivot = false
+			}
+		}
+		if pivot {
+			found = append(found, Pivot{
+				Index:      i,
+				Time:       price[i].Time,
+				Price:      price[i].Value,
+				Oscillator: oscillator[i].Value,
+			})
+		}
+	}
+	return found
+}
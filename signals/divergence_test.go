@@ -0,0 +1,76 @@
+package signals
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pivotOptions = &DivergenceOptions{PivotLeft: 2, PivotRight: 2, MinDistance: 3}
+
+func TestDivergencesBullish(t *testing.T) {
+	// Pivot lows at 2 (8) and 6 (7); the oscillator rises from 20 to 30
+	lows := timed(0, 10, 9, 8, 9, 10, 9, 7, 9, 10)
+	highs := timed(0, 11, 10, 9, 10, 11, 10, 8, 10, 11)
+	osc := timed(0, 40, 30, 20, 35, 50, 40, 30, 45, 55)
+
+	assert.Equal(t, []Divergence{{
+		Kind: RegularBullish,
+		From: Pivot{Index: 2, Time: hour(2), Price: 8, Oscillator: 20},
+		To:   Pivot{Index: 6, Time: hour(6), Price: 7, Oscillator: 30},
+	}}, Divergences(highs, lows, osc, pivotOptions))
+
+	// Pivots closer than MinDistance are not compared
+	assert.Empty(t, Divergences(highs, lows, osc, &DivergenceOptions{PivotLeft: 2, PivotRight: 2, MinDistance: 5}))
+}
+
+func TestDivergencesBearish(t *testing.T) {
+	// Pivot highs at 2 (12) and 6 (13); the oscillator falls from 80 to 70
+	highs := FromValues(10, 11, 12, 11, 10, 11, 13, 11, 10)
+	lows := FromValues(9, 10, 11, 10, 9, 10, 12, 10, 9)
+	osc := FromValues(60, 70, 80, 65, 55, 60, 70, 60, 50)
+
+	divergences := Divergences(highs, lows, osc, pivotOptions)
+	assert.Len(t, divergences, 1)
+	assert.Equal(t, RegularBearish, divergences[0].Kind)
+	assert.Equal(t, 2, divergences[0].From.Index)
+	assert.Equal(t, 6, divergences[0].To.Index)
+	assert.False(t, divergences[0].Kind.Bullish())
+}
+
+func TestDivergencesHidden(t *testing.T) {
+	// Higher price low at 6 with a lower oscillator low: hidden bullish
+	lows := FromValues(10, 9, 7, 9, 10, 9, 8, 9, 10)
+	highs := FromValues(11, 10, 8, 10, 11, 10, 9, 10, 11)
+	osc := FromValues(40, 30, 30, 35, 50, 40, 20, 45, 55)
+
+	assert.Empty(t, Divergences(highs, lows, osc, pivotOptions), "hidden divergences are opt-in")
+
+	opts := *pivotOptions
+	opts.Hidden = true
+	divergences := Divergences(highs, lows, osc, &opts)
+	assert.Len(t, divergences, 1)
+	assert.Equal(t, HiddenBullish, divergences[0].Kind)
+	assert.True(t, divergences[0].Kind.Hidden())
+	assert.Equal(t, "hidden bullish", divergences[0].Kind.String())
+}
+
+func TestPivotsNeedConfirmation(t *testing.T) {
+	// The latest low has fewer than PivotRight candles after it
+	lows := FromValues(10, 9, 8, 9, 10, 9, 7, 8)
+	found := pivots(lows, lows, pivotOptions.withDefaults(), func(a, b float64) bool { return a < b })
+	assert.Len(t, found, 1)
+	assert.Equal(t, 2, found[0].Index)
+
+	// A flat bottom yields its first candle
+	flat := FromValues(10, 9, 8, 8, 9, 10)
+	found = pivots(flat, flat, pivotOptions.withDefaults(), func(a, b float64) bool { return a < b })
+	assert.Len(t, found, 1)
+	assert.Equal(t, 2, found[0].Index)
+}
+
+func TestDivergenceKindString(t *testing.T) {
+	assert.Equal(t, "regular bullish", RegularBullish.String())
+	assert.Equal(t, "regular bearish", RegularBearish.String())
+	assert.Equal(t, "hidden bearish", HiddenBearish.String())
+}
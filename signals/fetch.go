@@ -0,0 +1,125 @@
+package signals
+
+import (
+	"context"
+	"sort"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// API is the part of taapi.API a Fetcher uses. *taapi.Client implements it,
+// and so does taapimock.Client for tests.
+type API interface {
+	taapi.SeriesFetcher
+	taapi.CandleFetcher
+}
+
+// Fetcher fetches backtracked series of one market from taapi.io
+type Fetcher struct {
+	api      API
+	asset    taapi.AssetType
+	exchange taapi.Exchange
+	symbol   string
+	interval taapi.Interval
+}
+
+// NewFetcher creates a fetcher for a market
+func NewFetcher(api API, exchange taapi.Exchange, symbol string, interval taapi.Interval) *Fetcher {
+	return &Fetcher{
+		api:      api,
+		exchange: exchange,
+		symbol:   symbol,
+		interval: interval,
+	}
+}
+
+// Type sets the asset type of the market
+func (f *Fetcher) Type(asset taapi.AssetType) *Fetcher {
+	f.asset = asset
+	return f
+}
+
+// Series fetches an output of the last n candles of an indicator, oldest
+// first
+func (f *Fetcher) Series(ctx context.Context, p taapi.ParamSet, output string, n int) (Series, error) {
+	if n < 1 {
+		return nil, taapi.InvalidArgumentError("signals: the number of candles must be positive")
+	}
+	params := make(map[string]interface{}, len(p.Params())+2)
+	for k, v := range p.Params() {
+		params[k] = v
+	}
+	params["backtracks"] = n
+	params["addResultTimestamp"] = true
+
+	responses, err := f.api.FetchSeries(ctx, f.request(p.Indicator(), params))
+	if err != nil {
+		return nil, err
+	}
+	return FromResponses(responses, output)
+}
+
+// Candles fetches the last n candles, oldest first
+func (f *Fetcher) Candles(ctx context.Context, n int) ([]*taapi.Candle, error) {
+	if n < 1 {
+		return nil, taapi.InvalidArgumentError("signals: the number of candles must be positive")
+	}
+	candles, err := f.api.FetchCandles(ctx, f.request(taapi.IndicatorCANDLE, map[string]interface{}{"backtracks": n}))
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(candles, func(i, j int) bool {
+		return candles[i].Time().Before(candles[j].Time())
+	})
+	return candles, nil
+}
+
+// request describes a request of the indicator on the market
+func (f *Fetcher) request(indicator taapi.Indicator, params map[string]interface{}) taapi.DirectRequest {
+	return taapi.DirectRequest{
+		Type:      f.asset,
+		Exchange:  f.exchange,
+		Symbol:    f.symbol,
+		Interval:  f.interval,
+		Indicator: indicator,
+		Params:    params,
+	}
+}
+
+// Crossovers fetches the value output of two indicators over the last n
+// candles and returns where a crosses b
+func (f *Fetcher) Crossovers(ctx context.Context, a, b taapi.ParamSet, n int) ([]Crossover, error) {
+	seriesA, err := f.Series(ctx, a, "value", n)
+	if err != nil {
+		return nil, err
+	}
+	seriesB, err := f.Series(ctx, b, "value", n)
+	if err != nil {
+		return nil, err
+	}
+	return Crossovers(seriesA, seriesB), nil
+}
+
+// ThresholdCrossings fetches the value output of an indicator over the last
+// n candles and returns where it crosses the level
+func (f *Fetcher) ThresholdCrossings(ctx context.Context, p taapi.ParamSet, level float64, n int) ([]Crossover, error) {
+	s, err := f.Series(ctx, p, "value", n)
+	if err != nil {
+		return nil, err
+	}
+	return ThresholdCrossings(s, level), nil
+}
+
+// Divergences fetches the last n candles and the value output of an
+// oscillator such as RSI and returns their divergences
+func (f *Fetcher) Divergences(ctx context.Context, oscillator taapi.ParamSet, n int, opts *DivergenceOptions) ([]Divergence, error) {
+	candles, err := f.Candles(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	osc, err := f.Series(ctx, oscillator, "value", n)
+	if err != nil {
+		return nil, err
+	}
+	return Divergences(Highs(candles), Lows(candles), osc, opts), nil
+}
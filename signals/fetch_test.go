@@ -0,0 +1,120 @@
+package signals
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigusigalpa/taapi-go"
+	"github.com/tigusigalpa/taapi-go/taapimock"
+	"github.com/tigusigalpa/taapi-go/taapitest"
+)
+
+// newestFirst returns a timestamped backtracks response of hourly values
+// given oldest first
+func newestFirst(values ...float64) taapitest.Response {
+	body := make([]map[string]interface{}, len(values))
+	for i, v := range values {
+		backtrack := len(values) - 1 - i
+		body[backtrack] = map[string]interface{}{
+			"value":     v,
+			"backtrack": backtrack,
+			"timestamp": hour(i).Unix(),
+		}
+	}
+	return taapitest.JSON(body)
+}
+
+func TestFetcherCrossovers(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.HandleFunc(taapi.IndicatorEMA, func(r taapitest.Request) taapitest.Response {
+		if r.Param("period") == "20" {
+			return taapitest.Series(11, 10, 9) // newest first
+		}
+		return taapitest.Series(10, 10, 10)
+	})
+
+	f := NewFetcher(server.Client(), taapi.ExchangeBinance, "BTC/USDT", taapi.Interval1h)
+	crosses, err := f.Crossovers(context.Background(), taapi.EMA().Period(20), taapi.EMA().Period(50), 3)
+	require.NoError(t, err)
+	assert.Equal(t, []Crossover{{Direction: CrossAbove, Index: 2, A: 11, B: 10}}, crosses)
+
+	server.AssertCalled(t, taapi.IndicatorEMA, 2)
+	server.AssertParam(t, taapi.IndicatorEMA, "backtracks", "3")
+	server.AssertParam(t, taapi.IndicatorEMA, "addResultTimestamp", "true")
+}
+
+func TestFetcherWithMock(t *testing.T) {
+	mock := taapimock.New()
+	mock.OnSeries(taapi.IndicatorEMA).WithParam("period", 20).WithParam("backtracks", 3).ReturnSeriesValues(9, 11, 12)
+	mock.OnSeries(taapi.IndicatorEMA).WithParam("period", 50).ReturnSeriesValues(10, 10, 10)
+	mock.OnCandles().WithSymbol("BTC/USDT").ReturnCandles(taapi.NewCandle(hour(1), 1, 1, 1, 1, 1), taapi.NewCandle(hour(0), 1, 1, 1, 1, 1))
+
+	f := NewFetcher(mock, taapi.ExchangeBinance, "BTC/USDT", taapi.Interval1h)
+	crosses, err := f.Crossovers(context.Background(), taapi.EMA().Period(20), taapi.EMA().Period(50), 3)
+	require.NoError(t, err)
+	assert.Equal(t, []Crossover{{Direction: CrossBelow, Index: 2, A: 9, B: 10}}, crosses)
+
+	candles, err := f.Candles(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, hour(0), candles[0].Time())
+
+	mock.AssertExpectations(t)
+	assert.Equal(t, true, mock.Calls()[0].Direct.Params["addResultTimestamp"])
+	assert.Equal(t, 2, mock.Calls()[2].Direct.Params["backtracks"])
+}
+
+func TestFetcherThresholdCrossings(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, newestFirst(35, 25, 32))
+
+	f := NewFetcher(server.Client(), taapi.ExchangeBinance, "BTC/USDT", taapi.Interval1h)
+	crosses, err := f.ThresholdCrossings(context.Background(), taapi.RSI().Period(14), 30, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []Crossover{
+		{Direction: CrossBelow, Index: 1, Time: hour(1), A: 25, B: 30},
+		{Direction: CrossAbove, Index: 2, Time: hour(2), A: 32, B: 30},
+	}, crosses)
+}
+
+func TestFetcherDivergences(t *testing.T) {
+	lows := []float64{10, 9, 8, 9, 10, 9, 7, 9, 10}
+	var candles []*taapi.Candle
+	for i := len(lows) - 1; i >= 0; i-- {
+		candles = append(candles, taapi.NewCandle(hour(i), lows[i]+1, lows[i]+1, lows[i], lows[i]+0.5, 100))
+	}
+
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorCANDLE, taapitest.Candles(candles...))
+	server.Handle(taapi.IndicatorRSI, newestFirst(40, 30, 20, 35, 50, 40, 30, 45, 55))
+
+	f := NewFetcher(server.Client(), taapi.ExchangeBinance, "BTC/USDT", taapi.Interval1h)
+	got, err := f.Candles(context.Background(), len(lows))
+	require.NoError(t, err)
+	assert.Equal(t, hour(0), got[0].Time(), "candles are sorted oldest first")
+
+	divergences, err := f.Divergences(context.Background(), taapi.RSI().Period(14), len(lows), pivotOptions)
+	require.NoError(t, err)
+	require.Len(t, divergences, 1)
+	assert.Equal(t, RegularBullish, divergences[0].Kind)
+	assert.Equal(t, Pivot{Index: 6, Time: hour(6), Price: 7, Oscillator: 30}, divergences[0].To)
+}
+
+func TestFetcherErrors(t *testing.T) {
+	server := taapitest.NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, taapitest.InvalidSymbol("BTC/USDT"))
+
+	f := NewFetcher(server.Client(), taapi.ExchangeBinance, "BTC/USDT", taapi.Interval1h)
+	_, err := f.Series(context.Background(), taapi.RSI(), "value", 0)
+	assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+	_, err = f.Candles(context.Background(), 0)
+	assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+	_, err = f.ThresholdCrossings(context.Background(), taapi.RSI(), 30, 10)
+	assert.ErrorIs(t, err, taapi.ErrInvalidSymbol)
+}
@@ -0,0 +1,163 @@
+// Package signals detects crossovers, threshold crossings and divergences
+// in indicator and price series. Series are either built locally, e.g. from
+// candles, or fetched from taapi.io with a Fetcher:
+//
+//	f := signals.NewFetcher(client, taapi.ExchangeBinance, "BTC/USDT", taapi.Interval4h)
+//	crosses, err := f.Crossovers(ctx, taapi.EMA().Period(20), taapi.EMA().Period(50), 100)
+//	divergences, err := f.Divergences(ctx, taapi.RSI().Period(14), 200, nil)
+package signals
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+// Point is the value of a series at a candle
+type Point struct {
+	// Time is the candle open time; zero when unknown
+	Time  time.Time
+	Value float64
+}
+
+// Series is a value series ordered oldest first
+type Series []Point
+
+// FromValues creates a series without candle times
+func FromValues(values ...float64) Series {
+	s := make(Series, len(values))
+	for i, v := range values {
+		s[i] = Point{Value: v}
+	}
+	return s
+}
+
+// FromCandles creates a series of a candle field, ordered by candle time
+func FromCandles(candles []*taapi.Candle, field func(*taapi.Candle) float64) Series {
+	s := make(Series, len(candles))
+	for i, c := range candles {
+		s[i] = Point{Time: c.Time(), Value: field(c)}
+	}
+	sort.SliceStable(s, func(i, j int) bool { return s[i].Time.Before(s[j].Time) })
+	return s
+}
+
+// Closes returns the close prices of the candles
+func Closes(candles []*taapi.Candle) Series {
+	return FromCandles(candles, func(c *taapi.Candle) float64 { return c.Close })
+}
+
+// Highs returns the high prices of the candles
+func Highs(candles []*taapi.Candle) Series {
+	return FromCandles(candles, func(c *taapi.Candle) float64 { return c.High })
+}
+
+// Lows returns the low prices of the candles
+func Lows(candles []*taapi.Candle) Series {
+	return FromCandles(candles, func(c *taapi.Candle) float64 { return c.Low })
+}
+
+// FromResponses creates a series from an output of backtracks responses,
+// such as returned by DirectBuilder.GetSeries. Items are ordered by their
+// backtrack field, or else assumed newest first as taapi.io returns them.
+// Timestamps are read when the request set addResultTimestamp.
+func FromResponses(responses []*taapi.IndicatorResponse, output string) (Series, error) {
+	type item struct {
+		backtrack float64
+		point     Point
+	}
+	items := make([]item, len(responses))
+	for i, resp := range responses {
+		v, ok := resp.GetFloat(output)
+		if !ok {
+			return nil, taapi.DecodeError(fmt.Sprintf("series item %d has no numeric %s", i, output), nil)
+		}
+		items[i].point.Value = v
+		items[i].backtrack = float64(i)
+		if b, ok := resp.GetFloat("backtrack"); ok {
+			items[i].backtrack = b
+		}
+		if ts, ok := resp.GetFloat("timestamp"); ok {
+			items[i].point.Time = time.Unix(taapi.TimeUnitAuto.ToSeconds(int64(ts)), 0).UTC()
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].backtrack > items[j].backtrack })
+
+	s := make(Series, len(items))
+	for i, it := range items {
+		s[i] = it.point
+	}
+	return s, nil
+}
+
+// Values returns the values of the series
+func (s Series) Values() []float64 {
+	values := make([]float64, len(s))
+	for i, p := range s {
+		values[i] = p.Value
+	}
+	return values
+}
+
+// timed reports whether every point has a time
+func (s Series) timed() bool {
+	for _, p := range s {
+		if p.Time.IsZero() {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+// Align returns the common part of the series. Series with times are
+// matched by time; otherwise they are aligned on their latest point and the
+// longer ones are trimmed.
+func Align(series ...Series) []Series {
+	aligned := make([]Series, len(series))
+	if len(series) == 0 {
+		return aligned
+	}
+
+	timed := true
+	for _, s := range series {
+		timed = timed && s.timed()
+	}
+
+	if !timed {
+		n := len(series[0])
+		for _, s := range series[1:] {
+			if len(s) < n {
+				n = len(s)
+			}
+		}
+		for i, s := range series {
+			aligned[i] = s[len(s)-n:]
+		}
+		return aligned
+	}
+
+	counts := make(map[int64]int)
+	for _, s := range series {
+		seen := make(map[int64]bool)
+		for _, p := range s {
+			if ts := p.Time.Unix(); !seen[ts] {
+				seen[ts] = true
+				counts[ts]++
+			}
+		}
+	}
+	for i, s := range series {
+		seen := make(map[int64]bool)
+		aligned[i] = Series{}
+		for _, p := range s {
+			ts := p.Time.Unix()
+			if counts[ts] == len(series) && !seen[ts] {
+				seen[ts] = true
+				aligned[i] = append(aligned[i], p)
+			}
+		}
+	}
+	return aligned
+}
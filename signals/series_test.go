@@ -0,0 +1,85 @@
+package signals
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tigusigalpa/taapi-go"
+)
+
+var start = time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)
+
+// hour returns the open time of the nth hourly candle
+func hour(n int) time.Time {
+	return start.Add(time.Duration(n) * time.Hour)
+}
+
+// timed creates an hourly series starting at candle first
+func timed(first int, values ...float64) Series {
+	s := make(Series, len(values))
+	for i, v := range values {
+		s[i] = Point{Time: hour(first + i), Value: v}
+	}
+	return s
+}
+
+func TestFromCandles(t *testing.T) {
+	candles := []*taapi.Candle{
+		taapi.NewCandle(hour(1), 2, 4, 1, 3, 10),
+		taapi.NewCandle(hour(0), 1, 3, 0.5, 2, 20),
+	}
+
+	assert.Equal(t, timed(0, 2, 3), Closes(candles))
+	assert.Equal(t, timed(0, 3, 4), Highs(candles))
+	assert.Equal(t, timed(0, 0.5, 1), Lows(candles))
+	assert.Equal(t, timed(0, 20, 10), FromCandles(candles, func(c *taapi.Candle) float64 { return c.Volume }))
+}
+
+func TestFromResponses(t *testing.T) {
+	item := func(data map[string]interface{}) *taapi.IndicatorResponse {
+		return &taapi.IndicatorResponse{Data: data}
+	}
+
+	// Newest first, with backtrack and timestamp fields
+	s, err := FromResponses([]*taapi.IndicatorResponse{
+		item(map[string]interface{}{"value": 3.0, "backtrack": 0.0, "timestamp": float64(hour(2).Unix())}),
+		item(map[string]interface{}{"value": 2.0, "backtrack": 1.0, "timestamp": float64(hour(1).Unix())}),
+		item(map[string]interface{}{"value": 1.0, "backtrack": 2.0, "timestamp": float64(hour(0).UnixMilli())}),
+	}, "value")
+	require.NoError(t, err)
+	assert.Equal(t, timed(0, 1, 2, 3), s)
+
+	// Without backtrack fields the order is reversed
+	s, err = FromResponses([]*taapi.IndicatorResponse{
+		item(map[string]interface{}{"valueMACD": 2.0}),
+		item(map[string]interface{}{"valueMACD": 1.0}),
+	}, "valueMACD")
+	require.NoError(t, err)
+	assert.Equal(t, FromValues(1, 2), s)
+	assert.Equal(t, []float64{1, 2}, s.Values())
+
+	_, err = FromResponses([]*taapi.IndicatorResponse{item(map[string]interface{}{"value": 1.0})}, "valueMACD")
+	assert.ErrorIs(t, err, taapi.ErrDecode)
+}
+
+func TestAlign(t *testing.T) {
+	// Untimed series are aligned on their latest point
+	aligned := Align(FromValues(1, 2, 3, 4), FromValues(5, 6))
+	assert.Equal(t, []Series{FromValues(3, 4), FromValues(5, 6)}, aligned)
+
+	// Timed series are matched by time, skipping gaps
+	a := append(timed(0, 1, 2), timed(3, 4, 5)...)
+	b := timed(1, 20, 30, 40, 50)
+	aligned = Align(a, b)
+	assert.Equal(t, append(timed(1, 2), timed(3, 4, 5)...), aligned[0])
+	assert.Equal(t, append(timed(1, 20), timed(3, 40, 50)...), aligned[1])
+
+	// A series without times falls back to positional alignment
+	aligned = Align(timed(0, 1, 2, 3), FromValues(7, 8))
+	assert.Equal(t, timed(1, 2, 3), aligned[0])
+
+	assert.Empty(t, Align())
+}
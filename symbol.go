@@ -0,0 +1,295 @@
+package taapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ContractType is the kind of market a symbol trades on
+type ContractType string
+
+const (
+	ContractSpot      ContractType = "spot"
+	ContractPerpetual ContractType = "perpetual"
+	ContractFuture    ContractType = "future"
+)
+
+// Symbol is a parsed crypto trading pair
+type Symbol struct {
+	Base  string
+	Quote string
+	// Settle is the settlement currency of derivatives; it defaults to Quote
+	Settle   string
+	Contract ContractType
+	// Expiry is the YYMMDD delivery date of futures
+	Expiry string
+}
+
+// NewSymbol creates a spot symbol
+func NewSymbol(base, quote string) Symbol {
+	return Symbol{
+		Base:     strings.ToUpper(base),
+		Quote:    strings.ToUpper(quote),
+		Contract: ContractSpot,
+	}
+}
+
+// knownQuotes are matched as suffixes when splitting symbols written without
+// a separator, such as BTCUSDT. Longer codes come first.
+var knownQuotes = []string{
+	"FDUSD", "USDT", "USDC", "BUSD", "TUSD", "USDP",
+	"DAI", "USD", "EUR", "GBP", "TRY", "BRL", "JPY", "AUD",
+	"BTC", "ETH", "BNB",
+}
+
+// assetAliases maps exchange-specific asset codes to their common codes
+var assetAliases = map[string]string{
+	"XBT": "BTC",
+	"XDG": "DOGE",
+}
+
+var (
+	assetCodePattern = regexp.MustCompile(`^[A-Z0-9]+$`)
+	expiryPattern    = regexp.MustCompile(`^[0-9]{6}$`)
+)
+
+// ParseSymbol parses the common symbol formats: BTC/USDT, BTC-USDT, BTC_USDT,
+// BTCUSDT, XBT/USD, BTC/USDT:USDT, BTC-USD-SWAP, BTC-PERP, BTCUSDT.P and
+// futures such as BTC-USD-241227 or BTC/USD:BTC-241227
+func ParseSymbol(s string) (Symbol, error) {
+	return parseSymbol(s, true)
+}
+
+// parseSymbol parses a symbol, mapping exchange-specific asset codes such as
+// XBT to their common codes when normalize is set
+func parseSymbol(s string, normalize bool) (Symbol, error) {
+	raw := s
+	s = strings.ToUpper(strings.TrimSpace(s))
+	invalid := func() (Symbol, error) {
+		return Symbol{}, &Error{
+			Message: fmt.Sprintf("cannot parse symbol %q", raw),
+			Kind:    ErrInvalidSymbol,
+		}
+	}
+	if s == "" {
+		return invalid()
+	}
+
+	sym := Symbol{Contract: ContractSpot}
+
+	// Unified derivative notation: BASE/QUOTE:SETTLE[-EXPIRY]
+	if i := strings.Index(s, ":"); i >= 0 {
+		settle := s[i+1:]
+		s = s[:i]
+		sym.Contract = ContractPerpetual
+		if j := strings.Index(settle, "-"); j >= 0 {
+			sym.Expiry = settle[j+1:]
+			settle = settle[:j]
+			sym.Contract = ContractFuture
+		}
+		sym.Settle = settle
+	}
+
+	switch {
+	case strings.Contains(s, "/"):
+		parts := strings.Split(s, "/")
+		if len(parts) != 2 {
+			return invalid()
+		}
+		sym.Base, sym.Quote = parts[0], parts[1]
+	case strings.ContainsAny(s, "-_"):
+		parts := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '_' })
+		switch {
+		case len(parts) == 2 && parts[1] == "PERP":
+			sym.Base, sym.Quote = parts[0], "USD"
+			sym.Contract = ContractPerpetual
+		case len(parts) == 2:
+			sym.Base, sym.Quote = parts[0], parts[1]
+		case len(parts) == 3 && parts[2] == "SWAP":
+			sym.Base, sym.Quote = parts[0], parts[1]
+			sym.Contract = ContractPerpetual
+		case len(parts) == 3 && expiryPattern.MatchString(parts[2]):
+			sym.Base, sym.Quote = parts[0], parts[1]
+			sym.Contract = ContractFuture
+			sym.Expiry = parts[2]
+		default:
+			return invalid()
+		}
+	default:
+		if strings.HasSuffix(s, ".P") {
+			s = strings.TrimSuffix(s, ".P")
+			sym.Contract = ContractPerpetual
+		}
+		sym.Base, sym.Quote = splitConcatenated(s)
+	}
+
+	if normalize {
+		sym.Base, sym.Quote = normalizeAsset(sym.Base), normalizeAsset(sym.Quote)
+	}
+	if !assetCodePattern.MatchString(sym.Base) || !assetCodePattern.MatchString(sym.Quote) {
+		return invalid()
+	}
+	if sym.Contract == ContractFuture && !expiryPattern.MatchString(sym.Expiry) {
+		return invalid()
+	}
+	if sym.Settle == "" && sym.Contract != ContractSpot {
+		sym.Settle = sym.Quote
+	}
+	if normalize {
+		sym.Settle = normalizeAsset(sym.Settle)
+	}
+
+	return sym, nil
+}
+
+// splitConcatenated splits a symbol written without a separator using the
+// known quote currencies. Splits leaving a base of at least three characters
+// are preferred, so XBTUSD is XBT/USD rather than XB/TUSD.
+func splitConcatenated(s string) (string, string) {
+	var base, quote string
+	for _, q := range knownQuotes {
+		if len(s) <= len(q) || !strings.HasSuffix(s, q) {
+			continue
+		}
+		b := s[:len(s)-len(q)]
+		if len(b) >= 3 {
+			return b, q
+		}
+		if base == "" {
+			base, quote = b, q
+		}
+	}
+	return base, quote
+}
+
+// normalizeAsset maps exchange-specific asset codes to their common codes
+func normalizeAsset(code string) string {
+	if alias, ok := assetAliases[code]; ok {
+		return alias
+	}
+	return code
+}
+
+// String returns the unified notation: BASE/QUOTE for spot,
+// BASE/QUOTE:SETTLE for perpetuals and BASE/QUOTE:SETTLE-YYMMDD for futures
+func (s Symbol) String() string {
+	pair := s.Base + "/" + s.Quote
+	switch s.Contract {
+	case ContractPerpetual:
+		return pair + ":" + s.settle()
+	case ContractFuture:
+		return pair + ":" + s.settle() + "-" + s.Expiry
+	default:
+		return pair
+	}
+}
+
+// settle returns the settlement currency, defaulting to the quote
+func (s Symbol) settle() string {
+	if s.Settle != "" {
+		return s.Settle
+	}
+	return s.Quote
+}
+
+// SymbolFormat describes how an exchange's symbols are written in taapi.io
+// requests
+type SymbolFormat struct {
+	// Separator is placed between base and quote
+	Separator string
+	// Aliases maps common asset codes to the exchange's codes, e.g. BTC to XBT
+	Aliases map[string]string
+	// Contracts lists the derivative contracts the exchange trades. Spot
+	// symbols are always accepted; other derivatives are rejected rather than
+	// sent as the spot pair.
+	Contracts []ContractType
+	// Settlement appends the unified :SETTLE[-EXPIRY] suffix to derivatives.
+	// Without it the exchange itself selects the derivatives market, so only
+	// contracts settled in the quote currency can be written.
+	Settlement bool
+}
+
+// defaultSymbolFormat is the BASE/QUOTE notation taapi.io uses for spot
+// markets
+var defaultSymbolFormat = SymbolFormat{Separator: "/"}
+
+var (
+	symbolFormatsMu sync.RWMutex
+	symbolFormats   = map[Exchange]SymbolFormat{
+		// USD-M futures are their own exchange on taapi.io, so perpetuals are
+		// written as the plain pair
+		ExchangeBinanceUSDM: {Separator: "/", Contracts: []ContractType{ContractPerpetual}},
+		// Bybit lists spot and derivatives under one exchange, so derivatives
+		// keep the unified settlement suffix
+		ExchangeBybit: {Separator: "/", Contracts: []ContractType{ContractPerpetual, ContractFuture}, Settlement: true},
+	}
+)
+
+// RegisterSymbolFormat sets the symbol format of an exchange
+func RegisterSymbolFormat(exchange Exchange, format SymbolFormat) {
+	symbolFormatsMu.Lock()
+	defer symbolFormatsMu.Unlock()
+	symbolFormats[exchange] = format
+}
+
+// SymbolFormatOf returns the symbol format of an exchange
+func SymbolFormatOf(exchange Exchange) SymbolFormat {
+	symbolFormatsMu.RLock()
+	defer symbolFormatsMu.RUnlock()
+	if format, ok := symbolFormats[exchange]; ok {
+		return format
+	}
+	return defaultSymbolFormat
+}
+
+// Format returns the symbol as taapi.io expects it for the exchange. A
+// derivative the exchange's format cannot express is an ErrInvalidSymbol
+// error.
+func (s Symbol) Format(exchange Exchange) (string, error) {
+	format := SymbolFormatOf(exchange)
+	alias := func(code string) string {
+		if a, ok := format.Aliases[code]; ok {
+			return a
+		}
+		return code
+	}
+
+	symbol := alias(s.Base) + format.Separator + alias(s.Quote)
+	if s.Contract == ContractSpot || s.Contract == "" {
+		return symbol, nil
+	}
+
+	unsupported := func(reason string) (string, error) {
+		return "", &Error{
+			Message: fmt.Sprintf("symbol %s: %s", s, reason),
+			Kind:    ErrInvalidSymbol,
+		}
+	}
+	if !format.supports(s.Contract) {
+		return unsupported(fmt.Sprintf("%s contracts are not supported on %s", s.Contract, exchange))
+	}
+	if !format.Settlement {
+		if s.Contract == ContractFuture || s.settle() != s.Quote {
+			return unsupported(fmt.Sprintf("the contract cannot be written for %s", exchange))
+		}
+		return symbol, nil
+	}
+
+	symbol += ":" + alias(s.settle())
+	if s.Contract == ContractFuture {
+		symbol += "-" + s.Expiry
+	}
+	return symbol, nil
+}
+
+// supports reports whether the format lists the contract type
+func (f SymbolFormat) supports(contract ContractType) bool {
+	for _, c := range f.Contracts {
+		if c == contract {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package taapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSymbol(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Symbol
+	}{
+		{"BTC/USDT", Symbol{Base: "BTC", Quote: "USDT", Contract: ContractSpot}},
+		{"btc-usdt", Symbol{Base: "BTC", Quote: "USDT", Contract: ContractSpot}},
+		{"ETH_BTC", Symbol{Base: "ETH", Quote: "BTC", Contract: ContractSpot}},
+		{"BTCUSDT", Symbol{Base: "BTC", Quote: "USDT", Contract: ContractSpot}},
+		{"ETHBTC", Symbol{Base: "ETH", Quote: "BTC", Contract: ContractSpot}},
+		{"1000SHIBFDUSD", Symbol{Base: "1000SHIB", Quote: "FDUSD", Contract: ContractSpot}},
+		{"XBT/USD", Symbol{Base: "BTC", Quote: "USD", Contract: ContractSpot}},
+		{"XBTUSD", Symbol{Base: "BTC", Quote: "USD", Contract: ContractSpot}},
+		{"BTC/USDT:USDT", Symbol{Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: ContractPerpetual}},
+		{"BTC-USD-SWAP", Symbol{Base: "BTC", Quote: "USD", Settle: "USD", Contract: ContractPerpetual}},
+		{"BTC-PERP", Symbol{Base: "BTC", Quote: "USD", Settle: "USD", Contract: ContractPerpetual}},
+		{"BTCUSDT.P", Symbol{Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: ContractPerpetual}},
+		{"BTC-USD-241227", Symbol{Base: "BTC", Quote: "USD", Settle: "USD", Contract: ContractFuture, Expiry: "241227"}},
+		{"BTC/USD:BTC-241227", Symbol{Base: "BTC", Quote: "USD", Settle: "BTC", Contract: ContractFuture, Expiry: "241227"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSymbol(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseSymbolInvalid(t *testing.T) {
+	for _, input := range []string{"", "BTC", "FOOBAR", "BTC/USD/EUR", "BTC-USD-XYZ", "BTC/US D", "BTC/USD:USD-2412"} {
+		_, err := ParseSymbol(input)
+		assert.True(t, errors.Is(err, ErrInvalidSymbol), input)
+	}
+}
+
+func TestSymbolString(t *testing.T) {
+	assert.Equal(t, "BTC/USDT", NewSymbol("btc", "usdt").String())
+
+	perp, _ := ParseSymbol("BTC-USDT-SWAP")
+	assert.Equal(t, "BTC/USDT:USDT", perp.String())
+
+	future, _ := ParseSymbol("BTC-USD-241227")
+	assert.Equal(t, "BTC/USD:USD-241227", future.String())
+}
+
+func TestSymbolFormat(t *testing.T) {
+	perp, _ := ParseSymbol("BTCUSDT.P")
+	swap, _ := ParseSymbol("BTC-USDT-SWAP")
+	future, _ := ParseSymbol("BTC-USD-241227")
+	inverse, _ := ParseSymbol("BTC/USD:BTC")
+
+	tests := []struct {
+		symbol   Symbol
+		exchange Exchange
+		want     string
+	}{
+		{NewSymbol("BTC", "USDT"), ExchangeBinance, "BTC/USDT"},
+		{NewSymbol("BTC", "USDT"), ExchangeBinanceUSDM, "BTC/USDT"},
+		{perp, ExchangeBinanceUSDM, "BTC/USDT"},
+		{swap, ExchangeBybit, "BTC/USDT:USDT"},
+		{future, ExchangeBybit, "BTC/USD:USD-241227"},
+		{inverse, ExchangeBybit, "BTC/USD:BTC"},
+	}
+	for _, tt := range tests {
+		got, err := tt.symbol.Format(tt.exchange)
+		require.NoError(t, err, "%s on %s", tt.symbol, tt.exchange)
+		assert.Equal(t, tt.want, got, "%s on %s", tt.symbol, tt.exchange)
+	}
+
+	// Derivatives are never downgraded to the spot pair
+	unsupported := []struct {
+		symbol   Symbol
+		exchange Exchange
+	}{
+		{perp, ExchangeBinance},
+		{swap, ExchangeOKX},
+		{future, ExchangeBinanceUSDM},
+		{inverse, ExchangeBinanceUSDM},
+	}
+	for _, tt := range unsupported {
+		_, err := tt.symbol.Format(tt.exchange)
+		assert.ErrorIs(t, err, ErrInvalidSymbol, "%s on %s", tt.symbol, tt.exchange)
+	}
+
+	RegisterSymbolFormat("testexchange", SymbolFormat{
+		Separator:  "-",
+		Aliases:    map[string]string{"BTC": "XBT"},
+		Contracts:  []ContractType{ContractPerpetual},
+		Settlement: true,
+	})
+	defer func() {
+		symbolFormatsMu.Lock()
+		delete(symbolFormats, "testexchange")
+		symbolFormatsMu.Unlock()
+	}()
+
+	got, err := perp.Format("testexchange")
+	require.NoError(t, err)
+	assert.Equal(t, "XBT-USDT:USDT", got)
+	got, err = NewSymbol("ETH", "USD").Format("testexchange")
+	require.NoError(t, err)
+	assert.Equal(t, "ETH-USD", got)
+	_, err = future.Format("testexchange")
+	assert.ErrorIs(t, err, ErrInvalidSymbol)
+}
+
+func TestBuildersNormalizeSymbol(t *testing.T) {
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"value": 50}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_secret").SetBaseURL(server.URL)
+	_, err := client.Direct().
+		Exchange(ExchangeBinance).
+		Symbol("btcusdt").
+		Interval(Interval1h).
+		Indicator(IndicatorRSI).
+		Get()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTC/USDT"}, query["symbol"])
+
+	// A quote missing from the known quotes is sent as written
+	_, err = client.Direct().
+		Exchange(ExchangeBinance).
+		Symbol("BTCPLN").
+		Interval(Interval1h).
+		Indicator(IndicatorRSI).
+		Get()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTCPLN"}, query["symbol"])
+
+	// Exchange-specific asset codes are kept
+	construct, err := client.Construct(ExchangeKraken, "XBT-USD", Interval1h).
+		AddIndicator(IndicatorRSI, nil).
+		ToMap()
+	require.NoError(t, err)
+	assert.Equal(t, "XBT/USD", construct["symbol"])
+
+	_, err = client.Direct().
+		Exchange(ExchangeBinance).
+		Symbol("BTC-USDT-SWAP").
+		Interval(Interval1h).
+		Indicator(IndicatorRSI).
+		Get()
+	assert.ErrorIs(t, err, ErrInvalidSymbol)
+
+	_, err = client.Direct().
+		Exchange(ExchangeBinanceUSDM).
+		Symbol("BTC-USDT-SWAP").
+		Interval(Interval1h).
+		Indicator(IndicatorRSI).
+		Get()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTC/USDT"}, query["symbol"])
+}
@@ -0,0 +1,396 @@
+// Package taapimock provides an in-memory implementation of the taapi request
+// interfaces for unit tests of code that depends on taapi.IndicatorFetcher,
+// taapi.SeriesFetcher, taapi.CandleFetcher, taapi.BulkExecutor or
+// taapi.ManualExecutor.
+//
+//	mock := taapimock.New()
+//	mock.OnFetch(taapi.IndicatorRSI).WithSymbol("BTC/USDT").ReturnValue(28).Once()
+//
+//	runCodeUnderTest(mock)
+//
+//	mock.AssertExpectations(t)
+//
+// The methods implementing taapi.API are generated from its declaration.
+package taapimock
+
+//go:generate go run ../internal/genmock -api ../api.go -out mock_gen.go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	taapi "github.com/tigusigalpa/taapi-go"
+)
+
+// ErrUnexpectedCall is returned for calls that match no expectation
+var ErrUnexpectedCall = errors.New("taapimock: unexpected call")
+
+// indicators returns the indicators requested by the call
+func (c Call) indicators() []taapi.Indicator {
+	switch c.Method {
+	case MethodFetchIndicator, MethodFetchSeries:
+		return []taapi.Indicator{c.Direct.Indicator}
+	case MethodFetchCandles:
+		return []taapi.Indicator{taapi.IndicatorCANDLE}
+	case MethodExecuteManual:
+		return []taapi.Indicator{c.Manual.Indicator}
+	}
+	var indicators []taapi.Indicator
+	for _, construct := range c.Constructs {
+		for _, p := range construct.Indicators {
+			indicators = append(indicators, p.Indicator())
+		}
+	}
+	return indicators
+}
+
+// params returns the parameters of a direct or manual call
+func (c Call) params() map[string]interface{} {
+	if c.Method == MethodExecuteManual {
+		return c.Manual.Params
+	}
+	return c.Direct.Params
+}
+
+// String describes the call for failure messages
+func (c Call) String() string {
+	switch c.Method {
+	case MethodFetchIndicator, MethodFetchSeries, MethodFetchCandles:
+		return fmt.Sprintf("%s(%s %s %s %s %v)", c.Method, c.indicators()[0], c.Direct.Exchange,
+			c.Direct.Symbol, c.Direct.Interval, c.Direct.Params)
+	case MethodExecuteManual:
+		return fmt.Sprintf("%s(%s, %d candles, %v)", c.Method, c.Manual.Indicator, len(c.Manual.Candles), c.Manual.Params)
+	}
+	var names []string
+	for _, indicator := range c.indicators() {
+		names = append(names, indicator.String())
+	}
+	return fmt.Sprintf("%s(%d constructs: %s)", c.Method, len(c.Constructs), strings.Join(names, ","))
+}
+
+// Client is a mock implementing taapi.API. Calls are matched against the
+// expectations in the order they were added; an expectation that has been
+// used up is skipped.
+type Client struct {
+	mu           sync.Mutex
+	expectations []*Expectation
+	calls        []Call
+}
+
+// New creates a mock without expectations
+func New() *Client {
+	return &Client{}
+}
+
+// OnFetch expects FetchIndicator calls for the indicator
+func (m *Client) OnFetch(indicator taapi.Indicator) *Expectation {
+	return m.expect(MethodFetchIndicator, indicator)
+}
+
+// OnSeries expects FetchSeries calls for the indicator
+func (m *Client) OnSeries(indicator taapi.Indicator) *Expectation {
+	return m.expect(MethodFetchSeries, indicator)
+}
+
+// OnCandles expects FetchCandles calls
+func (m *Client) OnCandles() *Expectation {
+	return m.expect(MethodFetchCandles, "")
+}
+
+// OnBulk expects ExecuteBulk calls
+func (m *Client) OnBulk() *Expectation {
+	return m.expect(MethodExecuteBulk, "")
+}
+
+// OnManual expects ExecuteManual calls for the indicator
+func (m *Client) OnManual(indicator taapi.Indicator) *Expectation {
+	return m.expect(MethodExecuteManual, indicator)
+}
+
+// expect adds an expectation
+func (m *Client) expect(method Method, indicator taapi.Indicator) *Expectation {
+	e := &Expectation{method: method, indicator: indicator}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// call records a call and returns the matching expectation
+func (m *Client) call(ctx context.Context, call Call) (*Expectation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, call)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, e := range m.expectations {
+		if e.exhausted() || !e.matches(call) {
+			continue
+		}
+		e.calls++
+		if e.err != nil {
+			return nil, e.err
+		}
+		return e, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnexpectedCall, call)
+}
+
+// Calls returns the recorded calls
+func (m *Client) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsFor returns the recorded calls requesting the indicator, including
+// bulk calls containing it
+func (m *Client) CallsFor(indicator taapi.Indicator) []Call {
+	var calls []Call
+	for _, call := range m.Calls() {
+		for _, i := range call.indicators() {
+			if i == indicator {
+				calls = append(calls, call)
+				break
+			}
+		}
+	}
+	return calls
+}
+
+// Reset removes all expectations and recorded calls
+func (m *Client) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expectations = nil
+	m.calls = nil
+}
+
+// AssertExpectations fails the test if an expectation was not met: an
+// expectation with Times must be called exactly that often, others at
+// least once
+func (m *Client) AssertExpectations(tb testing.TB) bool {
+	tb.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ok := true
+	for _, e := range m.expectations {
+		if e.times > 0 && e.calls != e.times {
+			tb.Errorf("taapimock: %s expected %d calls, got %d", e, e.times, e.calls)
+			ok = false
+		} else if e.times == 0 && e.calls == 0 {
+			tb.Errorf("taapimock: %s was not called", e)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertCalled fails the test unless the indicator was requested the given
+// number of times
+func (m *Client) AssertCalled(tb testing.TB, indicator taapi.Indicator, times int) bool {
+	tb.Helper()
+
+	if got := len(m.CallsFor(indicator)); got != times {
+		tb.Errorf("taapimock: expected %d calls for %s, got %d", times, indicator, got)
+		return false
+	}
+	return true
+}
+
+// AssertNotCalled fails the test if the indicator was requested
+func (m *Client) AssertNotCalled(tb testing.TB, indicator taapi.Indicator) bool {
+	tb.Helper()
+	return m.AssertCalled(tb, indicator, 0)
+}
+
+// Expectation is an expected call and its scripted result
+type Expectation struct {
+	method    Method
+	indicator taapi.Indicator
+	matchers  []func(Call) bool
+
+	data   map[string]interface{}
+	resp   *taapi.IndicatorResponse
+	series []*taapi.IndicatorResponse
+	ohlcv  []*taapi.Candle
+	bulk   *taapi.BulkResponse
+	err    error
+	times  int
+	calls  int
+}
+
+// WithExchange restricts the expectation to direct, series and candle
+// requests on the exchange
+func (e *Expectation) WithExchange(exchange taapi.Exchange) *Expectation {
+	return e.Match(func(c Call) bool { return c.Direct.Exchange == exchange })
+}
+
+// WithSymbol restricts the expectation to direct, series and candle
+// requests for the symbol
+func (e *Expectation) WithSymbol(symbol string) *Expectation {
+	return e.Match(func(c Call) bool { return c.Direct.Symbol == symbol })
+}
+
+// WithInterval restricts the expectation to direct, series and candle
+// requests on the interval
+func (e *Expectation) WithInterval(interval taapi.Interval) *Expectation {
+	return e.Match(func(c Call) bool { return c.Direct.Interval == interval })
+}
+
+// WithParam restricts the expectation to direct or manual requests with the
+// parameter set to the value
+func (e *Expectation) WithParam(key string, value interface{}) *Expectation {
+	return e.Match(func(c Call) bool {
+		v, ok := c.params()[key]
+		return ok && reflect.DeepEqual(v, value)
+	})
+}
+
+// Match restricts the expectation with a custom matcher
+func (e *Expectation) Match(matcher func(Call) bool) *Expectation {
+	e.matchers = append(e.matchers, matcher)
+	return e
+}
+
+// Return sets the response returned by FetchIndicator or ExecuteManual
+func (e *Expectation) Return(resp *taapi.IndicatorResponse) *Expectation {
+	e.resp = resp
+	return e
+}
+
+// ReturnData returns a response with the given data
+func (e *Expectation) ReturnData(data map[string]interface{}) *Expectation {
+	e.data = data
+	return e
+}
+
+// ReturnValue returns a single value response, e.g. {"value": 42}
+func (e *Expectation) ReturnValue(value float64) *Expectation {
+	return e.ReturnData(map[string]interface{}{"value": value})
+}
+
+// ReturnSeries sets the responses returned by FetchSeries
+func (e *Expectation) ReturnSeries(responses ...*taapi.IndicatorResponse) *Expectation {
+	e.series = responses
+	return e
+}
+
+// ReturnSeriesValues returns one {"value": v} response per value, newest
+// candle first as taapi.io orders backtracks responses
+func (e *Expectation) ReturnSeriesValues(values ...float64) *Expectation {
+	responses := make([]*taapi.IndicatorResponse, len(values))
+	for i, v := range values {
+		responses[i] = &taapi.IndicatorResponse{Data: map[string]interface{}{"value": v}}
+	}
+	return e.ReturnSeries(responses...)
+}
+
+// ReturnCandles sets the candles returned by FetchCandles
+func (e *Expectation) ReturnCandles(candles ...*taapi.Candle) *Expectation {
+	e.ohlcv = candles
+	return e
+}
+
+// ReturnBulk sets the response returned by ExecuteBulk
+func (e *Expectation) ReturnBulk(resp *taapi.BulkResponse) *Expectation {
+	e.bulk = resp
+	return e
+}
+
+// ReturnError makes matching calls fail with the error
+func (e *Expectation) ReturnError(err error) *Expectation {
+	e.err = err
+	return e
+}
+
+// Times limits the expectation to n calls
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// Once limits the expectation to a single call
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+// String describes the expectation for failure messages
+func (e *Expectation) String() string {
+	if e.indicator == "" {
+		return string(e.method)
+	}
+	return fmt.Sprintf("%s(%s)", e.method, e.indicator)
+}
+
+// exhausted reports whether the expectation has been used up
+func (e *Expectation) exhausted() bool {
+	return e.times > 0 && e.calls >= e.times
+}
+
+// matches reports whether the call satisfies the expectation
+func (e *Expectation) matches(call Call) bool {
+	if call.Method != e.method {
+		return false
+	}
+	if e.indicator != "" && call.indicators()[0] != e.indicator {
+		return false
+	}
+	for _, matcher := range e.matchers {
+		if !matcher(call) {
+			return false
+		}
+	}
+	return true
+}
+
+// indicatorResponse returns the scripted response of a direct or manual call
+func (e *Expectation) indicatorResponse(call Call) *taapi.IndicatorResponse {
+	if e.resp != nil {
+		return e.resp
+	}
+	data := make(map[string]interface{}, len(e.data))
+	for k, v := range e.data {
+		data[k] = v
+	}
+	return &taapi.IndicatorResponse{Indicator: call.indicators()[0].String(), Data: data}
+}
+
+// indicatorResponses returns the scripted responses of a series call
+func (e *Expectation) indicatorResponses(call Call) []*taapi.IndicatorResponse {
+	responses := make([]*taapi.IndicatorResponse, len(e.series))
+	for i, resp := range e.series {
+		if resp.Indicator == "" {
+			copied := *resp
+			copied.Indicator = call.indicators()[0].String()
+			resp = &copied
+		}
+		responses[i] = resp
+	}
+	return responses
+}
+
+// candles returns the scripted candles of a candles call
+func (e *Expectation) candles(Call) []*taapi.Candle {
+	return append([]*taapi.Candle(nil), e.ohlcv...)
+}
+
+// bulkResponse returns the scripted response of a bulk call
+func (e *Expectation) bulkResponse(Call) *taapi.BulkResponse {
+	if e.bulk == nil {
+		return &taapi.BulkResponse{}
+	}
+	return e.bulk
+}
@@ -0,0 +1,80 @@
+// Code generated by genmock from api.go. DO NOT EDIT.
+
+package taapimock
+
+import (
+	"context"
+
+	taapi "github.com/tigusigalpa/taapi-go"
+)
+
+// Method identifies the interface method of a call
+type Method string
+
+const (
+	MethodFetchIndicator Method = "FetchIndicator"
+	MethodFetchSeries    Method = "FetchSeries"
+	MethodFetchCandles   Method = "FetchCandles"
+	MethodExecuteBulk    Method = "ExecuteBulk"
+	MethodExecuteManual  Method = "ExecuteManual"
+)
+
+// Call is a recorded call. Only the request field of its method is set.
+type Call struct {
+	Method     Method
+	Direct     taapi.DirectRequest
+	Constructs []taapi.BulkConstruct
+	Manual     taapi.ManualRequest
+}
+
+var _ taapi.API = (*Client)(nil)
+
+// FetchIndicator implements taapi.IndicatorFetcher
+func (m *Client) FetchIndicator(ctx context.Context, direct taapi.DirectRequest) (*taapi.IndicatorResponse, error) {
+	call := Call{Method: MethodFetchIndicator, Direct: direct}
+	e, err := m.call(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+	return e.indicatorResponse(call), nil
+}
+
+// FetchSeries implements taapi.SeriesFetcher
+func (m *Client) FetchSeries(ctx context.Context, direct taapi.DirectRequest) ([]*taapi.IndicatorResponse, error) {
+	call := Call{Method: MethodFetchSeries, Direct: direct}
+	e, err := m.call(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+	return e.indicatorResponses(call), nil
+}
+
+// FetchCandles implements taapi.CandleFetcher
+func (m *Client) FetchCandles(ctx context.Context, direct taapi.DirectRequest) ([]*taapi.Candle, error) {
+	call := Call{Method: MethodFetchCandles, Direct: direct}
+	e, err := m.call(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+	return e.candles(call), nil
+}
+
+// ExecuteBulk implements taapi.BulkExecutor
+func (m *Client) ExecuteBulk(ctx context.Context, constructs ...taapi.BulkConstruct) (*taapi.BulkResponse, error) {
+	call := Call{Method: MethodExecuteBulk, Constructs: constructs}
+	e, err := m.call(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+	return e.bulkResponse(call), nil
+}
+
+// ExecuteManual implements taapi.ManualExecutor
+func (m *Client) ExecuteManual(ctx context.Context, manual taapi.ManualRequest) (*taapi.IndicatorResponse, error) {
+	call := Call{Method: MethodExecuteManual, Manual: manual}
+	e, err := m.call(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+	return e.indicatorResponse(call), nil
+}
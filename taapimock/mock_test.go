@@ -0,0 +1,149 @@
+package taapimock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	taapi "github.com/tigusigalpa/taapi-go"
+)
+
+// latestRSI is code under test depending only on the fetcher interface
+func latestRSI(ctx context.Context, fetcher taapi.IndicatorFetcher, symbol string) (float64, error) {
+	resp, err := fetcher.FetchIndicator(ctx, taapi.DirectRequest{
+		Exchange:  taapi.ExchangeBinance,
+		Symbol:    symbol,
+		Interval:  taapi.Interval1h,
+		Indicator: taapi.IndicatorRSI,
+		Params:    map[string]interface{}{"period": 14},
+	})
+	if err != nil {
+		return 0, err
+	}
+	value, _ := resp.GetFloat("value")
+	return value, nil
+}
+
+func TestMockFetch(t *testing.T) {
+	mock := New()
+	mock.OnFetch(taapi.IndicatorRSI).WithSymbol("BTC/USDT").WithParam("period", 14).ReturnValue(28).Once()
+	mock.OnFetch(taapi.IndicatorRSI).WithSymbol("BTC/USDT").ReturnValue(35)
+	mock.OnFetch(taapi.IndicatorRSI).WithSymbol("ETH/USDT").ReturnError(taapi.InvalidArgumentError("bad symbol"))
+
+	ctx := context.Background()
+	for _, want := range []float64{28, 35, 35} {
+		value, err := latestRSI(ctx, mock, "BTC/USDT")
+		require.NoError(t, err)
+		assert.Equal(t, want, value)
+	}
+
+	_, err := latestRSI(ctx, mock, "ETH/USDT")
+	assert.ErrorIs(t, err, taapi.ErrInvalidParams)
+
+	_, err = latestRSI(ctx, mock, "SOL/USDT")
+	assert.ErrorIs(t, err, ErrUnexpectedCall)
+
+	mock.AssertExpectations(t)
+	mock.AssertCalled(t, taapi.IndicatorRSI, 5)
+	mock.AssertNotCalled(t, taapi.IndicatorMACD)
+	assert.Equal(t, "SOL/USDT", mock.Calls()[4].Direct.Symbol)
+}
+
+func TestMockBulkAndManual(t *testing.T) {
+	mock := New()
+	bulk := &taapi.BulkResponse{Responses: []*taapi.IndicatorResponse{{ID: "macd", Indicator: "macd"}}}
+	mock.OnBulk().ReturnBulk(bulk)
+	mock.OnManual(taapi.IndicatorSMA).ReturnData(map[string]interface{}{"value": 7.0})
+
+	ctx := context.Background()
+	resp, err := mock.ExecuteBulk(ctx, taapi.BulkConstruct{
+		Exchange:   taapi.ExchangeBinance,
+		Symbol:     "BTC/USDT",
+		Interval:   taapi.Interval1h,
+		Indicators: []taapi.ParamSet{taapi.MACD().ID("macd")},
+	})
+	require.NoError(t, err)
+	assert.Same(t, bulk, resp)
+
+	manual, err := mock.ExecuteManual(ctx, taapi.ManualRequest{Indicator: taapi.IndicatorSMA})
+	require.NoError(t, err)
+	assert.Equal(t, "sma", manual.Indicator)
+	value, _ := manual.GetFloat("value")
+	assert.Equal(t, 7.0, value)
+
+	mock.AssertExpectations(t)
+	mock.AssertCalled(t, taapi.IndicatorMACD, 1)
+	mock.AssertCalled(t, taapi.IndicatorSMA, 1)
+}
+
+// failureRecorder records failures instead of failing the test
+type failureRecorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *failureRecorder) Helper() {}
+
+func (r *failureRecorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestMockSeriesAndCandles(t *testing.T) {
+	mock := New()
+	mock.OnSeries(taapi.IndicatorEMA).WithParam("backtracks", 3).ReturnSeriesValues(3, 2, 1)
+	candle := taapi.NewCandle(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 1, 2, 0.5, 1.5, 10)
+	mock.OnCandles().WithSymbol("BTC/USDT").ReturnCandles(candle)
+
+	ctx := context.Background()
+	series, err := mock.FetchSeries(ctx, taapi.DirectRequest{
+		Symbol:    "BTC/USDT",
+		Indicator: taapi.IndicatorEMA,
+		Params:    map[string]interface{}{"backtracks": 3},
+	})
+	require.NoError(t, err)
+	require.Len(t, series, 3)
+	assert.Equal(t, "ema", series[0].Indicator)
+	value, _ := series[2].GetFloat("value")
+	assert.Equal(t, 1.0, value)
+
+	candles, err := mock.FetchCandles(ctx, taapi.DirectRequest{Symbol: "BTC/USDT"})
+	require.NoError(t, err)
+	assert.Equal(t, []*taapi.Candle{candle}, candles)
+
+	_, err = mock.FetchCandles(ctx, taapi.DirectRequest{Symbol: "ETH/USDT"})
+	assert.ErrorContains(t, err, "FetchCandles(candle  ETH/USDT  map[])")
+
+	mock.AssertExpectations(t)
+	mock.AssertCalled(t, taapi.IndicatorCANDLE, 2)
+}
+
+func TestMockAssertExpectations(t *testing.T) {
+	mock := New()
+	mock.OnFetch(taapi.IndicatorRSI).Times(2)
+	mock.OnManual(taapi.IndicatorSMA)
+
+	_, err := latestRSI(context.Background(), mock, "BTC/USDT")
+	require.NoError(t, err)
+
+	recorder := &failureRecorder{TB: t}
+	assert.False(t, mock.AssertExpectations(recorder))
+	assert.Len(t, recorder.failures, 2)
+
+	mock.Reset()
+	assert.Empty(t, mock.Calls())
+	assert.True(t, mock.AssertExpectations(t))
+}
+
+func TestMockCanceledContext(t *testing.T) {
+	mock := New()
+	mock.OnFetch(taapi.IndicatorRSI).ReturnValue(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := latestRSI(ctx, mock, "BTC/USDT")
+	assert.True(t, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,375 @@
+package taapitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	taapi "github.com/tigusigalpa/taapi-go"
+)
+
+// ErrNoInteraction is returned by a replaying cassette for requests it has
+// no recording of
+var ErrNoInteraction = errors.New("taapitest: no recorded interaction")
+
+// redacted replaces the API secret in recorded response bodies
+const redacted = "REDACTED"
+
+// Mode selects whether a cassette records or replays traffic
+type Mode int
+
+const (
+	// ModeAuto replays an existing cassette file and records otherwise
+	ModeAuto Mode = iota
+	// ModeReplay only replays; unmatched requests fail
+	ModeReplay
+	// ModeRecord sends every request and records it, replacing the file
+	ModeRecord
+)
+
+// RecordedRequest is the normalized form of a request used for matching.
+// The secret is removed from the query and the JSON body.
+type RecordedRequest struct {
+	Method string              `json:"method"`
+	Path   string              `json:"path"`
+	Query  map[string][]string `json:"query,omitempty"`
+	Body   interface{}         `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded HTTP response
+type RecordedResponse struct {
+	Status int                 `json:"status"`
+	Header map[string][]string `json:"header,omitempty"`
+	Body   string              `json:"body"`
+}
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+
+	key  string
+	used bool
+}
+
+// cassetteFile is the JSON layout of a cassette file
+type cassetteFile struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Cassette is an http.RoundTripper that records traffic to a file and
+// replays it. Requests match on method, path and normalized query and body,
+// so parameter order does not matter. Identical requests replay their
+// recordings in order; the last one is repeated.
+type Cassette struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	recording    bool
+	interactions []*Interaction
+}
+
+// NewCassette opens a cassette file. In ModeReplay the file must exist;
+// ModeRecord starts empty and sends requests through http.DefaultTransport.
+func NewCassette(path string, mode Mode) (*Cassette, error) {
+	c := &Cassette{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+	}
+
+	if mode == ModeRecord {
+		c.recording = true
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && mode == ModeAuto {
+		c.recording = true
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("taapitest: read cassette: %w", err)
+	}
+
+	var file cassetteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("taapitest: decode cassette %s: %w", path, err)
+	}
+	for _, interaction := range file.Interactions {
+		interaction.key = interaction.Request.matchKey()
+	}
+	c.interactions = file.Interactions
+	return c, nil
+}
+
+// UseCassette opens a cassette, installs it on the client and saves it when
+// the test ends. It fails the test if the cassette cannot be opened.
+func UseCassette(tb testing.TB, client *taapi.Client, path string, mode Mode) *Cassette {
+	tb.Helper()
+
+	cassette, err := NewCassette(path, mode)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	client.SetTransport(cassette)
+	tb.Cleanup(func() {
+		if err := cassette.Save(); err != nil {
+			tb.Error(err)
+		}
+	})
+	return cassette
+}
+
+// SetTransport sets the transport used while recording
+func (c *Cassette) SetTransport(transport http.RoundTripper) *Cassette {
+	c.transport = transport
+	return c
+}
+
+// Recording reports whether the cassette sends and records requests
+func (c *Cassette) Recording() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.recording
+}
+
+// Interactions returns the recorded interactions
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	interactions := make([]Interaction, len(c.interactions))
+	for i, interaction := range c.interactions {
+		interactions[i] = *interaction
+	}
+	return interactions
+}
+
+// Save writes the recorded interactions to the cassette file. It does
+// nothing when replaying.
+func (c *Cassette) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.recording {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("taapitest: encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("taapitest: write cassette: %w", err)
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("taapitest: write cassette: %w", err)
+	}
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper. The request is not modified: its
+// body is read through GetBody, or sent as a copy when GetBody is not set.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	out, body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded, secret := recordRequest(req, body)
+
+	if c.Recording() {
+		return c.record(out, recorded, secret)
+	}
+	if out.Body != nil {
+		out.Body.Close()
+	}
+	return c.replay(req, recorded)
+}
+
+// readBody reads the body of a request without consuming it. When the body
+// cannot be read again through GetBody it is consumed, and a clone carrying
+// a copy is returned to be sent instead.
+func readBody(req *http.Request) (*http.Request, []byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		return req, data, nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(data))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return out, data, nil
+}
+
+// record sends the request and stores the scrubbed interaction
+func (c *Cassette) record(req *http.Request, recorded RecordedRequest, secret string) (*http.Response, error) {
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	stored := string(body)
+	if secret != "" {
+		stored = strings.ReplaceAll(stored, secret, redacted)
+	}
+
+	interaction := &Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: header,
+			Body:   stored,
+		},
+		key:  recorded.matchKey(),
+		used: true,
+	}
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, interaction)
+	c.mu.Unlock()
+
+	return newResponse(req, resp.StatusCode, resp.Header, body), nil
+}
+
+// replay returns the recorded response matching the request
+func (c *Cassette) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	key := recorded.matchKey()
+
+	c.mu.Lock()
+	var match *Interaction
+	for _, interaction := range c.interactions {
+		if interaction.key != key {
+			continue
+		}
+		match = interaction
+		if !interaction.used {
+			break
+		}
+	}
+	if match != nil {
+		match.used = true
+	}
+	c.mu.Unlock()
+
+	if match == nil {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, recorded.Method, recorded.Path)
+	}
+
+	header := http.Header(match.Response.Header).Clone()
+	return newResponse(req, match.Response.Status, header, []byte(match.Response.Body)), nil
+}
+
+// recordRequest normalizes a request with the given body and extracts its
+// secret
+func recordRequest(req *http.Request, data []byte) (RecordedRequest, string) {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+	}
+
+	query := req.URL.Query()
+	secret := query.Get("secret")
+	query.Del("secret")
+	if len(query) > 0 {
+		recorded.Query = query
+	}
+
+	if data != nil {
+		var body interface{}
+		if err := json.Unmarshal(data, &body); err == nil {
+			if fields, ok := body.(map[string]interface{}); ok {
+				if s, ok := fields["secret"].(string); ok {
+					secret = s
+				}
+				delete(fields, "secret")
+			}
+			recorded.Body = body
+		} else {
+			recorded.Body = string(data)
+		}
+	}
+
+	return recorded, secret
+}
+
+// matchKey returns a canonical representation of the request. encoding/json
+// sorts map keys, making the key independent of parameter order.
+func (r RecordedRequest) matchKey() string {
+	query := url.Values(r.Query)
+	data, _ := json.Marshal([]interface{}{r.Method, r.Path, query, normalizeJSON(r.Body)})
+	return string(data)
+}
+
+// normalizeJSON round-trips a value through JSON so that values decoded from
+// a cassette and from a live request compare equal
+func normalizeJSON(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return v
+	}
+	return normalized
+}
+
+// newResponse builds an HTTP response with the given status, header and body
+func newResponse(req *http.Request, status int, header http.Header, body []byte) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
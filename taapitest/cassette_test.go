@@ -0,0 +1,147 @@
+package taapitest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	taapi "github.com/tigusigalpa/taapi-go"
+)
+
+func TestCassetteRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "rsi.json")
+
+	server := NewServer()
+	server.Handle(taapi.IndicatorRSI, Value(30), Value(70))
+	server.Handle(taapi.IndicatorMACD, Values(map[string]float64{"valueMACD": 1.5}))
+
+	recorder, err := NewCassette(path, ModeAuto)
+	require.NoError(t, err)
+	assert.True(t, recorder.Recording())
+
+	client := server.Client().SetTransport(recorder)
+	for _, want := range []float64{30, 70} {
+		resp, err := rsiRequest(client).WithParam("period", 14).Get()
+		require.NoError(t, err)
+		value, _ := resp.GetFloat("value")
+		assert.Equal(t, want, value)
+	}
+
+	bulk, err := client.Bulk().
+		AddConstruct(client.Construct(taapi.ExchangeBinance, "BTC/USDT", taapi.Interval1h).
+			AddIndicator(taapi.IndicatorMACD, map[string]interface{}{"id": "macd", "optInFastPeriod": 12})).
+		Execute()
+	require.NoError(t, err)
+	require.Equal(t, 1, bulk.Count())
+
+	require.NoError(t, recorder.Save())
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), DefaultSecret)
+	assert.Len(t, recorder.Interactions(), 3)
+
+	// Replay with the server gone and the parameters added in another order
+	replayer, err := NewCassette(path, ModeAuto)
+	require.NoError(t, err)
+	assert.False(t, replayer.Recording())
+
+	client = taapi.NewClient("another-secret").SetBaseURL(server.URL).SetTransport(replayer)
+	for _, want := range []float64{30, 70, 70} {
+		resp, err := client.Direct().
+			WithParam("period", 14).
+			Indicator(taapi.IndicatorRSI).
+			Interval(taapi.Interval1h).
+			Symbol("BTC/USDT").
+			Exchange(taapi.ExchangeBinance).
+			Get()
+		require.NoError(t, err)
+		value, _ := resp.GetFloat("value")
+		assert.Equal(t, want, value)
+	}
+
+	bulk, err = client.Bulk().
+		AddConstruct(client.Construct(taapi.ExchangeBinance, "BTC/USDT", taapi.Interval1h).
+			AddIndicator(taapi.IndicatorMACD, map[string]interface{}{"optInFastPeriod": 12, "id": "macd"})).
+		Execute()
+	require.NoError(t, err)
+	require.Equal(t, 1, bulk.Count())
+	assert.NotNil(t, bulk.FindByID("macd"))
+}
+
+func TestCassetteDoesNotModifyRequest(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		received = append(received, string(data))
+		w.Write([]byte(`{"value": 1}`))
+	}))
+	defer server.Close()
+
+	cassette, err := NewCassette(filepath.Join(t.TempDir(), "post.json"), ModeRecord)
+	require.NoError(t, err)
+
+	// The body is read through GetBody and left for the transport
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/bulk", strings.NewReader(`{"a":1}`))
+	require.NoError(t, err)
+	body := req.Body
+	_, err = cassette.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, body, req.Body)
+
+	// Without GetBody a copy is sent and the request keeps its body
+	req, err = http.NewRequest(http.MethodPost, server.URL+"/bulk", io.NopCloser(strings.NewReader(`{"b":2}`)))
+	require.NoError(t, err)
+	require.Nil(t, req.GetBody)
+	body = req.Body
+	_, err = cassette.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, body, req.Body)
+	assert.Nil(t, req.GetBody)
+
+	assert.Equal(t, []string{`{"a":1}`, `{"b":2}`}, received)
+	require.Len(t, cassette.Interactions(), 2)
+	assert.Equal(t, map[string]interface{}{"b": 2.0}, cassette.Interactions()[1].Request.Body)
+}
+
+func TestCassetteMissingFile(t *testing.T) {
+	_, err := NewCassette(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	assert.Error(t, err)
+}
+
+func TestUseCassetteSaves(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rsi.json")
+
+	server := NewServer()
+	defer server.Close()
+	server.Handle(taapi.IndicatorRSI, Value(30))
+
+	t.Run("record", func(t *testing.T) {
+		client := server.Client()
+		UseCassette(t, client, path, ModeRecord)
+		_, err := rsiRequest(client).Get()
+		require.NoError(t, err)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		client := server.Client()
+		UseCassette(t, client, path, ModeReplay)
+
+		_, err := rsiRequest(client).Get()
+		require.NoError(t, err)
+
+		_, err = rsiRequest(client).WithParam("period", 21).Get()
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrNoInteraction))
+	})
+
+	server.AssertCalled(t, taapi.IndicatorRSI, 1)
+}
@@ -0,0 +1,111 @@
+package taapitest
+
+import (
+	"fmt"
+	"net/http"
+
+	taapi "github.com/tigusigalpa/taapi-go"
+)
+
+// Response is a scripted reply. A zero Status means 200 OK.
+type Response struct {
+	Status int
+	Body   interface{}
+	Header http.Header
+}
+
+// status returns the HTTP status, defaulting to 200
+func (r Response) status() int {
+	if r.Status == 0 {
+		return http.StatusOK
+	}
+	return r.Status
+}
+
+// message returns the error message of an error response
+func (r Response) message() string {
+	if body, ok := r.Body.(map[string]interface{}); ok {
+		if msg, ok := body["error"].(string); ok {
+			return msg
+		}
+	}
+	return http.StatusText(r.status())
+}
+
+// JSON returns a 200 response with the given body
+func JSON(body interface{}) Response {
+	return Response{Status: http.StatusOK, Body: body}
+}
+
+// Value returns a single value response, e.g. {"value": 42}
+func Value(value float64) Response {
+	return JSON(map[string]interface{}{"value": value})
+}
+
+// Values returns a response with several named values, e.g. the
+// valueMACD, valueMACDSignal and valueMACDHist fields of MACD
+func Values(values map[string]float64) Response {
+	body := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		body[k] = v
+	}
+	return JSON(body)
+}
+
+// Series returns a backtracks response with one value per candle, newest
+// first as taapi.io returns them
+func Series(values ...float64) Response {
+	body := make([]map[string]interface{}, len(values))
+	for i, v := range values {
+		body[i] = map[string]interface{}{"value": v, "backtrack": i}
+	}
+	return JSON(body)
+}
+
+// Candles returns a /candle response
+func Candles(candles ...*taapi.Candle) Response {
+	if len(candles) == 1 {
+		return JSON(candles[0])
+	}
+	return JSON(candles)
+}
+
+// Error returns an error response with a taapi.io style {"error": message} body
+func Error(status int, message string) Response {
+	return Response{Status: status, Body: map[string]interface{}{"error": message}}
+}
+
+// BadRequest returns a 400 response
+func BadRequest(message string) Response {
+	return Error(http.StatusBadRequest, message)
+}
+
+// Unauthorized returns the 401 response sent for a wrong API secret
+func Unauthorized() Response {
+	return Error(http.StatusUnauthorized, "Invalid API key. Please check your secret and try again.")
+}
+
+// PlanLimit returns the 403 response sent when a feature needs a higher plan
+func PlanLimit() Response {
+	return Error(http.StatusForbidden, "This feature is not available on your plan. Please upgrade.")
+}
+
+// InvalidSymbol returns the 400 response sent for an unknown symbol
+func InvalidSymbol(symbol string) Response {
+	return BadRequest(fmt.Sprintf("Invalid symbol %s", symbol))
+}
+
+// TooManyRequests returns a 429 response
+func TooManyRequests() Response {
+	return Error(http.StatusTooManyRequests, "You have exceeded your rate limit. Please slow down.")
+}
+
+// ServerError returns a 500 response
+func ServerError() Response {
+	return Error(http.StatusInternalServerError, "Internal server error")
+}
+
+// MethodNotAllowed returns a 405 response
+func MethodNotAllowed() Response {
+	return Error(http.StatusMethodNotAllowed, "Method not allowed")
+}
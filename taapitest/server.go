@@ -0,0 +1,538 @@
+// Package taapitest provides an in-process fake taapi.io server for tests.
+//
+// The server implements GET /{indicator}, POST /bulk, POST /manual and
+// GET /exchange-symbols. Responses are scripted per indicator; requests are
+// recorded so tests can assert on what the client sent.
+//
+//	server := taapitest.NewServer()
+//	defer server.Close()
+//
+//	server.Handle(taapi.IndicatorRSI, taapitest.Value(42))
+//	client := server.Client()
+//	resp, err := client.Direct().Exchange(taapi.ExchangeBinance).Symbol("BTC/USDT").
+//		Interval(taapi.Interval1h).Indicator(taapi.IndicatorRSI).Get()
+//	server.AssertCalled(t, taapi.IndicatorRSI, 1)
+package taapitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	taapi "github.com/tigusigalpa/taapi-go"
+)
+
+// DefaultSecret is the API secret accepted by a server created with NewServer
+const DefaultSecret = "taapitest-secret"
+
+// Request is a recorded indicator call. Bulk requests are recorded once per
+// indicator with the construct fields merged into Params.
+type Request struct {
+	Method    string
+	Path      string
+	Indicator string
+	// Params holds query parameters or JSON fields, without the secret
+	Params map[string]interface{}
+	Secret string
+}
+
+// Param returns a parameter formatted as a string, or "" when absent
+func (r Request) Param(key string) string {
+	v, ok := r.Params[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// HandlerFunc computes the response to a call
+type HandlerFunc func(Request) Response
+
+// Server is a fake taapi.io server
+type Server struct {
+	// URL is the base URL of the server
+	URL string
+
+	server     *httptest.Server
+	mu         sync.Mutex
+	secret     string
+	handlers   map[string]HandlerFunc
+	scripts    map[string][]Response
+	symbols    map[string][]string
+	requests   []Request
+	latency    time.Duration
+	limited    int
+	retryAfter time.Duration
+}
+
+// NewServer starts a fake server accepting DefaultSecret
+func NewServer() *Server {
+	s := &Server{
+		secret:   DefaultSecret,
+		handlers: make(map[string]HandlerFunc),
+		scripts:  make(map[string][]Response),
+		symbols:  make(map[string][]string),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client using the server's URL and secret
+func (s *Server) Client() *taapi.Client {
+	return taapi.NewClient(s.Secret()).SetBaseURL(s.URL)
+}
+
+// Secret returns the accepted API secret
+func (s *Server) Secret() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.secret
+}
+
+// SetSecret changes the accepted API secret; an empty secret accepts any
+func (s *Server) SetSecret(secret string) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secret = secret
+	return s
+}
+
+// Handle scripts the responses to calls of an indicator. Responses are
+// returned in order and the last one is repeated.
+func (s *Server) Handle(indicator taapi.Indicator, responses ...Response) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[indicator.String()] = append(s.scripts[indicator.String()], responses...)
+	delete(s.handlers, indicator.String())
+	return s
+}
+
+// HandleFunc computes the responses to calls of an indicator
+func (s *Server) HandleFunc(indicator taapi.Indicator, handler HandlerFunc) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[indicator.String()] = handler
+	delete(s.scripts, indicator.String())
+	return s
+}
+
+// SetSymbols sets the symbols returned by /exchange-symbols for an exchange
+func (s *Server) SetSymbols(exchange taapi.Exchange, symbols ...string) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.symbols[exchange.String()] = symbols
+	return s
+}
+
+// SetLatency delays every response
+func (s *Server) SetLatency(latency time.Duration) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = latency
+	return s
+}
+
+// RateLimitNext answers the next n HTTP requests with 429 Too Many Requests
+// and the given Retry-After
+func (s *Server) RateLimitNext(n int, retryAfter time.Duration) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limited = n
+	s.retryAfter = retryAfter
+	return s
+}
+
+// Requests returns the recorded calls
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsFor returns the recorded calls of an indicator
+func (s *Server) RequestsFor(indicator taapi.Indicator) []Request {
+	var requests []Request
+	for _, r := range s.Requests() {
+		if r.Indicator == indicator.String() {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// LastRequest returns the most recent call
+func (s *Server) LastRequest() (Request, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requests) == 0 {
+		return Request{}, false
+	}
+	return s.requests[len(s.requests)-1], true
+}
+
+// Reset clears recorded calls, scripted responses and injected failures
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers = make(map[string]HandlerFunc)
+	s.scripts = make(map[string][]Response)
+	s.requests = nil
+	s.latency = 0
+	s.limited = 0
+}
+
+// AssertCalled fails the test unless the indicator was called times times
+func (s *Server) AssertCalled(tb testing.TB, indicator taapi.Indicator, times int) bool {
+	tb.Helper()
+	if n := len(s.RequestsFor(indicator)); n != times {
+		tb.Errorf("taapitest: %s called %d times, want %d", indicator, n, times)
+		return false
+	}
+	return true
+}
+
+// AssertNotCalled fails the test if the indicator was called
+func (s *Server) AssertNotCalled(tb testing.TB, indicator taapi.Indicator) bool {
+	tb.Helper()
+	return s.AssertCalled(tb, indicator, 0)
+}
+
+// AssertParam fails the test unless every call of the indicator carried the
+// parameter with the given value
+func (s *Server) AssertParam(tb testing.TB, indicator taapi.Indicator, key, want string) bool {
+	tb.Helper()
+	requests := s.RequestsFor(indicator)
+	if len(requests) == 0 {
+		tb.Errorf("taapitest: %s was not called", indicator)
+		return false
+	}
+	for i, r := range requests {
+		if got := r.Param(key); got != want {
+			tb.Errorf("taapitest: %s call %d: %s = %q, want %q", indicator, i, key, got, want)
+			return false
+		}
+	}
+	return true
+}
+
+// serveHTTP routes requests to the endpoint handlers
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.wait(r) {
+		return
+	}
+	if s.rateLimited(w) {
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/bulk":
+		s.serveBulk(w, r)
+	case r.Method == http.MethodPost && r.URL.Path == "/manual":
+		s.serveManual(w, r)
+	case r.Method == http.MethodGet && r.URL.Path == "/exchange-symbols":
+		s.serveSymbols(w, r)
+	case r.Method == http.MethodGet:
+		s.serveIndicator(w, r)
+	default:
+		writeJSON(w, MethodNotAllowed())
+	}
+}
+
+// wait applies the configured latency, returning false if the client gave up
+func (s *Server) wait(r *http.Request) bool {
+	s.mu.Lock()
+	latency := s.latency
+	s.mu.Unlock()
+	if latency <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+// rateLimited writes a 429 response while injected rate limits remain
+func (s *Server) rateLimited(w http.ResponseWriter) bool {
+	s.mu.Lock()
+	if s.limited <= 0 {
+		s.mu.Unlock()
+		return false
+	}
+	s.limited--
+	retryAfter := s.retryAfter
+	s.mu.Unlock()
+
+	resp := TooManyRequests()
+	if retryAfter > 0 {
+		seconds := int((retryAfter + time.Second - 1) / time.Second)
+		resp.Header = http.Header{"Retry-After": {strconv.Itoa(seconds)}}
+	}
+	writeJSON(w, resp)
+	return true
+}
+
+// authorized checks the secret and writes a 401 response when it is wrong
+func (s *Server) authorized(w http.ResponseWriter, secret string) bool {
+	s.mu.Lock()
+	expected := s.secret
+	s.mu.Unlock()
+
+	if expected != "" && secret != expected {
+		writeJSON(w, Unauthorized())
+		return false
+	}
+	return true
+}
+
+func (s *Server) serveIndicator(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if !s.authorized(w, query.Get("secret")) {
+		return
+	}
+
+	params := make(map[string]interface{}, len(query))
+	for key := range query {
+		if key != "secret" {
+			params[key] = query.Get(key)
+		}
+	}
+
+	req := Request{
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		Indicator: strings.TrimPrefix(r.URL.Path, "/"),
+		Params:    params,
+		Secret:    query.Get("secret"),
+	}
+	if resp, ok := checkTarget(req); !ok {
+		s.record(req)
+		writeJSON(w, resp)
+		return
+	}
+
+	writeJSON(w, s.call(req))
+}
+
+func (s *Server) serveManual(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	secret, _ := body["secret"].(string)
+	if !s.authorized(w, secret) {
+		return
+	}
+	delete(body, "secret")
+
+	indicator, _ := body["indicator"].(string)
+	req := Request{Method: r.Method, Path: r.URL.Path, Indicator: indicator, Params: body, Secret: secret}
+
+	if indicator == "" {
+		s.record(req)
+		writeJSON(w, BadRequest("indicator is required"))
+		return
+	}
+	if candles, _ := body["candles"].([]interface{}); len(candles) == 0 {
+		s.record(req)
+		writeJSON(w, BadRequest("candles are required"))
+		return
+	}
+
+	writeJSON(w, s.call(req))
+}
+
+func (s *Server) serveBulk(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	secret, _ := body["secret"].(string)
+	if !s.authorized(w, secret) {
+		return
+	}
+
+	var constructs []interface{}
+	switch c := body["construct"].(type) {
+	case []interface{}:
+		constructs = c
+	case map[string]interface{}:
+		constructs = []interface{}{c}
+	}
+	if len(constructs) == 0 {
+		writeJSON(w, BadRequest("construct is required"))
+		return
+	}
+
+	var items []map[string]interface{}
+	for _, raw := range constructs {
+		construct, _ := raw.(map[string]interface{})
+		indicators, _ := construct["indicators"].([]interface{})
+		if len(indicators) == 0 {
+			writeJSON(w, BadRequest("every construct needs at least one indicator"))
+			return
+		}
+
+		for _, rawIndicator := range indicators {
+			fields, _ := rawIndicator.(map[string]interface{})
+			params := make(map[string]interface{}, len(construct)+len(fields))
+			for k, v := range construct {
+				if k != "indicators" {
+					params[k] = v
+				}
+			}
+			for k, v := range fields {
+				params[k] = v
+			}
+
+			indicator, _ := fields["indicator"].(string)
+			req := Request{Method: r.Method, Path: r.URL.Path, Indicator: indicator, Params: params, Secret: secret}
+
+			id := req.Param("id")
+			if id == "" {
+				id = defaultBulkID(req)
+			}
+			item := map[string]interface{}{"id": id, "indicator": indicator}
+
+			resp, ok := checkTarget(req)
+			if ok {
+				resp = s.call(req)
+			} else {
+				s.record(req)
+			}
+			if resp.status() >= 400 {
+				item["result"] = map[string]interface{}{}
+				item["errors"] = []string{resp.message()}
+			} else {
+				item["result"] = resp.Body
+				item["errors"] = []string{}
+			}
+			items = append(items, item)
+		}
+	}
+
+	writeJSON(w, JSON(items))
+}
+
+func (s *Server) serveSymbols(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if !s.authorized(w, query.Get("secret")) {
+		return
+	}
+
+	s.mu.Lock()
+	symbols, ok := s.symbols[query.Get("exchange")]
+	s.mu.Unlock()
+	if !ok {
+		writeJSON(w, BadRequest(fmt.Sprintf("Exchange %s is not supported", query.Get("exchange"))))
+		return
+	}
+	writeJSON(w, JSON(symbols))
+}
+
+// call records a request and resolves its response
+func (s *Server) call(req Request) Response {
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	handler := s.handlers[req.Indicator]
+	script := s.scripts[req.Indicator]
+	var resp Response
+	if len(script) > 0 {
+		resp = script[0]
+		if len(script) > 1 {
+			s.scripts[req.Indicator] = script[1:]
+		}
+	}
+	s.mu.Unlock()
+
+	switch {
+	case handler != nil:
+		return handler(req)
+	case len(script) > 0:
+		return resp
+	case !taapi.Indicator(req.Indicator).IsValid():
+		return BadRequest(fmt.Sprintf("Indicator %s is not supported", req.Indicator))
+	default:
+		return Error(http.StatusNotImplemented, fmt.Sprintf("taapitest: no response scripted for %s", req.Indicator))
+	}
+}
+
+// record stores a request that was rejected before reaching a handler
+func (s *Server) record(req Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, req)
+}
+
+// checkTarget validates the symbol, interval and exchange of a call the way
+// taapi.io does
+func checkTarget(req Request) (Response, bool) {
+	if req.Param("symbol") == "" {
+		return BadRequest("symbol is required"), false
+	}
+	if req.Param("interval") == "" {
+		return BadRequest("interval is required"), false
+	}
+	if req.Param("exchange") == "" && taapi.AssetType(req.Param("type")).RequiresExchange() {
+		return BadRequest("exchange is required"), false
+	}
+	return Response{}, true
+}
+
+// defaultBulkID builds the id taapi.io assigns to bulk results without one
+func defaultBulkID(req Request) string {
+	parts := []string{req.Param("exchange"), req.Param("symbol"), req.Param("interval"), req.Indicator}
+	keys := make([]string, 0, len(req.Params))
+	for key := range req.Params {
+		switch key {
+		case "exchange", "symbol", "interval", "indicator", "type":
+		default:
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		parts = append(parts, req.Param(key))
+	}
+	return strings.Join(parts, "_")
+}
+
+// decodeBody decodes a JSON request body, writing a 400 response on failure
+func decodeBody(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, BadRequest("invalid JSON body"))
+		return nil, false
+	}
+	return body, true
+}
+
+// writeJSON writes a scripted response
+func writeJSON(w http.ResponseWriter, resp Response) {
+	for key, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(key, v)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.status())
+	json.NewEncoder(w).Encode(resp.Body)
+}
@@ -0,0 +1,197 @@
+package taapitest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	taapi "github.com/tigusigalpa/taapi-go"
+)
+
+func rsiRequest(client *taapi.Client) *taapi.DirectBuilder {
+	return client.Direct().
+		Exchange(taapi.ExchangeBinance).
+		Symbol("BTC/USDT").
+		Interval(taapi.Interval1h).
+		Indicator(taapi.IndicatorRSI)
+}
+
+func TestServerDirect(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Handle(taapi.IndicatorRSI, Value(30), Value(70))
+	client := server.Client()
+
+	for _, want := range []float64{30, 70, 70} {
+		resp, err := rsiRequest(client).WithParam("period", 14).Get()
+		require.NoError(t, err)
+		value, _ := resp.GetFloat("value")
+		assert.Equal(t, want, value)
+	}
+
+	server.AssertCalled(t, taapi.IndicatorRSI, 3)
+	server.AssertParam(t, taapi.IndicatorRSI, "period", "14")
+	server.AssertParam(t, taapi.IndicatorRSI, "symbol", "BTC/USDT")
+	server.AssertNotCalled(t, taapi.IndicatorMACD)
+
+	last, ok := server.LastRequest()
+	require.True(t, ok)
+	assert.Equal(t, DefaultSecret, last.Secret)
+	assert.NotContains(t, last.Params, "secret")
+}
+
+func TestServerHandleFunc(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.HandleFunc(taapi.IndicatorRSI, func(r Request) Response {
+		if r.Param("symbol") == "ETH/USDT" {
+			return InvalidSymbol("ETH/USDT")
+		}
+		return Value(50)
+	})
+	client := server.Client()
+
+	_, err := rsiRequest(client).Get()
+	require.NoError(t, err)
+
+	_, err = rsiRequest(client).Symbol("ETH/USDT").Get()
+	assert.True(t, errors.Is(err, taapi.ErrInvalidSymbol))
+}
+
+func TestServerErrors(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	_, err := rsiRequest(server.Client()).Get()
+	var apiErr *taapi.Error
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 501, apiErr.StatusCode)
+
+	_, err = rsiRequest(taapi.NewClient("wrong").SetBaseURL(server.URL)).Get()
+	assert.True(t, errors.Is(err, taapi.ErrUnauthorized))
+
+	server.Handle(taapi.IndicatorRSI, PlanLimit())
+	_, err = rsiRequest(server.Client()).Get()
+	assert.True(t, errors.Is(err, taapi.ErrPlanLimit))
+
+	server.Handle(taapi.IndicatorMACD, ServerError())
+	_, err = rsiRequest(server.Client()).Indicator(taapi.IndicatorMACD).Get()
+	assert.True(t, errors.Is(err, taapi.ErrServer))
+}
+
+func TestServerRateLimit(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Handle(taapi.IndicatorRSI, Value(50))
+	server.RateLimitNext(1, 2*time.Second)
+	client := server.Client()
+
+	_, err := rsiRequest(client).Get()
+	require.True(t, errors.Is(err, taapi.ErrRateLimited))
+	var rateErr *taapi.RateLimitError
+	require.True(t, errors.As(err, &rateErr))
+	assert.Equal(t, 2, rateErr.RetryAfter)
+
+	_, err = rsiRequest(client).Get()
+	assert.NoError(t, err)
+}
+
+func TestServerLatency(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Handle(taapi.IndicatorRSI, Value(50))
+	server.SetLatency(200 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := rsiRequest(server.Client()).GetContext(ctx)
+	assert.True(t, errors.Is(err, taapi.ErrTimeout) || errors.Is(err, taapi.ErrCanceled), err)
+}
+
+func TestServerBulk(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Handle(taapi.IndicatorRSI, Value(42))
+	server.Handle(taapi.IndicatorMACD, Values(map[string]float64{"valueMACD": 1, "valueMACDSignal": 2, "valueMACDHist": -1}))
+	client := server.Client()
+
+	resp, err := client.Bulk().
+		AddConstruct(client.Construct(taapi.ExchangeBinance, "BTC/USDT", taapi.Interval1h).
+			AddIndicator(taapi.IndicatorRSI, map[string]interface{}{"id": "rsi"}).
+			Add(taapi.MACD().ID("macd")).
+			AddIndicator(taapi.IndicatorEMA, nil)).
+		Execute()
+	require.NoError(t, err)
+	require.Equal(t, 3, resp.Count())
+
+	rsi := resp.FindByID("rsi")
+	require.NotNil(t, rsi)
+	assert.Equal(t, map[string]interface{}{"value": 42.0}, rsi.Data["result"])
+
+	ema := resp.FilterByIndicator("ema")
+	require.Len(t, ema, 1)
+	assert.Equal(t, "binance_BTC/USDT_1h_ema", ema[0].ID)
+	assert.NotEmpty(t, ema[0].Data["errors"])
+
+	server.AssertCalled(t, taapi.IndicatorMACD, 1)
+	server.AssertParam(t, taapi.IndicatorMACD, "exchange", "binance")
+}
+
+func TestServerManual(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Handle(taapi.IndicatorEMA, Value(101.5))
+	resp, err := server.Client().Manual(taapi.IndicatorEMA).
+		WithCandles([][]interface{}{{1609459200, 1, 2, 0.5, 1.5, 10}}).
+		WithParam("period", 9).
+		Execute()
+	require.NoError(t, err)
+
+	value, _ := resp.GetFloat("value")
+	assert.Equal(t, 101.5, value)
+	server.AssertParam(t, taapi.IndicatorEMA, "period", "9")
+}
+
+func TestServerCandlesAndSymbols(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Handle(taapi.IndicatorCANDLE, Candles(
+		taapi.NewCandle(time.Unix(1609459200, 0), 1, 2, 0.5, 1.5, 10),
+		taapi.NewCandle(time.Unix(1609462800, 0), 1.5, 2.5, 1, 2, 12),
+	))
+	server.SetSymbols(taapi.ExchangeBinance, "BTC/USDT", "ETH/USDT")
+	client := server.Client()
+
+	candles, err := client.Candles(taapi.ExchangeBinance, "BTC/USDT", taapi.Interval1h).Backtracks(2).Get()
+	require.NoError(t, err)
+	assert.Len(t, candles, 2)
+
+	require.NoError(t, client.RefreshMarkets(context.Background(), taapi.ExchangeBinance))
+	info, _ := client.Markets().Exchange(taapi.ExchangeBinance)
+	assert.Equal(t, []string{"BTC/USDT", "ETH/USDT"}, info.Symbols)
+}
+
+func TestServerReset(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Handle(taapi.IndicatorRSI, Value(50))
+	_, err := rsiRequest(server.Client()).Get()
+	require.NoError(t, err)
+
+	server.Reset()
+	assert.Empty(t, server.Requests())
+	_, err = rsiRequest(server.Client()).Get()
+	assert.Error(t, err)
+}